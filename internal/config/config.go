@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -25,6 +26,35 @@ type Config struct {
 	ServerPort string `mapstructure:"SERVER_PORT"`
 	ServerHost string `mapstructure:"SERVER_HOST"`
 
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     string `mapstructure:"SMTP_PORT"`
+	SMTPUser     string `mapstructure:"SMTP_USER"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+
+	StorageDriver          string `mapstructure:"STORAGE_DRIVER"`
+	StorageLocalPath       string `mapstructure:"STORAGE_LOCAL_PATH"`
+	StoragePublicURL       string `mapstructure:"STORAGE_PUBLIC_URL"`
+	StoragePrivatePath     string `mapstructure:"STORAGE_PRIVATE_PATH"`
+	S3Endpoint             string `mapstructure:"S3_ENDPOINT"`
+	S3Region               string `mapstructure:"S3_REGION"`
+	S3Bucket               string `mapstructure:"S3_BUCKET"`
+	S3PrivateBucket        string `mapstructure:"S3_PRIVATE_BUCKET"`
+	S3AccessKey            string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey            string `mapstructure:"S3_SECRET_KEY"`
+	S3UsePathStyle         bool   `mapstructure:"S3_USE_PATH_STYLE"`
+	UploadMaxImageBytes    int64  `mapstructure:"UPLOAD_MAX_IMAGE_BYTES"`
+	UploadMaxDocumentBytes int64  `mapstructure:"UPLOAD_MAX_DOCUMENT_BYTES"`
+
+	SalaryMinSampleSize int `mapstructure:"SALARY_MIN_SAMPLE_SIZE"`
+
+	CVFontPath     string `mapstructure:"CV_FONT_PATH"`
+	CVBoldFontPath string `mapstructure:"CV_FONT_BOLD_PATH"`
+
+	OpenToWorkExpiry time.Duration `mapstructure:"OPEN_TO_WORK_EXPIRY"`
+
+	CertificateReminderDays string `mapstructure:"CERTIFICATE_REMINDER_DAYS"`
+
 	Environment string `mapstructure:"ENV"`
 }
 
@@ -95,6 +125,42 @@ func setDefaults() {
 	viper.SetDefault("SERVER_PORT", "8080")
 	viper.SetDefault("SERVER_HOST", "0.0.0.0")
 
+	// Mail defaults (emails are logged when SMTP_HOST is empty)
+	viper.SetDefault("SMTP_HOST", "")
+	viper.SetDefault("SMTP_PORT", "587")
+	viper.SetDefault("SMTP_USER", "")
+	viper.SetDefault("SMTP_PASSWORD", "")
+	viper.SetDefault("SMTP_FROM", "no-reply@bdseeker.com")
+
+	// Storage defaults (local files are served under /uploads; private documents such as
+	// claim documents and resumes are kept apart and only downloaded through authorized endpoints)
+	viper.SetDefault("STORAGE_DRIVER", "local")
+	viper.SetDefault("STORAGE_LOCAL_PATH", "./uploads")
+	viper.SetDefault("STORAGE_PUBLIC_URL", "")
+	viper.SetDefault("STORAGE_PRIVATE_PATH", "./private-uploads")
+	viper.SetDefault("S3_ENDPOINT", "")
+	viper.SetDefault("S3_REGION", "us-east-1")
+	viper.SetDefault("S3_BUCKET", "")
+	viper.SetDefault("S3_PRIVATE_BUCKET", "")
+	viper.SetDefault("S3_ACCESS_KEY", "")
+	viper.SetDefault("S3_SECRET_KEY", "")
+	viper.SetDefault("S3_USE_PATH_STYLE", true)
+	viper.SetDefault("UPLOAD_MAX_IMAGE_BYTES", 5<<20)
+	viper.SetDefault("UPLOAD_MAX_DOCUMENT_BYTES", 10<<20)
+
+	// Salary defaults (aggregates are hidden until this many reports exist)
+	viper.SetDefault("SALARY_MIN_SAMPLE_SIZE", 5)
+
+	// CV defaults (a TrueType font with Bangla glyphs, such as Noto Sans Bengali; required in production)
+	viper.SetDefault("CV_FONT_PATH", "")
+	viper.SetDefault("CV_FONT_BOLD_PATH", "")
+
+	// Job search defaults (an open-to-work status lapses this long after it was last set or refreshed)
+	viper.SetDefault("OPEN_TO_WORK_EXPIRY", 90*24*time.Hour)
+
+	// Certificate defaults (developers are emailed when a certificate expires within each of these many days)
+	viper.SetDefault("CERTIFICATE_REMINDER_DAYS", "60,30,7")
+
 	// Environment default
 	viper.SetDefault("ENV", "development")
 }
@@ -105,6 +171,13 @@ func validateConfig(c *Config) error {
 		return fmt.Errorf("JWT_SECRET must be set in production environment")
 	}
 
+	if c.CVFontPath == "" {
+		if c.Environment == "production" {
+			return fmt.Errorf("CV_FONT_PATH must be set in production environment")
+		}
+		log.Println("WARNING: CV_FONT_PATH is not set, Bangla text in CVs renders as \"?\"")
+	}
+
 	if c.DBPassword == "postgres" && c.Environment == "production" {
 		log.Println("WARNING: Using default database password in production is not recommended")
 	}
@@ -20,6 +20,8 @@ func Connect(cfg *config.Config) error {
 	var err error
 	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Report unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -43,6 +45,14 @@ func Connect(cfg *config.Config) error {
 func Migrate() error {
 	log.Println("Running database migrations...")
 
+	// Skill links carry proficiency details, so they use custom join models
+	if err := DB.SetupJoinTable(&models.DeveloperProfile{}, "Technologies", &models.DeveloperTechnology{}); err != nil {
+		return fmt.Errorf("failed to set up developer_technologies: %w", err)
+	}
+	if err := DB.SetupJoinTable(&models.DeveloperProfile{}, "ProgrammingLanguages", &models.DeveloperLanguage{}); err != nil {
+		return fmt.Errorf("failed to set up developer_languages: %w", err)
+	}
+
 	// Migrate in order of dependencies
 	err := DB.AutoMigrate(
 		// Base models
@@ -52,17 +62,33 @@ func Migrate() error {
 
 		// Company models
 		&models.CompanyProfile{},
+		&models.CompanyOffice{},
+		&models.CompanySlugRedirect{},
+		&models.CompanyClaim{},
+		&models.CompanyOwnershipTransfer{},
+		&models.CompanyVerification{},
 		&models.CompanyRating{},
 		&models.CompanyReview{},
 		&models.CompanyReviewReaction{},
 		&models.CompanyReviewComment{},
 		&models.CompanyReviewReply{},
+		&models.CompanyReviewRevision{},
+		&models.CompanyFollower{},
+		&models.CompanyActivity{},
 
 		// Developer models
 		&models.DeveloperProfile{},
 		&models.DeveloperExperience{},
 		&models.DeveloperEducation{},
 		&models.DeveloperCertificate{},
+		&models.CertificateReminder{},
+		&models.DeveloperTechnology{},
+		&models.DeveloperLanguage{},
+		&models.DeveloperProject{},
+		&models.DeveloperProjectImage{},
+		&models.DeveloperResume{},
+		&models.SkillEndorsement{},
+		&models.ContactRequest{},
 
 		// Job models
 		&models.JobPost{},
@@ -70,18 +96,47 @@ func Migrate() error {
 		&models.PostComment{},
 		&models.CommentReply{},
 
+		// Salary and interview models
+		&models.SalaryReport{},
+		&models.InterviewReport{},
+
 		// Report model
 		&models.UserReport{},
+
+		// Moderation models
+		&models.ModerationEvent{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Data migrations
+	if err := migrateReviewContentToPros(); err != nil {
+		return fmt.Errorf("failed to migrate review content: %w", err)
+	}
+	if err := migrateReviewApprovalToStatus(); err != nil {
+		return fmt.Errorf("failed to migrate review status: %w", err)
+	}
+
 	log.Println("✓ Database migrations completed successfully")
 	return nil
 }
 
+// migrateReviewContentToPros maps legacy free-text review content onto the structured pros field
+func migrateReviewContentToPros() error {
+	return DB.Model(&models.CompanyReview{}).
+		Where("(pros IS NULL OR pros = '') AND content <> ''").
+		Update("pros", gorm.Expr("content")).Error
+}
+
+// migrateReviewApprovalToStatus marks reviews approved before moderation statuses existed as approved
+func migrateReviewApprovalToStatus() error {
+	return DB.Model(&models.CompanyReview{}).
+		Where("is_approved = ? AND status = ?", true, models.ModerationPending).
+		Update("status", models.ModerationApproved).Error
+}
+
 // GetDB returns the database instance
 func GetDB() *gorm.DB {
 	return DB
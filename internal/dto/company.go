@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// Company is the public view of a company profile.
+// It intentionally has no owner field so a preloaded User is never serialized.
+type Company struct {
+	ID                uint                   `json:"id"`
+	CompanyName       string                 `json:"company_name"`
+	Slug              string                 `json:"slug"`
+	Description       string                 `json:"description"`
+	Website           string                 `json:"website"`
+	Location          string                 `json:"location"`
+	LogoURL           string                 `json:"logo_url"`
+	LogoThumbnailURL  string                 `json:"logo_thumbnail_url"`
+	CoverURL          string                 `json:"cover_url"`
+	CoverThumbnailURL string                 `json:"cover_thumbnail_url"`
+	IsVerified        bool                   `json:"is_verified"`
+	VerifiedAt        *time.Time             `json:"verified_at,omitempty"`
+	IsClaimed         bool                   `json:"is_claimed"`
+	Technologies      []models.Technology    `json:"technologies"`
+	Offices           []models.CompanyOffice `json:"offices"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+// CompanyDetail is the public view of a single company with its ratings, approved reviews and followers
+type CompanyDetail struct {
+	Company
+	Rating        RatingScorecard `json:"rating"`
+	Reviews       []Review        `json:"reviews"`
+	FollowerCount int64           `json:"follower_count"`
+}
+
+// NewCompany converts a company model into its public representation
+func NewCompany(company *models.CompanyProfile) Company {
+	result := Company{
+		ID:                company.ID,
+		CompanyName:       company.CompanyName,
+		Slug:              company.Slug,
+		Description:       company.Description,
+		Website:           company.Website,
+		Location:          company.Location,
+		LogoURL:           company.LogoURL,
+		LogoThumbnailURL:  company.LogoThumbnailURL,
+		CoverURL:          company.CoverURL,
+		CoverThumbnailURL: company.CoverThumbnailURL,
+		IsVerified:        company.IsVerified,
+		VerifiedAt:        company.VerifiedAt,
+		IsClaimed:         company.UserID != nil,
+		Technologies:      company.Technologies,
+		Offices:           company.Offices,
+		CreatedAt:         company.CreatedAt,
+		UpdatedAt:         company.UpdatedAt,
+	}
+	if result.Technologies == nil {
+		result.Technologies = []models.Technology{}
+	}
+	if result.Offices == nil {
+		result.Offices = []models.CompanyOffice{}
+	}
+	return result
+}
+
+// NewCompanies converts a list of company models into their public representation
+func NewCompanies(companies []models.CompanyProfile) []Company {
+	result := make([]Company, 0, len(companies))
+	for i := range companies {
+		result = append(result, NewCompany(&companies[i]))
+	}
+	return result
+}
+
+// NewCompanyDetail converts a company model with its ratings and reviews into its public representation
+func NewCompanyDetail(company *models.CompanyProfile, followerCount int64) CompanyDetail {
+	return CompanyDetail{
+		Company:       NewCompany(company),
+		Rating:        newRatingScorecard(company.Ratings),
+		Reviews:       NewReviews(company.Reviews),
+		FollowerCount: followerCount,
+	}
+}
+
+func newRatingScorecard(ratings []models.CompanyRating) RatingScorecard {
+	scorecard := RatingScorecard{Distribution: map[int]int64{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}}
+	total := 0
+	for _, rating := range ratings {
+		scorecard.Count++
+		scorecard.Distribution[rating.Rating]++
+		total += rating.Rating
+	}
+	if scorecard.Count > 0 {
+		scorecard.Average = math.Round(float64(total)/float64(scorecard.Count)*100) / 100
+	}
+	return scorecard
+}
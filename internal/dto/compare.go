@@ -0,0 +1,140 @@
+package dto
+
+import (
+	"math"
+	"sort"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// RatingScorecard summarizes a company's star ratings
+type RatingScorecard struct {
+	Average      float64       `json:"average"`
+	Count        int64         `json:"count"`
+	Distribution map[int]int64 `json:"distribution"`
+}
+
+// SalaryRange is the range of monthly salaries advertised in a company's job posts
+type SalaryRange struct {
+	Min            float64 `json:"min"`
+	Max            float64 `json:"max"`
+	JobsWithSalary int64   `json:"jobs_with_salary"`
+}
+
+// ComparedCompany is one column of a company comparison
+type ComparedCompany struct {
+	Company            CompanySummary  `json:"company"`
+	Location           string          `json:"location"`
+	JobLocations       []string        `json:"job_locations"`
+	Rating             RatingScorecard `json:"rating"`
+	ReviewCount        int64           `json:"review_count"`
+	OpenJobCount       int64           `json:"open_job_count"`
+	SalaryRange        *SalaryRange    `json:"salary_range"`
+	Technologies       []string        `json:"technologies"`
+	UniqueTechnologies []string        `json:"unique_technologies"`
+}
+
+// CompanyComparison compares companies side by side.
+// Leaders holds the ID of the best company per metric, omitted when nobody has data or there is a tie.
+type CompanyComparison struct {
+	Companies          []ComparedCompany `json:"companies"`
+	SharedTechnologies []string          `json:"shared_technologies"`
+	Leaders            map[string]uint   `json:"leaders"`
+}
+
+// NewCompanyComparison builds a comparison of companies in the given order
+func NewCompanyComparison(companies []models.CompanyProfile, aggregates map[uint]*models.CompanyAggregates) CompanyComparison {
+	comparison := CompanyComparison{
+		Companies:          make([]ComparedCompany, 0, len(companies)),
+		SharedTechnologies: []string{},
+		Leaders:            make(map[string]uint),
+	}
+
+	// techCompanies counts how many of the compared companies use each technology
+	techCompanies := make(map[string]int)
+	for i := range companies {
+		for _, tech := range companies[i].Technologies {
+			techCompanies[tech.Name]++
+		}
+	}
+
+	for i := range companies {
+		company := &companies[i]
+		agg := aggregates[company.ID]
+
+		compared := ComparedCompany{
+			Company:      NewCompanySummary(company),
+			Location:     company.Location,
+			JobLocations: []string{},
+			Rating: RatingScorecard{
+				Average:      math.Round(agg.AverageRating*100) / 100,
+				Count:        agg.RatingCount,
+				Distribution: agg.RatingCounts,
+			},
+			ReviewCount:        agg.ReviewCount,
+			OpenJobCount:       agg.OpenJobCount,
+			Technologies:       []string{},
+			UniqueTechnologies: []string{},
+		}
+
+		if agg.JobLocations != nil {
+			compared.JobLocations = agg.JobLocations
+		}
+
+		if agg.JobsWithSalary > 0 {
+			compared.SalaryRange = &SalaryRange{
+				Min:            agg.SalaryMin,
+				Max:            agg.SalaryMax,
+				JobsWithSalary: agg.JobsWithSalary,
+			}
+		}
+
+		for _, tech := range company.Technologies {
+			compared.Technologies = append(compared.Technologies, tech.Name)
+			if techCompanies[tech.Name] == 1 {
+				compared.UniqueTechnologies = append(compared.UniqueTechnologies, tech.Name)
+			}
+		}
+		sort.Strings(compared.Technologies)
+		sort.Strings(compared.UniqueTechnologies)
+
+		comparison.Companies = append(comparison.Companies, compared)
+	}
+
+	for name, count := range techCompanies {
+		if count == len(companies) {
+			comparison.SharedTechnologies = append(comparison.SharedTechnologies, name)
+		}
+	}
+	sort.Strings(comparison.SharedTechnologies)
+
+	comparison.setLeader("rating", func(c *ComparedCompany) float64 { return c.Rating.Average })
+	comparison.setLeader("review_count", func(c *ComparedCompany) float64 { return float64(c.ReviewCount) })
+	comparison.setLeader("open_job_count", func(c *ComparedCompany) float64 { return float64(c.OpenJobCount) })
+	comparison.setLeader("salary_max", func(c *ComparedCompany) float64 {
+		if c.SalaryRange == nil {
+			return 0
+		}
+		return c.SalaryRange.Max
+	})
+
+	return comparison
+}
+
+func (comparison *CompanyComparison) setLeader(metric string, value func(*ComparedCompany) float64) {
+	var best float64
+	var leader uint
+	tie := false
+	for i := range comparison.Companies {
+		v := value(&comparison.Companies[i])
+		switch {
+		case v > best:
+			best, leader, tie = v, comparison.Companies[i].Company.ID, false
+		case v == best && v > 0:
+			tie = true
+		}
+	}
+	if leader != 0 && !tie {
+		comparison.Leaders[metric] = leader
+	}
+}
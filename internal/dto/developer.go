@@ -0,0 +1,412 @@
+package dto
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// DeveloperAccess describes what a viewer may see on a developer profile
+type DeveloperAccess struct {
+	Full       bool // the developer or an admin: hidden fields and privacy settings are included
+	CanContact bool // a company the developer applied to or accepted a contact request from
+	Recruiter  bool // a company account: job search preferences are included
+}
+
+// Tag is a technology or programming language
+type Tag struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// Skill is a technology or programming language with the developer's proficiency
+type Skill struct {
+	ID                uint               `json:"id"`
+	Name              string             `json:"name"`
+	Proficiency       string             `json:"proficiency"`
+	YearsOfExperience int                `json:"years_of_experience"`
+	IsPrimary         bool               `json:"is_primary"`
+	Endorsements      *SkillEndorsements `json:"endorsements,omitempty"` // technologies only
+}
+
+// SkillEndorsements counts the endorsements of a skill and shows the most relevant endorsers
+type SkillEndorsements struct {
+	Count        int        `json:"count"`
+	TopEndorsers []Endorser `json:"top_endorsers"`
+}
+
+// Endorser is someone who endorsed a skill
+type Endorser struct {
+	FullName           string `json:"full_name"`
+	DeveloperSlug      string `json:"developer_slug,omitempty"` // when their developer profile is visible to the viewer
+	AvatarThumbnailURL string `json:"avatar_thumbnail_url,omitempty"`
+	Proficiency        string `json:"proficiency,omitempty"` // their own proficiency in the skill
+	Context            string `json:"context,omitempty"`
+}
+
+// topEndorsersPerSkill is how many endorsers are shown for each skill
+const topEndorsersPerSkill = 3
+
+// DeveloperSummary is the public view of a developer used in lists.
+// It intentionally has no email field so nothing sensitive can be serialized.
+type DeveloperSummary struct {
+	ID                   uint       `json:"id"`
+	Slug                 string     `json:"slug"`
+	FullName             string     `json:"full_name"`
+	Bio                  string     `json:"bio"`
+	Location             string     `json:"location"`
+	AvatarURL            string     `json:"avatar_url"`
+	AvatarThumbnailURL   string     `json:"avatar_thumbnail_url"`
+	Technologies         []Tag      `json:"technologies"`
+	ProgrammingLanguages []Tag      `json:"programming_languages"`
+	JobSearch            *JobSearch `json:"job_search,omitempty"` // only shown to the developer and to companies
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// JobSearch is what a developer is looking for
+type JobSearch struct {
+	Status             string     `json:"status"`
+	DesiredRoles       []string   `json:"desired_roles"`
+	PreferredWorkModes []string   `json:"preferred_work_modes"`
+	PreferredLocations []string   `json:"preferred_locations"`
+	ExpectedSalaryMin  float64    `json:"expected_salary_min"` // monthly, in BDT; 0 when not given
+	ExpectedSalaryMax  float64    `json:"expected_salary_max"`
+	NoticePeriodDays   *int       `json:"notice_period_days"`
+	AvailableFrom      *time.Time `json:"available_from"` // null when available now
+	ExpiresAt          *time.Time `json:"expires_at"`     // null while not looking
+}
+
+// Experience is the public view of a work experience
+type Experience struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	CompanyName string     `json:"company_name,omitempty"` // empty when the developer hides their current employer
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	IsCurrent   bool       `json:"is_current"`
+	Description string     `json:"description"`
+}
+
+// Education is the public view of an education entry
+type Education struct {
+	ID           uint       `json:"id"`
+	Institution  string     `json:"institution"`
+	Degree       string     `json:"degree"`
+	FieldOfStudy string     `json:"field_of_study"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      *time.Time `json:"end_date"`
+	Grade        string     `json:"grade"`
+	Description  string     `json:"description"`
+}
+
+// Certificate is the public view of a certificate
+type Certificate struct {
+	ID                  uint       `json:"id"`
+	CertificateName     string     `json:"certificate_name"`
+	IssuingOrganization string     `json:"issuing_organization"`
+	IssueDate           time.Time  `json:"issue_date"`
+	ExpirationDate      *time.Time `json:"expiration_date"`
+	Status              string     `json:"status"` // valid or expired
+	CredentialID        string     `json:"credential_id"`
+	CertificateLink     string     `json:"certificate_link"`
+	Description         string     `json:"description"`
+}
+
+// ProjectImage is a picture in a project's gallery
+type ProjectImage struct {
+	ID           uint   `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Caption      string `json:"caption"`
+}
+
+// Project is the public view of a developer's project
+type Project struct {
+	ID           uint           `json:"id"`
+	Title        string         `json:"title"`
+	Description  string         `json:"description"`
+	Role         string         `json:"role"`
+	Technologies []Tag          `json:"technologies"`
+	RepoURL      string         `json:"repo_url"`
+	DemoURL      string         `json:"demo_url"`
+	StartDate    *time.Time     `json:"start_date"`
+	EndDate      *time.Time     `json:"end_date"`
+	Position     int            `json:"position"`
+	Images       []ProjectImage `json:"images"`
+}
+
+// DeveloperPrivacy holds a developer's privacy settings, shown only to the developer
+type DeveloperPrivacy struct {
+	Visibility          string `json:"visibility"`
+	HideEmail           bool   `json:"hide_email"`
+	HideCurrentEmployer bool   `json:"hide_current_employer"`
+	HideEducation       bool   `json:"hide_education"`
+}
+
+// Developer is the view of a developer profile, filtered by the viewer's access
+type Developer struct {
+	DeveloperSummary
+	Email        string            `json:"email,omitempty"`
+	Skills       []Skill           `json:"skills"`
+	Languages    []Skill           `json:"languages"`
+	Experiences  []Experience      `json:"experiences"`
+	Educations   []Education       `json:"educations"`
+	Certificates []Certificate     `json:"certificates"`
+	Projects     []Project         `json:"projects"`
+	Privacy      *DeveloperPrivacy `json:"privacy,omitempty"`
+}
+
+// NewDeveloperSummary converts a developer model into its list representation
+func NewDeveloperSummary(developer *models.DeveloperProfile) DeveloperSummary {
+	summary := DeveloperSummary{
+		ID:                   developer.ID,
+		Slug:                 developer.Slug,
+		FullName:             developer.User.FullName,
+		Bio:                  developer.Bio,
+		Location:             developer.Location,
+		AvatarURL:            developer.AvatarURL,
+		AvatarThumbnailURL:   developer.AvatarThumbnailURL,
+		Technologies:         make([]Tag, 0, len(developer.Technologies)),
+		ProgrammingLanguages: make([]Tag, 0, len(developer.ProgrammingLanguages)),
+		CreatedAt:            developer.CreatedAt,
+		UpdatedAt:            developer.UpdatedAt,
+	}
+	for _, tech := range developer.Technologies {
+		summary.Technologies = append(summary.Technologies, Tag{ID: tech.ID, Name: tech.Name})
+	}
+	for _, lang := range developer.ProgrammingLanguages {
+		summary.ProgrammingLanguages = append(summary.ProgrammingLanguages, Tag{ID: lang.ID, Name: lang.Name})
+	}
+	return summary
+}
+
+// NewDeveloperSummaries converts developer models into their list representation
+func NewDeveloperSummaries(developers []models.DeveloperProfile) []DeveloperSummary {
+	result := make([]DeveloperSummary, 0, len(developers))
+	for i := range developers {
+		result = append(result, NewDeveloperSummary(&developers[i]))
+	}
+	return result
+}
+
+// NewJobSearch converts a developer's job search preferences
+func NewJobSearch(developer *models.DeveloperProfile) *JobSearch {
+	return &JobSearch{
+		Status:             developer.OpenToWorkStatus,
+		DesiredRoles:       append([]string{}, developer.DesiredRoles...),
+		PreferredWorkModes: append([]string{}, developer.PreferredWorkModes...),
+		PreferredLocations: append([]string{}, developer.PreferredLocations...),
+		ExpectedSalaryMin:  developer.ExpectedSalaryMin,
+		ExpectedSalaryMax:  developer.ExpectedSalaryMax,
+		NoticePeriodDays:   developer.NoticePeriodDays,
+		AvailableFrom:      developer.AvailableFrom,
+		ExpiresAt:          developer.OpenToWorkExpiresAt,
+	}
+}
+
+// NewDeveloper converts a developer model into the view allowed by access
+func NewDeveloper(developer *models.DeveloperProfile, access DeveloperAccess) Developer {
+	result := Developer{
+		DeveloperSummary: NewDeveloperSummary(developer),
+		Skills:           make([]Skill, 0, len(developer.Skills)),
+		Languages:        make([]Skill, 0, len(developer.Languages)),
+		Experiences:      make([]Experience, 0, len(developer.Experiences)),
+		Educations:       []Education{},
+		Certificates:     make([]Certificate, 0, len(developer.Certificates)),
+		Projects:         NewProjects(developer.Projects),
+	}
+
+	if access.Full || access.Recruiter {
+		result.JobSearch = NewJobSearch(developer)
+	}
+	if access.Full || (access.CanContact && !developer.HideEmail) {
+		result.Email = developer.User.Email
+	}
+	if access.Full {
+		result.Privacy = &DeveloperPrivacy{
+			Visibility:          developer.Visibility,
+			HideEmail:           developer.HideEmail,
+			HideCurrentEmployer: developer.HideCurrentEmployer,
+			HideEducation:       developer.HideEducation,
+		}
+	}
+
+	for _, skill := range developer.Skills {
+		item := Skill{ID: skill.TechnologyID, Proficiency: skill.Proficiency, YearsOfExperience: skill.YearsOfExperience, IsPrimary: skill.IsPrimary}
+		if skill.Technology != nil {
+			item.Name = skill.Technology.Name
+		}
+		result.Skills = append(result.Skills, item)
+	}
+	for _, lang := range developer.Languages {
+		item := Skill{ID: lang.ProgrammingLanguageID, Proficiency: lang.Proficiency, YearsOfExperience: lang.YearsOfExperience, IsPrimary: lang.IsPrimary}
+		if lang.ProgrammingLanguage != nil {
+			item.Name = lang.ProgrammingLanguage.Name
+		}
+		result.Languages = append(result.Languages, item)
+	}
+
+	hideEmployer := developer.HideCurrentEmployer && !access.Full
+	for _, exp := range developer.Experiences {
+		item := Experience{
+			ID:          exp.ID,
+			Title:       exp.Title,
+			CompanyName: exp.CompanyName,
+			StartDate:   exp.StartDate,
+			EndDate:     exp.EndDate,
+			IsCurrent:   exp.EndDate == nil,
+			Description: exp.Description,
+		}
+		if item.IsCurrent && hideEmployer {
+			item.CompanyName = ""
+		}
+		result.Experiences = append(result.Experiences, item)
+	}
+
+	if !developer.HideEducation || access.Full {
+		for _, edu := range developer.Educations {
+			result.Educations = append(result.Educations, Education{
+				ID:           edu.ID,
+				Institution:  edu.Institution,
+				Degree:       edu.Degree,
+				FieldOfStudy: edu.FieldOfStudy,
+				StartDate:    edu.StartDate,
+				EndDate:      edu.EndDate,
+				Grade:        edu.Grade,
+				Description:  edu.Description,
+			})
+		}
+	}
+
+	now := time.Now()
+	for _, cert := range developer.Certificates {
+		status := models.CertificateValid
+		if cert.ExpirationDate != nil && cert.ExpirationDate.Before(now) {
+			status = models.CertificateExpired
+		}
+		result.Certificates = append(result.Certificates, Certificate{
+			ID:                  cert.ID,
+			CertificateName:     cert.CertificateName,
+			IssuingOrganization: cert.IssuingOrganization,
+			IssueDate:           cert.IssueDate,
+			ExpirationDate:      cert.ExpirationDate,
+			Status:              status,
+			CredentialID:        cert.CredentialID,
+			CertificateLink:     cert.CertificateLink,
+			Description:         cert.Description,
+		})
+	}
+
+	return result
+}
+
+// AddEndorsements sets the endorsements of every technology skill. Endorsers who know the skill best come
+// first, then the most recent; details must be ordered newest first.
+func (d *Developer) AddEndorsements(details []models.EndorsementDetail) {
+	rank := make(map[string]int, len(models.ProficiencyLevels))
+	for i, level := range models.ProficiencyLevels {
+		rank[level] = i + 1
+	}
+
+	bySkill := make(map[uint][]models.EndorsementDetail)
+	for _, detail := range details {
+		bySkill[detail.TechnologyID] = append(bySkill[detail.TechnologyID], detail)
+	}
+
+	for i := range d.Skills {
+		endorsements := bySkill[d.Skills[i].ID]
+		sort.SliceStable(endorsements, func(a, b int) bool {
+			return rank[endorsements[a].Proficiency] > rank[endorsements[b].Proficiency]
+		})
+
+		summary := &SkillEndorsements{Count: len(endorsements), TopEndorsers: []Endorser{}}
+		for _, endorsement := range endorsements {
+			if len(summary.TopEndorsers) == topEndorsersPerSkill {
+				break
+			}
+			summary.TopEndorsers = append(summary.TopEndorsers, Endorser{
+				FullName:           endorsement.FullName,
+				DeveloperSlug:      endorsement.DeveloperSlug,
+				AvatarThumbnailURL: endorsement.AvatarThumbnailURL,
+				Proficiency:        endorsement.Proficiency,
+				Context:            endorsement.Context,
+			})
+		}
+		d.Skills[i].Endorsements = summary
+	}
+}
+
+// NewProjectImage converts a gallery image into its public representation
+func NewProjectImage(image *models.DeveloperProjectImage) ProjectImage {
+	return ProjectImage{ID: image.ID, URL: image.URL, ThumbnailURL: image.ThumbnailURL, Caption: image.Caption}
+}
+
+// NewProject converts a project with its technologies and gallery into its public representation
+func NewProject(project *models.DeveloperProject) Project {
+	result := Project{
+		ID:           project.ID,
+		Title:        project.Title,
+		Description:  project.Description,
+		Role:         project.Role,
+		Technologies: make([]Tag, 0, len(project.Technologies)),
+		RepoURL:      project.RepoURL,
+		DemoURL:      project.DemoURL,
+		StartDate:    project.StartDate,
+		EndDate:      project.EndDate,
+		Position:     project.Position,
+		Images:       make([]ProjectImage, 0, len(project.Images)),
+	}
+	for _, tech := range project.Technologies {
+		result.Technologies = append(result.Technologies, Tag{ID: tech.ID, Name: tech.Name})
+	}
+	for i := range project.Images {
+		result.Images = append(result.Images, NewProjectImage(&project.Images[i]))
+	}
+	return result
+}
+
+// NewProjects converts projects, keeping their order
+func NewProjects(projects []models.DeveloperProject) []Project {
+	result := make([]Project, 0, len(projects))
+	for i := range projects {
+		result = append(result, NewProject(&projects[i]))
+	}
+	return result
+}
+
+// ContactRequest is the view of a contact request for the developer or the requesting company
+type ContactRequest struct {
+	ID          uint              `json:"id"`
+	Status      string            `json:"status"`
+	Message     string            `json:"message"`
+	RespondedAt *time.Time        `json:"responded_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Company     *CompanySummary   `json:"company,omitempty"`
+	Developer   *DeveloperSummary `json:"developer,omitempty"`
+}
+
+// NewContactRequests converts contact requests, including whichever side was preloaded
+func NewContactRequests(requests []models.ContactRequest) []ContactRequest {
+	result := make([]ContactRequest, 0, len(requests))
+	for _, request := range requests {
+		item := ContactRequest{
+			ID:          request.ID,
+			Status:      request.Status,
+			Message:     request.Message,
+			RespondedAt: request.RespondedAt,
+			CreatedAt:   request.CreatedAt,
+		}
+		if request.Company != nil {
+			company := NewCompanySummary(request.Company)
+			item.Company = &company
+		}
+		if request.Developer != nil {
+			developer := NewDeveloperSummary(request.Developer)
+			item.Developer = &developer
+		}
+		result = append(result, item)
+	}
+	return result
+}
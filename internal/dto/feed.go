@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// CompanySummary is the minimal public view of a company used in lists and feeds
+type CompanySummary struct {
+	ID               uint   `json:"id"`
+	CompanyName      string `json:"company_name"`
+	Slug             string `json:"slug"`
+	LogoThumbnailURL string `json:"logo_thumbnail_url,omitempty"`
+	IsVerified       bool   `json:"is_verified"`
+}
+
+// FeedItem is a company activity shown in a user's feed
+type FeedItem struct {
+	ID        uint           `json:"id"`
+	Type      string         `json:"type"`
+	SubjectID *uint          `json:"subject_id,omitempty"`
+	Summary   string         `json:"summary"`
+	Company   CompanySummary `json:"company"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// NewCompanySummary converts a company model into its summary representation
+func NewCompanySummary(company *models.CompanyProfile) CompanySummary {
+	return CompanySummary{
+		ID:               company.ID,
+		CompanyName:      company.CompanyName,
+		Slug:             company.Slug,
+		LogoThumbnailURL: company.LogoThumbnailURL,
+		IsVerified:       company.IsVerified,
+	}
+}
+
+// NewFeedItems converts company activities into feed items
+func NewFeedItems(activities []models.CompanyActivity) []FeedItem {
+	items := make([]FeedItem, 0, len(activities))
+	for i := range activities {
+		activity := &activities[i]
+		items = append(items, FeedItem{
+			ID:        activity.ID,
+			Type:      activity.Type,
+			SubjectID: activity.SubjectID,
+			Summary:   activity.Summary,
+			Company:   NewCompanySummary(&activity.Company),
+			CreatedAt: activity.CreatedAt,
+		})
+	}
+	return items
+}
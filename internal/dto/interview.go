@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// AnonymousCandidateLabel is shown in place of the author's name on anonymous interview reports
+const AnonymousCandidateLabel = "Anonymous Candidate"
+
+// InterviewJob identifies the listing an interview was for
+type InterviewJob struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+}
+
+// Interview is the public view of an interview report
+type Interview struct {
+	ID            uint          `json:"id"`
+	CompanyID     uint          `json:"company_id"`
+	Job           *InterviewJob `json:"job,omitempty"`
+	Role          string        `json:"role"`
+	Rounds        int           `json:"rounds"`
+	Process       string        `json:"process,omitempty"`
+	Questions     string        `json:"questions,omitempty"`
+	Difficulty    int           `json:"difficulty"`
+	Outcome       string        `json:"outcome"`
+	DurationDays  *int          `json:"duration_days,omitempty"`
+	InterviewedAt *time.Time    `json:"interviewed_at,omitempty"`
+	Author        ReviewAuthor  `json:"author"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// NewInterview converts an interview report into its public representation
+func NewInterview(report *models.InterviewReport) Interview {
+	result := Interview{
+		ID:            report.ID,
+		CompanyID:     report.CompanyID,
+		Role:          report.Role,
+		Rounds:        report.Rounds,
+		Process:       report.Process,
+		Questions:     report.Questions,
+		Difficulty:    report.Difficulty,
+		Outcome:       report.Outcome,
+		DurationDays:  report.DurationDays,
+		InterviewedAt: report.InterviewedAt,
+		Author:        ReviewAuthor{IsAnonymous: report.IsAnonymous},
+		CreatedAt:     report.CreatedAt,
+	}
+
+	if report.JobPost != nil {
+		result.Job = &InterviewJob{ID: report.JobPost.ID, Title: report.JobPost.Title}
+	}
+
+	if report.IsAnonymous {
+		result.Author.DisplayName = AnonymousCandidateLabel
+	} else {
+		userID := report.UserID
+		result.Author.UserID = &userID
+		result.Author.DisplayName = report.User.FullName
+	}
+
+	return result
+}
+
+// NewInterviews converts a list of interview reports into their public representation
+func NewInterviews(reports []models.InterviewReport) []Interview {
+	result := make([]Interview, 0, len(reports))
+	for i := range reports {
+		result = append(result, NewInterview(&reports[i]))
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// Job is the public view of a job post. The posting company is shown through Company, which has no owner field.
+type Job struct {
+	ID                 uint                  `json:"id"`
+	CompanyID          uint                  `json:"company_id"`
+	Title              string                `json:"title"`
+	Slug               string                `json:"slug"`
+	Description        string                `json:"description"`
+	SalaryMin          float64               `json:"salary_min"`
+	SalaryMax          float64               `json:"salary_max"`
+	ExperienceMinYears int                   `json:"experience_min_years"`
+	ExperienceMaxYears int                   `json:"experience_max_years"`
+	WorkMode           string                `json:"work_mode"`
+	Location           string                `json:"location"`
+	OfficeID           *uint                 `json:"office_id"`
+	Status             string                `json:"status"`
+	Company            *Company              `json:"company,omitempty"`
+	Office             *models.CompanyOffice `json:"office,omitempty"`
+	Reactions          []models.PostReaction `json:"reactions,omitempty"`
+	Comments           []models.PostComment  `json:"comments,omitempty"`
+	CreatedAt          time.Time             `json:"created_at"`
+	UpdatedAt          time.Time             `json:"updated_at"`
+}
+
+// NewJob converts a job post into its public representation; Company is left out when it was not loaded
+func NewJob(job *models.JobPost) Job {
+	result := Job{
+		ID:                 job.ID,
+		CompanyID:          job.CompanyID,
+		Title:              job.Title,
+		Slug:               job.Slug,
+		Description:        job.Description,
+		SalaryMin:          job.SalaryMin,
+		SalaryMax:          job.SalaryMax,
+		ExperienceMinYears: job.ExperienceMinYears,
+		ExperienceMaxYears: job.ExperienceMaxYears,
+		WorkMode:           job.WorkMode,
+		Location:           job.Location,
+		OfficeID:           job.OfficeID,
+		Status:             job.Status,
+		Office:             job.Office,
+		Reactions:          job.Reactions,
+		Comments:           job.Comments,
+		CreatedAt:          job.CreatedAt,
+		UpdatedAt:          job.UpdatedAt,
+	}
+	if job.Company.ID != 0 {
+		company := NewCompany(&job.Company)
+		result.Company = &company
+	}
+	return result
+}
+
+// NewJobs converts a list of job posts into their public representation
+func NewJobs(jobs []models.JobPost) []Job {
+	result := make([]Job, 0, len(jobs))
+	for i := range jobs {
+		result = append(result, NewJob(&jobs[i]))
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// AnonymousReviewerLabel is shown in place of the author's name on anonymous reviews
+const AnonymousReviewerLabel = "Anonymous Employee"
+
+// ReviewAuthor is the public view of a review's author.
+// It intentionally has no email field so nothing sensitive can be serialized.
+type ReviewAuthor struct {
+	UserID         *uint  `json:"user_id,omitempty"`
+	DisplayName    string `json:"display_name"`
+	JobTitle       string `json:"job_title,omitempty"`
+	YearsAtCompany *int   `json:"years_at_company,omitempty"`
+	IsAnonymous    bool   `json:"is_anonymous"`
+}
+
+// ReviewComment is the public view of an approved comment on a review
+type ReviewComment struct {
+	ID        uint      `json:"id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Review is the public view of a company review
+type Review struct {
+	ID                  uint            `json:"id"`
+	CompanyID           uint            `json:"company_id"`
+	Title               string          `json:"title"`
+	Pros                string          `json:"pros"`
+	Cons                string          `json:"cons"`
+	AdviceToManagement  string          `json:"advice_to_management,omitempty"`
+	EmploymentStatus    string          `json:"employment_status,omitempty"`
+	Author              ReviewAuthor    `json:"author"`
+	EmployerResponse    string          `json:"employer_response,omitempty"`
+	EmployerRespondedAt *time.Time      `json:"employer_responded_at,omitempty"`
+	Reactions           map[string]int  `json:"reactions"`
+	Comments            []ReviewComment `json:"comments"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+}
+
+// NewReview converts a review model into its public representation
+func NewReview(review *models.CompanyReview) Review {
+	result := Review{
+		ID:                  review.ID,
+		CompanyID:           review.CompanyID,
+		Title:               review.Title,
+		Pros:                review.Pros,
+		Cons:                review.Cons,
+		AdviceToManagement:  review.AdviceToManagement,
+		EmploymentStatus:    review.EmploymentStatus,
+		Author:              newReviewAuthor(review),
+		EmployerResponse:    review.EmployerResponse,
+		EmployerRespondedAt: review.EmployerRespondedAt,
+		Reactions:           make(map[string]int),
+		Comments:            make([]ReviewComment, 0, len(review.Comments)),
+		CreatedAt:           review.CreatedAt,
+		UpdatedAt:           review.UpdatedAt,
+	}
+
+	// Reviews written before structured fields existed only have free-text content
+	if result.Pros == "" {
+		result.Pros = review.Content
+	}
+
+	for _, reaction := range review.Reactions {
+		result.Reactions[reaction.Type]++
+	}
+
+	for _, comment := range review.Comments {
+		result.Comments = append(result.Comments, ReviewComment{
+			ID:        comment.ID,
+			Content:   comment.Content,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+
+	return result
+}
+
+// NewReviews converts a list of review models into their public representation
+func NewReviews(reviews []models.CompanyReview) []Review {
+	result := make([]Review, 0, len(reviews))
+	for i := range reviews {
+		result = append(result, NewReview(&reviews[i]))
+	}
+	return result
+}
+
+func newReviewAuthor(review *models.CompanyReview) ReviewAuthor {
+	author := ReviewAuthor{
+		JobTitle:       review.JobTitle,
+		YearsAtCompany: review.YearsAtCompany,
+		IsAnonymous:    review.IsAnonymous,
+	}
+
+	if review.IsAnonymous {
+		author.DisplayName = AnonymousReviewerLabel
+		return author
+	}
+
+	userID := review.UserID
+	author.UserID = &userID
+	author.DisplayName = review.User.FullName
+	return author
+}
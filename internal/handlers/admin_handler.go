@@ -13,17 +13,25 @@ import (
 )
 
 type AdminHandler struct {
-	userRepo    *repositories.UserRepository
-	companyRepo *repositories.CompanyRepository
-	reportRepo  *repositories.ReportRepository
+	userRepo        *repositories.UserRepository
+	companyRepo     *repositories.CompanyRepository
+	reportRepo      *repositories.ReportRepository
+	modRepo         *repositories.ModerationRepository
+	salaryRepo      *repositories.SalaryRepository
+	interviewRepo   *repositories.InterviewRepository
+	endorsementRepo *repositories.EndorsementRepository
 }
 
 func NewAdminHandler() *AdminHandler {
 	db := database.GetDB()
 	return &AdminHandler{
-		userRepo:    repositories.NewUserRepository(db),
-		companyRepo: repositories.NewCompanyRepository(db),
-		reportRepo:  repositories.NewReportRepository(db),
+		userRepo:        repositories.NewUserRepository(db),
+		companyRepo:     repositories.NewCompanyRepository(db),
+		reportRepo:      repositories.NewReportRepository(db),
+		modRepo:         repositories.NewModerationRepository(db),
+		salaryRepo:      repositories.NewSalaryRepository(db),
+		interviewRepo:   repositories.NewInterviewRepository(db),
+		endorsementRepo: repositories.NewEndorsementRepository(db),
 	}
 }
 
@@ -45,7 +53,7 @@ func (h *AdminHandler) GetStats(c *gin.Context) {
 	db.Model(&models.CompanyProfile{}).Count(&stats.TotalCompanies)
 	db.Model(&models.JobPost{}).Count(&stats.TotalJobs)
 	db.Model(&models.UserReport{}).Count(&stats.TotalReports)
-	db.Model(&models.CompanyReview{}).Where("is_approved = ?", false).Count(&stats.PendingReviews)
+	db.Model(&models.CompanyReview{}).Where("status = ?", models.ModerationPending).Count(&stats.PendingReviews)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Statistics retrieved successfully",
@@ -92,52 +100,148 @@ func (h *AdminHandler) ListUsers(c *gin.Context) {
 
 // ApproveReview approves a company review
 func (h *AdminHandler) ApproveReview(c *gin.Context) {
+	h.moderateReview(c, models.ModerationApproved, "", "Review approved successfully")
+}
+
+// RejectReview rejects a company review with a reason the author can see
+func (h *AdminHandler) RejectReview(c *gin.Context) {
+	h.moderateReview(c, models.ModerationRejected, "", "Review rejected successfully")
+}
+
+// RejectReviewLegacy keeps DELETE /admin/reviews/:id/reject, the route used before rejection reasons existed,
+// working for clients that send no reason
+func (h *AdminHandler) RejectReviewLegacy(c *gin.Context) {
+	h.moderateReview(c, models.ModerationRejected, "other", "Review rejected successfully")
+}
+
+// RequestReviewChanges sends a review back to its author for changes
+func (h *AdminHandler) RequestReviewChanges(c *gin.Context) {
+	h.moderateReview(c, models.ModerationNeedsChanges, "", "Review changes requested successfully")
+}
+
+// ListReviewModeration returns the moderation trail of a review
+func (h *AdminHandler) ListReviewModeration(c *gin.Context) {
+	h.listModeration(c, moderationContentReview, "Invalid review ID")
+}
+
+func (h *AdminHandler) listModeration(c *gin.Context, contentType, invalidIDMessage string) {
+	contentID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidIDMessage})
+		return
+	}
+
+	events, err := h.modRepo.ListEvents(contentType, contentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch moderation history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Moderation history retrieved successfully",
+		"data":    events,
+	})
+}
+
+// moderationRequest is the body accepted by moderation actions.
+// A reason code is required for every decision other than approval.
+type moderationRequest struct {
+	ReasonCode string `json:"reason_code" validate:"omitempty,oneof=spam offensive personal_info off_topic unverifiable other"`
+	Note       string `json:"note" validate:"max=2000"`
+}
+
+// Content types recorded in the moderation trail
+const (
+	moderationContentReview      = "review"
+	moderationContentSalary      = "salary"
+	moderationContentInterview   = "interview"
+	moderationContentEndorsement = "endorsement"
+)
+
+func (h *AdminHandler) moderateReview(c *gin.Context, status, defaultReasonCode, successMessage string) {
 	reviewID, err := getIDFromURL(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
 		return
 	}
 
+	moderation, event, ok := bindModeration(c, status, moderationContentReview, defaultReasonCode)
+	if !ok {
+		return
+	}
+
 	review, err := h.companyRepo.FindReviewByID(reviewID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
 		return
 	}
 
-	review.IsApproved = true
-	if err := h.companyRepo.UpdateReview(review); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to approve review"})
+	review.IsApproved = status == models.ModerationApproved
+	review.Moderation = *moderation
+	event.ContentID = review.ID
+
+	if err := h.modRepo.Record(review, event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate review"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Review approved successfully",
+		"message": successMessage,
 		"data":    review,
 	})
 }
 
-// RejectReview rejects/deletes a company review
-func (h *AdminHandler) RejectReview(c *gin.Context) {
-	reviewID, err := getIDFromURL(c)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
-		return
+// bindModeration parses a moderation decision and builds the new moderation state with its audit event.
+// defaultReasonCode is used when the request gives no reason_code; pass "" to require one.
+// It writes an error response and returns false when the request is invalid.
+func bindModeration(c *gin.Context, status, contentType, defaultReasonCode string) (*models.Moderation, *models.ModerationEvent, bool) {
+	adminID, _ := middleware.GetUserID(c)
+
+	var req moderationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return nil, nil, false
+		}
 	}
 
-	if err := h.companyRepo.DeleteReview(reviewID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reject review"})
-		return
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return nil, nil, false
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Review rejected successfully",
-		"data":    nil,
-	})
+	if req.ReasonCode == "" {
+		req.ReasonCode = defaultReasonCode
+	}
+	if status != models.ModerationApproved && req.ReasonCode == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason_code is required"})
+		return nil, nil, false
+	}
+
+	now := time.Now()
+	moderation := &models.Moderation{
+		Status:         status,
+		ReasonCode:     req.ReasonCode,
+		ModerationNote: req.Note,
+		ModeratedBy:    &adminID,
+		ModeratedAt:    &now,
+	}
+
+	event := &models.ModerationEvent{
+		ContentType: contentType,
+		ModeratorID: adminID,
+		Status:      status,
+		ReasonCode:  req.ReasonCode,
+		Note:        req.Note,
+	}
+
+	return moderation, event, true
 }
 
-// ListPendingReviews returns all pending reviews
+// ListPendingReviews returns reviews awaiting moderation (or in the status given by ?status=)
 func (h *AdminHandler) ListPendingReviews(c *gin.Context) {
 	page, limit := getPaginationFromQuery(c)
+	status := c.DefaultQuery("status", models.ModerationPending)
 
 	db := database.GetDB()
 	var reviews []models.CompanyReview
@@ -145,8 +249,8 @@ func (h *AdminHandler) ListPendingReviews(c *gin.Context) {
 
 	offset := (page - 1) * limit
 
-	db.Model(&models.CompanyReview{}).Where("is_approved = ?", false).Count(&total)
-	err := db.Where("is_approved = ?", false).
+	db.Model(&models.CompanyReview{}).Where("status = ?", status).Count(&total)
+	err := db.Where("status = ?", status).
 		Offset(offset).Limit(limit).
 		Preload("User").Preload("Company").
 		Find(&reviews).Error
@@ -281,3 +385,251 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 		"data":    nil,
 	})
 }
+
+// ListFlaggedSalaries returns salary reports awaiting moderation (or in the status given by ?status=)
+func (h *AdminHandler) ListFlaggedSalaries(c *gin.Context) {
+	page, limit := getPaginationFromQuery(c)
+	status := c.DefaultQuery("status", models.ModerationPending)
+
+	reports, total, err := h.salaryRepo.ListByStatus(page, limit, status, c.Query("outliers") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch salary reports"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       reports,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Salary reports retrieved successfully",
+		"data":    result,
+	})
+}
+
+// ApproveSalary counts a flagged salary report in the aggregates
+func (h *AdminHandler) ApproveSalary(c *gin.Context) {
+	h.moderateSalary(c, models.ModerationApproved, "Salary report approved successfully")
+}
+
+// RejectSalary excludes a salary report from the aggregates
+func (h *AdminHandler) RejectSalary(c *gin.Context) {
+	h.moderateSalary(c, models.ModerationRejected, "Salary report rejected successfully")
+}
+
+func (h *AdminHandler) moderateSalary(c *gin.Context, status, successMessage string) {
+	reportID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid salary report ID"})
+		return
+	}
+
+	moderation, event, ok := bindModeration(c, status, moderationContentSalary, "")
+	if !ok {
+		return
+	}
+
+	report, err := h.salaryRepo.FindByID(reportID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Salary report not found"})
+		return
+	}
+
+	report.Moderation = *moderation
+	event.ContentID = report.ID
+
+	if err := h.modRepo.Record(report, event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate salary report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": successMessage,
+		"data":    report,
+	})
+}
+
+// ListPendingInterviews returns interview reports awaiting moderation (or in the status given by ?status=)
+func (h *AdminHandler) ListPendingInterviews(c *gin.Context) {
+	page, limit := getPaginationFromQuery(c)
+	status := c.DefaultQuery("status", models.ModerationPending)
+
+	reports, total, err := h.interviewRepo.ListByStatus(page, limit, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interview reports"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       reports,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Pending interview reports retrieved successfully",
+		"data":    result,
+	})
+}
+
+// ApproveInterview publishes an interview report
+func (h *AdminHandler) ApproveInterview(c *gin.Context) {
+	h.moderateInterview(c, models.ModerationApproved, "Interview report approved successfully")
+}
+
+// RejectInterview rejects an interview report with a reason the author can see
+func (h *AdminHandler) RejectInterview(c *gin.Context) {
+	h.moderateInterview(c, models.ModerationRejected, "Interview report rejected successfully")
+}
+
+// RequestInterviewChanges sends an interview report back to its author for changes
+func (h *AdminHandler) RequestInterviewChanges(c *gin.Context) {
+	h.moderateInterview(c, models.ModerationNeedsChanges, "Interview report changes requested successfully")
+}
+
+// ListInterviewModeration returns the moderation trail of an interview report
+func (h *AdminHandler) ListInterviewModeration(c *gin.Context) {
+	h.listModeration(c, moderationContentInterview, "Invalid interview report ID")
+}
+
+func (h *AdminHandler) moderateInterview(c *gin.Context, status, successMessage string) {
+	reportID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interview report ID"})
+		return
+	}
+
+	moderation, event, ok := bindModeration(c, status, moderationContentInterview, "")
+	if !ok {
+		return
+	}
+
+	report, err := h.interviewRepo.FindByID(reportID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Interview report not found"})
+		return
+	}
+
+	report.Moderation = *moderation
+	event.ContentID = report.ID
+
+	if err := h.modRepo.Record(report, event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate interview report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": successMessage,
+		"data":    report,
+	})
+}
+
+// ListFlaggedEndorsements returns skill endorsements awaiting moderation (or in the status given by ?status=)
+func (h *AdminHandler) ListFlaggedEndorsements(c *gin.Context) {
+	page, limit := getPaginationFromQuery(c)
+	status := c.DefaultQuery("status", models.ModerationPending)
+
+	endorsements, total, err := h.endorsementRepo.ListByStatus(page, limit, status, c.Query("flagged") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch endorsements"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       endorsements,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Endorsements retrieved successfully",
+		"data":    result,
+	})
+}
+
+// ApproveEndorsement counts a flagged endorsement on the developer's profile
+func (h *AdminHandler) ApproveEndorsement(c *gin.Context) {
+	h.moderateEndorsement(c, models.ModerationApproved, "Endorsement approved successfully")
+}
+
+// RejectEndorsement keeps an endorsement off the developer's profile
+func (h *AdminHandler) RejectEndorsement(c *gin.Context) {
+	h.moderateEndorsement(c, models.ModerationRejected, "Endorsement rejected successfully")
+}
+
+func (h *AdminHandler) moderateEndorsement(c *gin.Context, status, successMessage string) {
+	endorsementID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid endorsement ID"})
+		return
+	}
+
+	moderation, event, ok := bindModeration(c, status, moderationContentEndorsement, "")
+	if !ok {
+		return
+	}
+
+	endorsement, err := h.endorsementRepo.FindByID(endorsementID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Endorsement not found"})
+		return
+	}
+
+	endorsement.Moderation = *moderation
+	event.ContentID = endorsement.ID
+
+	if err := h.modRepo.Record(endorsement, event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate endorsement"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": successMessage,
+		"data":    endorsement,
+	})
+}
+
+// CreateCompany seeds an unowned company page that its employer can claim later
+func (h *AdminHandler) CreateCompany(c *gin.Context) {
+	var req struct {
+		CompanyName string `json:"company_name" validate:"required,max=255"`
+		Description string `json:"description"`
+		Website     string `json:"website" validate:"max=255"`
+		Location    string `json:"location" validate:"max=255"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	company := &models.CompanyProfile{
+		CompanyName: req.CompanyName,
+		Description: req.Description,
+		Website:     req.Website,
+		Location:    req.Location,
+	}
+
+	if err := h.companyRepo.Create(company); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create company"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Company page created successfully",
+		"data":    company,
+	})
+}
@@ -3,19 +3,29 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/bishworup11/bdSeeker-backend/internal/database"
 	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
 	"github.com/bishworup11/bdSeeker-backend/internal/services"
 	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type AuthHandler struct {
-	authService *services.AuthService
+	authService   *services.AuthService
+	developerRepo *repositories.DeveloperRepository
+	companyRepo   *repositories.CompanyRepository
 }
 
 func NewAuthHandler(authService *services.AuthService) *AuthHandler {
-	return &AuthHandler{authService: authService}
+	db := database.GetDB()
+	return &AuthHandler{
+		authService:   authService,
+		developerRepo: repositories.NewDeveloperRepository(db),
+		companyRepo:   repositories.NewCompanyRepository(db),
+	}
 }
 
 // Register handles user registration
@@ -78,7 +88,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
-// GetMe returns the current authenticated user's information
+// GetMe returns the current authenticated user's information,
+// with the completeness score and missing items of their developer or company profile
 func (h *AuthHandler) GetMe(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
@@ -92,9 +103,22 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 		return
 	}
 
+	var completeness *repositories.Completeness
+	switch {
+	case user.DeveloperProfile != nil:
+		completeness, err = h.developerRepo.Completeness(user.DeveloperProfile.ID)
+	case user.CompanyProfile != nil:
+		completeness, err = h.companyRepo.Completeness(user.CompanyProfile.ID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute profile completeness"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "User retrieved successfully",
-		"data":    user,
+		"message":      "User retrieved successfully",
+		"data":         user,
+		"completeness": completeness,
 	})
 }
 
@@ -128,3 +152,20 @@ func getIDFromURL(c *gin.Context) (uint, error) {
 	}
 	return uint(id), nil
 }
+
+// getIDOrSlugFromURL returns the numeric ID in the :id param, or the slug when the param is not a number
+func getIDOrSlugFromURL(c *gin.Context) (uint, string) {
+	param := c.Param("id")
+	if id, err := strconv.ParseUint(param, 10, 32); err == nil {
+		return uint(id), ""
+	}
+	return 0, param
+}
+
+// redirectToSlug permanently redirects a URL ending in an old slug to the current one, keeping the query string
+func redirectToSlug(c *gin.Context, oldSlug, newSlug string) {
+	target := *c.Request.URL
+	target.Path = strings.TrimSuffix(target.Path, oldSlug) + newSlug
+	target.RawPath = ""
+	c.Redirect(http.StatusMovedPermanently, target.RequestURI())
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ClaimHandler struct {
+	claimService *services.ClaimService
+}
+
+func NewClaimHandler(claimService *services.ClaimService) *ClaimHandler {
+	return &ClaimHandler{claimService: claimService}
+}
+
+// SubmitClaim POST /api/v1/companies/:id/claims
+func (h *ClaimHandler) SubmitClaim(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	var req services.SubmitClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	claim, err := h.claimService.Submit(userID, companyID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Claim submitted successfully. Upload a document as proof or wait for admin review."
+	if claim.WorkEmail != "" {
+		message = "Claim submitted successfully. We sent a code to " + claim.WorkEmail + " to confirm your work email."
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message,
+		"data":    claim,
+	})
+}
+
+// ListMyClaims GET /api/v1/me/claims
+func (h *ClaimHandler) ListMyClaims(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	claims, err := h.claimService.ListMine(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch claims"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Claims retrieved successfully",
+		"data":    claims,
+	})
+}
+
+// VerifyClaimEmail POST /api/v1/me/claims/:id/verify-email
+func (h *ClaimHandler) VerifyClaimEmail(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	claimID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid claim ID"})
+		return
+	}
+
+	var req struct {
+		Code string `json:"code" validate:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	claim, err := h.claimService.VerifyEmail(userID, claimID, req.Code)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Work email verified successfully",
+		"data":    claim,
+	})
+}
+
+// UploadClaimDocument POST /api/v1/me/claims/:id/document
+func (h *ClaimHandler) UploadClaimDocument(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	claimID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid claim ID"})
+		return
+	}
+
+	data, filename, err := readUploadedFile(c, "file", h.claimService.MaxDocumentSize())
+	if err != nil {
+		if errors.Is(err, services.ErrFileTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Document must be at most %d bytes", h.claimService.MaxDocumentSize())})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file must be uploaded in the \"file\" field"})
+		return
+	}
+
+	claim, err := h.claimService.AttachDocument(c.Request.Context(), userID, claimID, filename, data)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Document uploaded successfully",
+		"data":    claim,
+	})
+}
+
+// ListClaims GET /api/v1/admin/claims
+func (h *ClaimHandler) ListClaims(c *gin.Context) {
+	page, limit := getPaginationFromQuery(c)
+	status := c.DefaultQuery("status", models.ClaimPending)
+
+	claims, total, err := h.claimService.List(page, limit, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch claims"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       claims,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Claims retrieved successfully",
+		"data":    result,
+	})
+}
+
+// GetClaimDocument GET /api/v1/admin/claims/:id/document
+func (h *ClaimHandler) GetClaimDocument(c *gin.Context) {
+	claimID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid claim ID"})
+		return
+	}
+
+	reader, claim, err := h.claimService.OpenDocument(c.Request.Context(), claimID)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Content-Type", claim.DocumentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": claim.DocumentName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, reader)
+}
+
+// ApproveClaim PUT /api/v1/admin/claims/:id/approve
+func (h *ClaimHandler) ApproveClaim(c *gin.Context) {
+	h.reviewClaim(c, h.claimService.Approve, "Claim approved and ownership transferred successfully")
+}
+
+// RejectClaim PUT /api/v1/admin/claims/:id/reject
+func (h *ClaimHandler) RejectClaim(c *gin.Context) {
+	h.reviewClaim(c, h.claimService.Reject, "Claim rejected successfully")
+}
+
+func (h *ClaimHandler) reviewClaim(c *gin.Context, review func(adminID, claimID uint, note string) (*models.CompanyClaim, error), successMessage string) {
+	adminID, _ := middleware.GetUserID(c)
+	claimID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid claim ID"})
+		return
+	}
+
+	var req struct {
+		Note string `json:"note" validate:"max=2000"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	claim, err := review(adminID, claimID, req.Note)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": successMessage,
+		"data":    claim,
+	})
+}
+
+// TransferCompanyOwnership PUT /api/v1/admin/companies/:id/owner
+// A null user_id releases the company so it can be claimed again.
+func (h *ClaimHandler) TransferCompanyOwnership(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	var req struct {
+		UserID *uint  `json:"user_id"`
+		Note   string `json:"note" validate:"required,max=2000"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	transfer, err := h.claimService.TransferOwnership(adminID, companyID, req.UserID, req.Note)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Company ownership updated successfully",
+		"data":    transfer,
+	})
+}
+
+// ListOwnershipTransfers GET /api/v1/admin/companies/:id/ownership
+func (h *ClaimHandler) ListOwnershipTransfers(c *gin.Context) {
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	transfers, err := h.claimService.Transfers(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch ownership history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Ownership history retrieved successfully",
+		"data":    transfers,
+	})
+}
+
+// claimError maps claim service errors to HTTP responses
+func claimError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrClaimNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Claim not found"})
+	case errors.Is(err, services.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrOwnerChanged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -3,8 +3,13 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
 	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
 	"github.com/bishworup11/bdSeeker-backend/internal/models"
 	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
@@ -13,13 +18,42 @@ import (
 	"gorm.io/gorm"
 )
 
+// reviewRequest is the body accepted when creating or editing a review
+type reviewRequest struct {
+	Title              string `json:"title" validate:"required,max=150"`
+	Pros               string `json:"pros" validate:"required,min=10,max=5000"`
+	Cons               string `json:"cons" validate:"required,min=10,max=5000"`
+	AdviceToManagement string `json:"advice_to_management" validate:"max=2000"`
+	Content            string `json:"content"` // legacy field, used as pros when pros is empty
+	Anonymous          bool   `json:"anonymous"`
+	JobTitle           string `json:"job_title" validate:"max=255"`
+	EmploymentStatus   string `json:"employment_status" validate:"required,oneof=current former"`
+	YearsAtCompany     *int   `json:"years_at_company" validate:"omitempty,min=0,max=60"`
+}
+
+func (req *reviewRequest) apply(review *models.CompanyReview) {
+	review.Title = req.Title
+	review.Pros = req.Pros
+	review.Cons = req.Cons
+	review.AdviceToManagement = req.AdviceToManagement
+	review.IsAnonymous = req.Anonymous
+	review.JobTitle = req.JobTitle
+	review.EmploymentStatus = req.EmploymentStatus
+	review.YearsAtCompany = req.YearsAtCompany
+}
+
 type CompanyHandler struct {
-	repo *repositories.CompanyRepository
+	repo         *repositories.CompanyRepository
+	techRepo     *repositories.TechRepository
+	activityRepo *repositories.ActivityRepository
 }
 
 func NewCompanyHandler() *CompanyHandler {
+	db := database.GetDB()
 	return &CompanyHandler{
-		repo: repositories.NewCompanyRepository(database.GetDB()),
+		repo:         repositories.NewCompanyRepository(db),
+		techRepo:     repositories.NewTechRepository(db),
+		activityRepo: repositories.NewActivityRepository(db),
 	}
 }
 
@@ -38,7 +72,7 @@ func (h *CompanyHandler) ListCompanies(c *gin.Context) {
 	}
 
 	result := utils.PaginationResult{
-		Data:       companies,
+		Data:       dto.NewCompanies(companies),
 		TotalCount: total,
 		Page:       page,
 		Limit:      limit,
@@ -51,23 +85,89 @@ func (h *CompanyHandler) ListCompanies(c *gin.Context) {
 	})
 }
 
-// GetCompany GET /api/v1/companies/:id
+// GetCompany GET /api/v1/companies/:id (ID or slug)
 func (h *CompanyHandler) GetCompany(c *gin.Context) {
-	id, err := getIDFromURL(c)
+	id, slug := getIDOrSlugFromURL(c)
+
+	var company *models.CompanyProfile
+	var err error
+	if slug == "" {
+		company, err = h.repo.FindByID(id)
+	} else {
+		company, err = h.repo.FindBySlug(slug)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if current, redirectErr := h.repo.FindSlugRedirect(slug); redirectErr == nil {
+				redirectToSlug(c, slug, current)
+				return
+			}
+		}
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
 		return
 	}
 
-	company, err := h.repo.FindByID(id)
+	followers, err := h.activityRepo.CountFollowers(company.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch company"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Company retrieved successfully",
-		"data":    company,
+		"data":    dto.NewCompanyDetail(company, followers),
+	})
+}
+
+// CompareCompanies GET /api/v1/companies/compare?ids=1,2,3
+func (h *CompanyHandler) CompareCompanies(c *gin.Context) {
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, part := range strings.Split(c.Query("ids"), ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ids must be a comma-separated list of company IDs"})
+			return
+		}
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			ids = append(ids, uint(id))
+		}
+	}
+
+	if len(ids) < 2 || len(ids) > 4 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Compare between 2 and 4 companies"})
+		return
+	}
+
+	companies, err := h.repo.FindManyForComparison(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch companies"})
+		return
+	}
+	if len(companies) != len(ids) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return
+	}
+
+	// Keep the order the companies were requested in
+	position := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.Slice(companies, func(i, j int) bool {
+		return position[companies[i].ID] < position[companies[j].ID]
+	})
+
+	aggregates, err := h.repo.ComparisonAggregates(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare companies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Companies compared successfully",
+		"data":    dto.NewCompanyComparison(companies, aggregates),
 	})
 }
 
@@ -87,6 +187,9 @@ func (h *CompanyHandler) CreateCompany(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
 		return
 	}
+	if !checkTechnologies(c, req.TechnologyIDs, h.techRepo.CountTechnologies) {
+		return
+	}
 
 	// Check if user already has a company profile
 	existing, err := h.repo.FindByUserID(userID)
@@ -100,21 +203,91 @@ func (h *CompanyHandler) CreateCompany(c *gin.Context) {
 	}
 
 	company := &models.CompanyProfile{
-		UserID:      userID,
+		UserID:      &userID,
 		CompanyName: req.CompanyName,
 		Description: req.Description,
 		Website:     req.Website,
 		Location:    req.Location,
 	}
 
-	if err := h.repo.Create(company); err != nil {
+	if err := h.repo.CreateWithTechnologies(company, req.TechnologyIDs); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create company"})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Company created successfully",
-		"data":    company,
+		"data":    dto.NewCompany(company),
+	})
+}
+
+// UpdateMyCompany PUT /api/v1/companies/me
+// Renaming the company changes its slug; the old slug keeps redirecting to the company.
+func (h *CompanyHandler) UpdateMyCompany(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req struct {
+		CompanyName *string `json:"company_name" validate:"omitempty,min=1,max=255"`
+		Description *string `json:"description"`
+		Website     *string `json:"website" validate:"omitempty,max=255"`
+		Location    *string `json:"location" validate:"omitempty,max=255"`
+
+		TechnologyIDs *[]uint `json:"technology_ids"` // replaces the tech stack when present
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+	if req.TechnologyIDs != nil && !checkTechnologies(c, *req.TechnologyIDs, h.techRepo.CountTechnologies) {
+		return
+	}
+
+	company, err := h.repo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company profile not found"})
+		return
+	}
+
+	nameChanged := false
+	if req.CompanyName != nil && *req.CompanyName != company.CompanyName {
+		company.CompanyName = *req.CompanyName
+		nameChanged = true
+	}
+	if req.Description != nil {
+		company.Description = *req.Description
+	}
+	if req.Website != nil && *req.Website != company.Website {
+		company.Website = *req.Website
+		// The verified badge belongs to the old domain
+		company.IsVerified = false
+		company.VerifiedAt = nil
+	}
+	if req.Location != nil {
+		company.Location = *req.Location
+	}
+
+	if req.TechnologyIDs != nil {
+		err = h.repo.UpdateWithTechnologies(company, nameChanged, *req.TechnologyIDs)
+	} else {
+		err = h.repo.UpdateProfile(company, nameChanged)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update company"})
+		return
+	}
+
+	recordActivity(h.activityRepo, company.ID, models.ActivityProfileUpdated, nil,
+		company.CompanyName+" updated its profile")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Company updated successfully",
+		"data":    dto.NewCompany(company),
 	})
 }
 
@@ -176,21 +349,28 @@ func (h *CompanyHandler) CreateReview(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		Content string `json:"content" validate:"required"`
-	}
-
+	var req reviewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
 		return
 	}
 
+	if req.Pros == "" {
+		req.Pros = req.Content
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
 	review := &models.CompanyReview{
 		CompanyID:  companyID,
 		UserID:     userID,
-		Content:    req.Content,
 		IsApproved: false,
+		Moderation: models.Moderation{Status: models.ModerationPending},
 	}
+	req.apply(review)
 
 	if err := h.repo.CreateReview(review); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create review"})
@@ -212,7 +392,46 @@ func (h *CompanyHandler) ListReviews(c *gin.Context) {
 	}
 
 	page, limit := getPaginationFromQuery(c)
-	reviews, total, err := h.repo.ListReviews(companyID, page, limit, true)
+
+	filters := make(map[string]interface{})
+
+	if status := c.Query("employment_status"); status != "" {
+		filters["employment_status"] = status
+	}
+	if jobTitle := c.Query("job_title"); jobTitle != "" {
+		filters["job_title"] = jobTitle
+	}
+	if minYears, err := strconv.Atoi(c.Query("min_years")); err == nil {
+		filters["min_years"] = minYears
+	}
+
+	reviews, total, err := h.repo.ListReviews(companyID, page, limit, true, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reviews"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       dto.NewReviews(reviews),
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reviews retrieved successfully",
+		"data":    result,
+	})
+}
+
+// ListMyReviews GET /api/v1/me/reviews
+func (h *CompanyHandler) ListMyReviews(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	page, limit := getPaginationFromQuery(c)
+	status := c.Query("status")
+
+	reviews, total, err := h.repo.ListReviewsByUser(userID, page, limit, status)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reviews"})
 		return
@@ -231,3 +450,104 @@ func (h *CompanyHandler) ListReviews(c *gin.Context) {
 		"data":    result,
 	})
 }
+
+// UpdateMyReview PUT /api/v1/me/reviews/:id
+// Edits are stored as revisions and the review goes back into the moderation queue.
+func (h *CompanyHandler) UpdateMyReview(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	reviewID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	var req reviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if req.Pros == "" {
+		req.Pros = req.Content
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	review, err := h.repo.UpdateReviewWithRevision(reviewID, userID, func(review *models.CompanyReview) {
+		req.apply(review)
+		review.IsApproved = false
+		review.Moderation = models.Moderation{Status: models.ModerationPending}
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review updated successfully (pending approval)",
+		"data":    review,
+	})
+}
+
+// RespondToReview PUT /api/v1/companies/me/reviews/:id/response
+func (h *CompanyHandler) RespondToReview(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	reviewID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	var req struct {
+		Response string `json:"response" validate:"required,max=5000"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	company, err := h.repo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User must have a company profile to respond to reviews"})
+		return
+	}
+
+	review, err := h.repo.FindReviewByID(reviewID)
+	if err != nil || review.CompanyID != company.ID || !review.IsApproved {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	}
+
+	// Followers are told about the first response only, not about later edits of it
+	firstResponse := review.EmployerRespondedAt == nil
+	now := time.Now()
+	review.EmployerResponse = req.Response
+	review.EmployerRespondedAt = &now
+	if err := h.repo.UpdateReview(review); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save response"})
+		return
+	}
+
+	if firstResponse {
+		recordActivity(h.activityRepo, company.ID, models.ActivityEmployerResponse, &review.ID,
+			company.CompanyName+" responded to a review")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Response saved successfully",
+		"data":    dto.NewReview(review),
+	})
+}
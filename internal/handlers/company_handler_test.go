@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testUser struct {
+	id       int64
+	email    string
+	fullName string
+}
+
+var (
+	companyOwner   = testUser{7, "owner@acme.test", "Olivia Owner"}
+	namedReviewer  = testUser{8, "named@example.test", "Nadia Named"}
+	anonReviewer   = testUser{9, "anon@example.test", "Anwar Anon"}
+	reviewTestTime = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+)
+
+// reviewedCompanyTables is a company owned by companyOwner with one named and one anonymous approved review
+func reviewedCompanyTables() map[string]fakeTable {
+	users := fakeTable{columns: []string{"id", "email", "password_hash", "full_name", "role", "created_at", "updated_at"}}
+	for _, user := range []testUser{companyOwner, namedReviewer, anonReviewer} {
+		users.rows = append(users.rows, []driver.Value{user.id, user.email, "hash", user.fullName, "developer", reviewTestTime, reviewTestTime})
+	}
+
+	return map[string]fakeTable{
+		"users": users,
+		"company_profiles": {
+			columns: []string{"id", "user_id", "company_name", "slug", "website", "created_at", "updated_at"},
+			rows:    [][]driver.Value{{int64(1), companyOwner.id, "Acme", "acme", "https://acme.test", reviewTestTime, reviewTestTime}},
+		},
+		"company_reviews": {
+			columns: []string{"id", "company_id", "user_id", "title", "pros", "cons", "is_anonymous", "job_title",
+				"years_at_company", "is_approved", "status", "created_at", "updated_at"},
+			rows: [][]driver.Value{
+				{int64(1), int64(1), namedReviewer.id, "Great team", "People", "Pay", false, "Engineer", int64(2), true, "approved", reviewTestTime, reviewTestTime},
+				{int64(2), int64(1), anonReviewer.id, "Long hours", "Learning", "Hours", true, "QA Engineer", int64(1), true, "approved", reviewTestTime, reviewTestTime},
+			},
+		},
+		"company_ratings": {
+			columns: []string{"id", "company_id", "user_id", "rating", "created_at", "updated_at"},
+			rows:    [][]driver.Value{{int64(1), int64(1), anonReviewer.id, int64(4), reviewTestTime, reviewTestTime}},
+		},
+	}
+}
+
+func serve(t *testing.T, method, pattern, target string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, pattern, handler)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("%s %s returned %d: %s", method, target, recorder.Code, recorder.Body.String())
+	}
+	return recorder
+}
+
+// assertNoUserLeak fails when a public response exposes user objects, emails, or who wrote the anonymous review
+func assertNoUserLeak(t *testing.T, body string) {
+	t.Helper()
+	for _, leak := range []string{`"email"`, `"user"`, `"password_hash"`, `"role"`, companyOwner.email, namedReviewer.email,
+		anonReviewer.email, companyOwner.fullName, anonReviewer.fullName} {
+		if strings.Contains(body, leak) {
+			t.Errorf("response leaks %s: %s", leak, body)
+		}
+	}
+}
+
+// assertReviewAuthors checks the named review credits its author and the anonymous one only shows the label
+func assertReviewAuthors(t *testing.T, reviews []map[string]interface{}) {
+	t.Helper()
+	if len(reviews) != 2 {
+		t.Fatalf("got %d reviews, want 2", len(reviews))
+	}
+	for _, review := range reviews {
+		author := review["author"].(map[string]interface{})
+		switch review["title"] {
+		case "Great team":
+			if author["display_name"] != namedReviewer.fullName {
+				t.Errorf("named review author = %v, want %s", author["display_name"], namedReviewer.fullName)
+			}
+		case "Long hours":
+			if author["display_name"] != "Anonymous Employee" {
+				t.Errorf("anonymous review author = %v, want the anonymous label", author["display_name"])
+			}
+			if _, ok := author["user_id"]; ok {
+				t.Errorf("anonymous review exposes user_id: %v", author)
+			}
+			if author["job_title"] != "QA Engineer" {
+				t.Errorf("anonymous review job_title = %v, want QA Engineer", author["job_title"])
+			}
+		default:
+			t.Errorf("unexpected review %v", review["title"])
+		}
+	}
+}
+
+func TestGetCompanyDoesNotLeakUsers(t *testing.T) {
+	useFakeDB(t, reviewedCompanyTables())
+	h := NewCompanyHandler()
+
+	recorder := serve(t, http.MethodGet, "/companies/:id", "/companies/1", h.GetCompany)
+	body := recorder.Body.String()
+	assertNoUserLeak(t, body)
+
+	var response struct {
+		Data struct {
+			CompanyName string                   `json:"company_name"`
+			IsClaimed   bool                     `json:"is_claimed"`
+			Reviews     []map[string]interface{} `json:"reviews"`
+			Rating      struct {
+				Count int `json:"count"`
+			} `json:"rating"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Data.CompanyName != "Acme" || !response.Data.IsClaimed || response.Data.Rating.Count != 1 {
+		t.Errorf("unexpected company: %s", body)
+	}
+	assertReviewAuthors(t, response.Data.Reviews)
+}
+
+func TestListReviewsDoesNotLeakUsers(t *testing.T) {
+	useFakeDB(t, reviewedCompanyTables())
+	h := NewCompanyHandler()
+
+	recorder := serve(t, http.MethodGet, "/companies/:id/reviews", "/companies/1/reviews", h.ListReviews)
+	assertNoUserLeak(t, recorder.Body.String())
+
+	var response struct {
+		Data struct {
+			Data       []map[string]interface{} `json:"data"`
+			TotalCount int                      `json:"total_count"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Data.TotalCount != 2 {
+		t.Errorf("total_count = %d, want 2", response.Data.TotalCount)
+	}
+	assertReviewAuthors(t, response.Data.Data)
+}
+
+func TestListCompaniesDoesNotLeakOwner(t *testing.T) {
+	useFakeDB(t, reviewedCompanyTables())
+	h := NewCompanyHandler()
+
+	recorder := serve(t, http.MethodGet, "/companies", "/companies", h.ListCompanies)
+	body := recorder.Body.String()
+	assertNoUserLeak(t, body)
+	if !strings.Contains(body, `"company_name":"Acme"`) {
+		t.Errorf("company missing from list: %s", body)
+	}
+}
+
+func TestRespondToReviewRequiresCompanyOwner(t *testing.T) {
+	tables := reviewedCompanyTables()
+	otherCompany := tables["company_profiles"]
+	otherCompany.rows = append(otherCompany.rows, []driver.Value{int64(2), anonReviewer.id, "Globex", "globex", "https://globex.test", reviewTestTime, reviewTestTime})
+	tables["company_profiles"] = otherCompany
+	useFakeDB(t, tables)
+	h := NewCompanyHandler()
+
+	const pattern = "/companies/me/reviews/:id/response"
+	body := `{"response":"Thanks for the feedback"}`
+	requests := []struct {
+		user testUser
+		want int
+	}{
+		{namedReviewer, http.StatusBadRequest}, // no company
+		{anonReviewer, http.StatusNotFound},    // owns another company
+		{companyOwner, http.StatusOK},
+	}
+	for _, req := range requests {
+		if code := serveAs(req.user.id, http.MethodPut, pattern, "/companies/me/reviews/1/response", body, h.RespondToReview).Code; code != req.want {
+			t.Errorf("response by %s returned %d, want %d", req.user.fullName, code, req.want)
+		}
+	}
+}
+
+func TestUpdateMyReviewOnlyEditsOwnReview(t *testing.T) {
+	useFakeDB(t, reviewedCompanyTables())
+	h := NewCompanyHandler()
+
+	const pattern = "/me/reviews/:id"
+	body := `{"title":"Great team","pros":"Supportive people","cons":"Below market pay","employment_status":"former"}`
+	if code := serveAs(anonReviewer.id, http.MethodPut, pattern, "/me/reviews/1", body, h.UpdateMyReview).Code; code != http.StatusNotFound {
+		t.Errorf("edit by another user returned %d, want 404", code)
+	}
+
+	recorder := serveAs(namedReviewer.id, http.MethodPut, pattern, "/me/reviews/1", body, h.UpdateMyReview)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("edit by the author returned %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Data struct {
+			EmploymentStatus string `json:"employment_status"`
+			IsApproved       bool   `json:"is_approved"`
+			Moderation       struct {
+				Status string `json:"status"`
+			} `json:"moderation"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Data.EmploymentStatus != "former" || response.Data.IsApproved || response.Data.Moderation.Status != "pending" {
+		t.Errorf("edited review = %+v, want it former and back in moderation", response.Data)
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ContactHandler struct {
+	repo          *repositories.ContactRepository
+	developerRepo *repositories.DeveloperRepository
+	companyRepo   *repositories.CompanyRepository
+}
+
+func NewContactHandler() *ContactHandler {
+	db := database.GetDB()
+	return &ContactHandler{
+		repo:          repositories.NewContactRepository(db),
+		developerRepo: repositories.NewDeveloperRepository(db),
+		companyRepo:   repositories.NewCompanyRepository(db),
+	}
+}
+
+// RequestContact POST /api/v1/developers/:id/contact-requests
+func (h *ContactHandler) RequestContact(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	developerID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid developer ID"})
+		return
+	}
+
+	var req struct {
+		Message string `json:"message" validate:"required,max=2000"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	company, err := h.companyRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User must have a company profile to contact developers"})
+		return
+	}
+
+	developer, err := h.developerRepo.FindByID(developerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+		return
+	}
+
+	existing, err := h.repo.FindOpen(company.ID, developer.ID)
+	if err == nil {
+		message := "A contact request is already pending"
+		if existing.Status == models.ContactRequestAccepted {
+			message = "The developer has already accepted a contact request"
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": message})
+		return
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check existing requests"})
+		return
+	}
+
+	request := &models.ContactRequest{
+		CompanyID:     company.ID,
+		DeveloperID:   developer.ID,
+		RequestedByID: userID,
+		Message:       req.Message,
+		Status:        models.ContactRequestPending,
+	}
+	if err := h.repo.Create(request); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send contact request"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Contact request sent successfully",
+		"data":    dto.NewContactRequests([]models.ContactRequest{*request})[0],
+	})
+}
+
+// ListMyContactRequests GET /api/v1/developers/me/contact-requests?status=pending
+func (h *ContactHandler) ListMyContactRequests(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	developer, err := h.developerRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer profile not found"})
+		return
+	}
+
+	requests, err := h.repo.ListForDeveloper(developer.ID, c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contact requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contact requests retrieved successfully",
+		"data":    dto.NewContactRequests(requests),
+	})
+}
+
+// AcceptContactRequest POST /api/v1/developers/me/contact-requests/:id/accept
+func (h *ContactHandler) AcceptContactRequest(c *gin.Context) {
+	h.respond(c, models.ContactRequestAccepted)
+}
+
+// DeclineContactRequest POST /api/v1/developers/me/contact-requests/:id/decline
+func (h *ContactHandler) DeclineContactRequest(c *gin.Context) {
+	h.respond(c, models.ContactRequestDeclined)
+}
+
+func (h *ContactHandler) respond(c *gin.Context, status string) {
+	userID, _ := middleware.GetUserID(c)
+	requestID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact request ID"})
+		return
+	}
+
+	developer, err := h.developerRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer profile not found"})
+		return
+	}
+
+	request, err := h.repo.FindByID(requestID)
+	if err != nil || request.DeveloperID != developer.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contact request not found"})
+		return
+	}
+	if request.Status != models.ContactRequestPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "Contact request has already been answered"})
+		return
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.RespondedAt = &now
+	if err := h.repo.Update(request); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contact request"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contact request " + status,
+		"data":    dto.NewContactRequests([]models.ContactRequest{*request})[0],
+	})
+}
+
+// ListCompanyContactRequests GET /api/v1/companies/me/contact-requests?status=accepted
+func (h *ContactHandler) ListCompanyContactRequests(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	company, err := h.companyRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company profile not found"})
+		return
+	}
+
+	requests, err := h.repo.ListForCompany(company.ID, c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contact requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contact requests retrieved successfully",
+		"data":    dto.NewContactRequests(requests),
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type CVHandler struct {
+	cvService  *services.CVService
+	developers *DeveloperHandler
+}
+
+func NewCVHandler(cvService *services.CVService) *CVHandler {
+	return &CVHandler{
+		cvService:  cvService,
+		developers: NewDeveloperHandler(),
+	}
+}
+
+// GetDeveloperCV GET /api/v1/developers/:id/cv.pdf?template=classic
+// Renders the profile as the caller may see it, so privacy settings apply to the CV as well.
+func (h *CVHandler) GetDeveloperCV(c *gin.Context) {
+	id, slug := getIDOrSlugFromURL(c)
+
+	var developer *models.DeveloperProfile
+	var err error
+	if slug == "" {
+		developer, err = h.developers.repo.FindByID(id)
+	} else {
+		developer, err = h.developers.repo.FindBySlug(slug)
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+		return
+	}
+
+	access, ok := h.developers.developerAccess(c, developer)
+	if !ok {
+		return
+	}
+
+	cv, err := h.cvService.Render(dto.NewDeveloper(developer, access), c.DefaultQuery("template", services.CVTemplateClassic))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownCVTemplate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate CV"})
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": developer.Slug + "-cv.pdf"}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, "application/pdf", cv)
+}
@@ -3,8 +3,12 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
 	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
 	"github.com/bishworup11/bdSeeker-backend/internal/models"
 	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
@@ -14,12 +18,21 @@ import (
 )
 
 type DeveloperHandler struct {
-	repo *repositories.DeveloperRepository
+	repo            *repositories.DeveloperRepository
+	techRepo        *repositories.TechRepository
+	companyRepo     *repositories.CompanyRepository
+	contactRepo     *repositories.ContactRepository
+	endorsementRepo *repositories.EndorsementRepository
 }
 
 func NewDeveloperHandler() *DeveloperHandler {
+	db := database.GetDB()
 	return &DeveloperHandler{
-		repo: repositories.NewDeveloperRepository(database.GetDB()),
+		repo:            repositories.NewDeveloperRepository(db),
+		techRepo:        repositories.NewTechRepository(db),
+		companyRepo:     repositories.NewCompanyRepository(db),
+		contactRepo:     repositories.NewContactRepository(db),
+		endorsementRepo: repositories.NewEndorsementRepository(db),
 	}
 }
 
@@ -27,16 +40,83 @@ func NewDeveloperHandler() *DeveloperHandler {
 func (h *DeveloperHandler) ListDevelopers(c *gin.Context) {
 	page, limit := getPaginationFromQuery(c)
 
-	var techIDs, langIDs []uint
+	filters := map[string]interface{}{"visibilities": visibleDeveloperProfiles(c)}
+	if userID, signedIn := middleware.GetUserID(c); signedIn {
+		role, _ := middleware.GetUserRole(c)
+		filters["viewer_id"] = userID
+		filters["show_hidden"] = role == "admin"
+	}
+
+	for _, key := range []string{"tech_ids", "lang_ids"} {
+		if value := c.Query(key); value != "" {
+			ids, err := parseIDList(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": key + " must be a comma-separated list of IDs"})
+				return
+			}
+			filters[key] = ids
+		}
+	}
+	for _, key := range []string{"min_experience", "max_experience"} {
+		if value := c.Query(key); value != "" {
+			years, err := strconv.ParseFloat(value, 64)
+			if err != nil || years < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": key + " must be a number of years"})
+				return
+			}
+			filters[key] = years
+		}
+	}
+	if value := c.Query("min_completeness"); value != "" {
+		minCompleteness, err := strconv.Atoi(value)
+		if err != nil || minCompleteness < 0 || minCompleteness > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_completeness must be a score from 0 to 100"})
+			return
+		}
+		filters["min_completeness"] = minCompleteness
+	}
+	if value := c.Query("certificate_valid"); value != "" {
+		validOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "certificate_valid must be true or false"})
+			return
+		}
+		filters["certificate_valid"] = validOnly
+	}
+	for _, key := range []string{"min_proficiency", "location", "degree", "field_of_study", "certificate", "search", "sort_by"} {
+		if value := c.Query(key); value != "" {
+			filters[key] = value
+		}
+	}
+
+	recruiter := canSeeJobSearch(c)
+	if !parseJobSearchFilters(c, filters, recruiter) {
+		return
+	}
+
+	developers, total, err := h.repo.List(page, limit, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch developers"})
+		return
+	}
 
-	developers, total, err := h.repo.List(page, limit, techIDs, langIDs)
+	facets, err := h.repo.Facets(filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch developers"})
 		return
 	}
 
+	summaries := dto.NewDeveloperSummaries(developers)
+	if recruiter {
+		for i := range summaries {
+			summaries[i].JobSearch = dto.NewJobSearch(&developers[i])
+		}
+	} else {
+		facets.OpenToWork = nil
+	}
+
 	result := utils.PaginationResult{
-		Data:       developers,
+		Data:       summaries,
 		TotalCount: total,
 		Page:       page,
 		Limit:      limit,
@@ -46,35 +126,222 @@ func (h *DeveloperHandler) ListDevelopers(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Developers retrieved successfully",
 		"data":    result,
+		"facets":  facets,
 	})
 }
 
-// GetDeveloper GET /api/v1/developers/:id
+// parseJobSearchFilters reads the open-to-work filters of a developer search into filters.
+// They are only available to companies; it writes the error response and returns false otherwise or when a value is invalid.
+func parseJobSearchFilters(c *gin.Context, filters map[string]interface{}, recruiter bool) bool {
+	keys := []string{"open_to_work", "open_to_work_status", "work_mode", "desired_role", "preferred_location",
+		"max_expected_salary", "max_notice_days", "available_by"}
+	for _, key := range keys {
+		if c.Query(key) != "" && !recruiter {
+			c.JSON(http.StatusForbidden, gin.H{"error": key + " is only available to companies"})
+			return false
+		}
+	}
+
+	if value := c.Query("open_to_work"); value != "" {
+		openToWork, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "open_to_work must be true or false"})
+			return false
+		}
+		filters["open_to_work"] = openToWork
+	}
+	if value := c.Query("open_to_work_status"); value != "" {
+		statuses, ok := parseEnumList(value, models.OpenToWorkStatuses)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "open_to_work_status must be a comma-separated list of " + strings.Join(models.OpenToWorkStatuses, ", ")})
+			return false
+		}
+		filters["open_to_work_statuses"] = statuses
+	}
+	if value := c.Query("work_mode"); value != "" {
+		workModes, ok := parseEnumList(value, models.WorkModes)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "work_mode must be a comma-separated list of " + strings.Join(models.WorkModes, ", ")})
+			return false
+		}
+		filters["work_modes"] = workModes
+	}
+	for _, key := range []string{"desired_role", "preferred_location"} {
+		if value := c.Query(key); value != "" {
+			filters[key] = value
+		}
+	}
+	if value := c.Query("max_expected_salary"); value != "" {
+		salary, err := strconv.ParseFloat(value, 64)
+		if err != nil || salary < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_expected_salary must be a monthly amount in BDT"})
+			return false
+		}
+		filters["max_expected_salary"] = salary
+	}
+	if value := c.Query("max_notice_days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_notice_days must be a number of days"})
+			return false
+		}
+		filters["max_notice_days"] = days
+	}
+	if value := c.Query("available_by"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "available_by must be a date such as 2025-01-31"})
+			return false
+		}
+		filters["available_by"] = date
+	}
+	return true
+}
+
+// parseEnumList parses a comma-separated list whose values must all be allowed, dropping duplicates
+func parseEnumList(value string, allowed []string) ([]string, bool) {
+	var values []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		valid := false
+		for _, option := range allowed {
+			if part == option {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, false
+		}
+		if !seen[part] {
+			seen[part] = true
+			values = append(values, part)
+		}
+	}
+	return values, true
+}
+
+// parseIDList parses a comma-separated list of IDs such as "1,2,3", dropping duplicates
+func parseIDList(value string) ([]uint, error) {
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids, nil
+}
+
+// GetDeveloper GET /api/v1/developers/:id (ID or slug)
 func (h *DeveloperHandler) GetDeveloper(c *gin.Context) {
-	id, err := getIDFromURL(c)
+	id, slug := getIDOrSlugFromURL(c)
+
+	var developer *models.DeveloperProfile
+	var err error
+	if slug == "" {
+		developer, err = h.repo.FindByID(id)
+	} else {
+		developer, err = h.repo.FindBySlug(slug)
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid developer ID"})
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+		return
+	}
+
+	access, ok := h.developerAccess(c, developer)
+	if !ok {
 		return
 	}
 
-	developer, err := h.repo.FindByID(id)
+	result := dto.NewDeveloper(developer, access)
+	endorsements, err := h.endorsementRepo.ListApproved(developer.ID, visibleDeveloperProfiles(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch endorsements"})
 		return
 	}
+	result.AddEndorsements(endorsements)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Developer retrieved successfully",
-		"data":    developer,
+		"data":    result,
 	})
 }
 
+// visibleDeveloperProfiles returns the profile visibilities the caller may see
+func visibleDeveloperProfiles(c *gin.Context) []string {
+	if _, signedIn := middleware.GetUserID(c); !signedIn {
+		return []string{models.VisibilityPublic}
+	}
+	if role, _ := middleware.GetUserRole(c); role == "company" || role == "admin" {
+		return []string{models.VisibilityPublic, models.VisibilitySignedIn, models.VisibilityCompanies}
+	}
+	return []string{models.VisibilityPublic, models.VisibilitySignedIn}
+}
+
+// canSeeJobSearch reports whether the caller may see and filter by developers' job search preferences
+func canSeeJobSearch(c *gin.Context) bool {
+	if _, signedIn := middleware.GetUserID(c); !signedIn {
+		return false
+	}
+	role, _ := middleware.GetUserRole(c)
+	return role == "company" || role == "admin"
+}
+
+// developerAccess decides what the caller may see on a developer profile.
+// It writes the error response and returns false when the profile is not visible to them.
+func (h *DeveloperHandler) developerAccess(c *gin.Context, developer *models.DeveloperProfile) (dto.DeveloperAccess, bool) {
+	userID, signedIn := middleware.GetUserID(c)
+	role, _ := middleware.GetUserRole(c)
+
+	if signedIn && (userID == developer.UserID || role == "admin") {
+		return dto.DeveloperAccess{Full: true}, true
+	}
+
+	visible := false
+	for _, visibility := range visibleDeveloperProfiles(c) {
+		if visibility == developer.Visibility {
+			visible = true
+			break
+		}
+	}
+	if !visible {
+		if !signedIn {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Sign in to view this profile"})
+		} else {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This profile is only visible to companies"})
+		}
+		return dto.DeveloperAccess{}, false
+	}
+
+	var access dto.DeveloperAccess
+	if role == "company" {
+		access.Recruiter = true
+		company, err := h.companyRepo.FindByUserID(userID)
+		if err == nil {
+			access.CanContact, err = h.contactRepo.HasContactAccess(company.ID, developer)
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch developer"})
+			return dto.DeveloperAccess{}, false
+		}
+	}
+	return access, true
+}
+
 // CreateDeveloper POST /api/v1/developers
 func (h *DeveloperHandler) CreateDeveloper(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 
 	var req struct {
-		Bio string `json:"bio"`
+		Bio      string `json:"bio"`
+		Location string `json:"location" validate:"max=255"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -82,6 +349,11 @@ func (h *DeveloperHandler) CreateDeveloper(c *gin.Context) {
 		return
 	}
 
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
 	// Check if user already has a developer profile
 	existing, err := h.repo.FindByUserID(userID)
 	if err == nil && existing.ID > 0 {
@@ -94,8 +366,9 @@ func (h *DeveloperHandler) CreateDeveloper(c *gin.Context) {
 	}
 
 	developer := &models.DeveloperProfile{
-		UserID: userID,
-		Bio:    req.Bio,
+		UserID:   userID,
+		Bio:      req.Bio,
+		Location: req.Location,
 	}
 
 	if err := h.repo.Create(developer); err != nil {
@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// experienceRequest is the body accepted when creating or editing a work experience
+type experienceRequest struct {
+	Title       string     `json:"title" validate:"required,max=255"`
+	CompanyName string     `json:"company_name" validate:"required,max=255"`
+	StartDate   *time.Time `json:"start_date" validate:"required"`
+	EndDate     *time.Time `json:"end_date" validate:"omitempty,gtfield=StartDate"` // null while current
+	Description string     `json:"description" validate:"max=5000"`
+}
+
+func (req *experienceRequest) apply(exp *models.DeveloperExperience) {
+	exp.Title = req.Title
+	exp.CompanyName = req.CompanyName
+	exp.StartDate = *req.StartDate
+	exp.EndDate = req.EndDate
+	exp.Description = req.Description
+}
+
+// educationRequest is the body accepted when creating or editing an education entry
+type educationRequest struct {
+	Institution  string     `json:"institution" validate:"required,max=255"`
+	Degree       string     `json:"degree" validate:"required,max=255"`
+	FieldOfStudy string     `json:"field_of_study" validate:"max=255"`
+	StartDate    *time.Time `json:"start_date" validate:"required"`
+	EndDate      *time.Time `json:"end_date" validate:"omitempty,gtfield=StartDate"`
+	Grade        string     `json:"grade" validate:"max=50"`
+	Description  string     `json:"description" validate:"max=5000"`
+}
+
+func (req *educationRequest) apply(edu *models.DeveloperEducation) {
+	edu.Institution = req.Institution
+	edu.Degree = req.Degree
+	edu.FieldOfStudy = req.FieldOfStudy
+	edu.StartDate = *req.StartDate
+	edu.EndDate = req.EndDate
+	edu.Grade = req.Grade
+	edu.Description = req.Description
+}
+
+// certificateRequest is the body accepted when creating or editing a certificate
+type certificateRequest struct {
+	CertificateName     string     `json:"certificate_name" validate:"required,max=255"`
+	IssuingOrganization string     `json:"issuing_organization" validate:"required,max=255"`
+	IssueDate           *time.Time `json:"issue_date" validate:"required"`
+	ExpirationDate      *time.Time `json:"expiration_date" validate:"omitempty,gtfield=IssueDate"`
+	CredentialID        string     `json:"credential_id" validate:"max=255"`
+	CertificateLink     string     `json:"certificate_link" validate:"omitempty,http_url,max=500"`
+	Description         string     `json:"description" validate:"max=5000"`
+}
+
+func (req *certificateRequest) apply(cert *models.DeveloperCertificate) {
+	cert.CertificateName = req.CertificateName
+	cert.IssuingOrganization = req.IssuingOrganization
+	cert.IssueDate = *req.IssueDate
+	cert.ExpirationDate = req.ExpirationDate
+	cert.CredentialID = req.CredentialID
+	cert.CertificateLink = req.CertificateLink
+	cert.Description = req.Description
+}
+
+// bindJSON parses and validates a request body, writing the error response on failure
+func bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return false
+	}
+
+	if validationErrors := utils.ValidateStruct(req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return false
+	}
+	return true
+}
+
+// myDeveloper loads the caller's developer profile, writing a 404 when they have none
+func (h *DeveloperHandler) myDeveloper(c *gin.Context) (*models.DeveloperProfile, bool) {
+	userID, _ := middleware.GetUserID(c)
+	developer, err := h.repo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer profile not found"})
+		return nil, false
+	}
+	return developer, true
+}
+
+// GetMyDeveloper GET /api/v1/developers/me
+func (h *DeveloperHandler) GetMyDeveloper(c *gin.Context) {
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	developer, err := h.repo.FindByID(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch developer profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Developer retrieved successfully",
+		"data":    dto.NewDeveloper(developer, dto.DeveloperAccess{Full: true}),
+	})
+}
+
+// UpdateMyDeveloper PATCH /api/v1/developers/me
+func (h *DeveloperHandler) UpdateMyDeveloper(c *gin.Context) {
+	var req struct {
+		Bio      *string `json:"bio" validate:"omitempty,max=5000"`
+		Location *string `json:"location" validate:"omitempty,max=255"`
+
+		Visibility          *string `json:"visibility" validate:"omitempty,oneof=public signed_in companies"`
+		HideEmail           *bool   `json:"hide_email"`
+		HideCurrentEmployer *bool   `json:"hide_current_employer"`
+		HideEducation       *bool   `json:"hide_education"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	if req.Bio != nil {
+		developer.Bio = *req.Bio
+	}
+	if req.Location != nil {
+		developer.Location = *req.Location
+	}
+	if req.Visibility != nil {
+		developer.Visibility = *req.Visibility
+	}
+	if req.HideEmail != nil {
+		developer.HideEmail = *req.HideEmail
+	}
+	if req.HideCurrentEmployer != nil {
+		developer.HideCurrentEmployer = *req.HideCurrentEmployer
+	}
+	if req.HideEducation != nil {
+		developer.HideEducation = *req.HideEducation
+	}
+
+	if err := h.repo.Update(developer); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update developer profile"})
+		return
+	}
+
+	developer, err := h.repo.FindByID(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch developer profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Developer profile updated successfully",
+		"data":    dto.NewDeveloper(developer, dto.DeveloperAccess{Full: true}),
+	})
+}
+
+// CreateExperience POST /api/v1/developers/me/experiences
+func (h *DeveloperHandler) CreateExperience(c *gin.Context) {
+	var req experienceRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	exp := &models.DeveloperExperience{DeveloperID: developer.ID}
+	req.apply(exp)
+
+	if err := h.repo.CreateExperience(exp); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create experience"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Experience created successfully",
+		"data":    exp,
+	})
+}
+
+// UpdateExperience PUT /api/v1/developers/me/experiences/:id
+func (h *DeveloperHandler) UpdateExperience(c *gin.Context) {
+	var req experienceRequest
+	exp, ok := h.findMyExperience(c)
+	if !ok || !bindJSON(c, &req) {
+		return
+	}
+	req.apply(exp)
+
+	if err := h.repo.UpdateExperience(exp); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update experience"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Experience updated successfully",
+		"data":    exp,
+	})
+}
+
+// DeleteExperience DELETE /api/v1/developers/me/experiences/:id
+func (h *DeveloperHandler) DeleteExperience(c *gin.Context) {
+	exp, ok := h.findMyExperience(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteExperience(exp.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete experience"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Experience deleted successfully"})
+}
+
+func (h *DeveloperHandler) findMyExperience(c *gin.Context) (*models.DeveloperExperience, bool) {
+	id, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid experience ID"})
+		return nil, false
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return nil, false
+	}
+
+	exp, err := h.repo.FindExperienceByID(id)
+	if err != nil || exp.DeveloperID != developer.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Experience not found"})
+		return nil, false
+	}
+	return exp, true
+}
+
+// CreateEducation POST /api/v1/developers/me/educations
+func (h *DeveloperHandler) CreateEducation(c *gin.Context) {
+	var req educationRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	edu := &models.DeveloperEducation{DeveloperID: developer.ID}
+	req.apply(edu)
+
+	if err := h.repo.CreateEducation(edu); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create education"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Education created successfully",
+		"data":    edu,
+	})
+}
+
+// UpdateEducation PUT /api/v1/developers/me/educations/:id
+func (h *DeveloperHandler) UpdateEducation(c *gin.Context) {
+	var req educationRequest
+	edu, ok := h.findMyEducation(c)
+	if !ok || !bindJSON(c, &req) {
+		return
+	}
+	req.apply(edu)
+
+	if err := h.repo.UpdateEducation(edu); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update education"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Education updated successfully",
+		"data":    edu,
+	})
+}
+
+// DeleteEducation DELETE /api/v1/developers/me/educations/:id
+func (h *DeveloperHandler) DeleteEducation(c *gin.Context) {
+	edu, ok := h.findMyEducation(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteEducation(edu.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete education"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Education deleted successfully"})
+}
+
+func (h *DeveloperHandler) findMyEducation(c *gin.Context) (*models.DeveloperEducation, bool) {
+	id, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid education ID"})
+		return nil, false
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return nil, false
+	}
+
+	edu, err := h.repo.FindEducationByID(id)
+	if err != nil || edu.DeveloperID != developer.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Education not found"})
+		return nil, false
+	}
+	return edu, true
+}
+
+// CreateCertificate POST /api/v1/developers/me/certificates
+func (h *DeveloperHandler) CreateCertificate(c *gin.Context) {
+	var req certificateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	cert := &models.DeveloperCertificate{DeveloperID: developer.ID}
+	req.apply(cert)
+
+	if err := h.repo.CreateCertificate(cert); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create certificate"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Certificate created successfully",
+		"data":    cert,
+	})
+}
+
+// UpdateCertificate PUT /api/v1/developers/me/certificates/:id
+func (h *DeveloperHandler) UpdateCertificate(c *gin.Context) {
+	var req certificateRequest
+	cert, ok := h.findMyCertificate(c)
+	if !ok || !bindJSON(c, &req) {
+		return
+	}
+	req.apply(cert)
+
+	if err := h.repo.UpdateCertificate(cert); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update certificate"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certificate updated successfully",
+		"data":    cert,
+	})
+}
+
+// DeleteCertificate DELETE /api/v1/developers/me/certificates/:id
+func (h *DeveloperHandler) DeleteCertificate(c *gin.Context) {
+	cert, ok := h.findMyCertificate(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteCertificate(cert.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete certificate"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Certificate deleted successfully"})
+}
+
+func (h *DeveloperHandler) findMyCertificate(c *gin.Context) (*models.DeveloperCertificate, bool) {
+	id, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid certificate ID"})
+		return nil, false
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return nil, false
+	}
+
+	cert, err := h.repo.FindCertificateByID(id)
+	if err != nil || cert.DeveloperID != developer.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Certificate not found"})
+		return nil, false
+	}
+	return cert, true
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/pkg/imaging"
+	"github.com/gin-gonic/gin"
+)
+
+// maxProjectImages limits the gallery of a single project
+const maxProjectImages = 10
+
+// projectRequest is the body accepted when creating or editing a project
+type projectRequest struct {
+	Title         string     `json:"title" validate:"required,max=255"`
+	Description   string     `json:"description" validate:"max=5000"`
+	Role          string     `json:"role" validate:"max=255"`
+	TechnologyIDs []uint     `json:"technology_ids" validate:"max=30"`
+	RepoURL       string     `json:"repo_url" validate:"omitempty,http_url,max=500"`
+	DemoURL       string     `json:"demo_url" validate:"omitempty,http_url,max=500"`
+	StartDate     *time.Time `json:"start_date"`
+	EndDate       *time.Time `json:"end_date" validate:"omitempty,gtfield=StartDate"` // null while ongoing
+}
+
+func (req *projectRequest) apply(project *models.DeveloperProject) {
+	project.Title = req.Title
+	project.Description = req.Description
+	project.Role = req.Role
+	project.RepoURL = req.RepoURL
+	project.DemoURL = req.DemoURL
+	project.StartDate = req.StartDate
+	project.EndDate = req.EndDate
+}
+
+type DeveloperProjectHandler struct {
+	mediaService *services.MediaService
+	developers   *DeveloperHandler
+}
+
+func NewDeveloperProjectHandler(mediaService *services.MediaService) *DeveloperProjectHandler {
+	return &DeveloperProjectHandler{
+		mediaService: mediaService,
+		developers:   NewDeveloperHandler(),
+	}
+}
+
+// CreateProject POST /api/v1/developers/me/projects
+func (h *DeveloperProjectHandler) CreateProject(c *gin.Context) {
+	var req projectRequest
+	if !bindJSON(c, &req) || !checkTechnologies(c, req.TechnologyIDs, h.developers.techRepo.CountTechnologies) {
+		return
+	}
+
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	project := &models.DeveloperProject{DeveloperID: developer.ID}
+	req.apply(project)
+
+	if err := h.developers.repo.CreateProject(project, req.TechnologyIDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Project created successfully",
+		"data":    dto.NewProject(project),
+	})
+}
+
+// UpdateProject PUT /api/v1/developers/me/projects/:id
+func (h *DeveloperProjectHandler) UpdateProject(c *gin.Context) {
+	var req projectRequest
+	project, ok := h.findMyProject(c)
+	if !ok || !bindJSON(c, &req) || !checkTechnologies(c, req.TechnologyIDs, h.developers.techRepo.CountTechnologies) {
+		return
+	}
+	req.apply(project)
+
+	if err := h.developers.repo.UpdateProject(project, req.TechnologyIDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project updated successfully",
+		"data":    dto.NewProject(project),
+	})
+}
+
+// DeleteProject DELETE /api/v1/developers/me/projects/:id
+func (h *DeveloperProjectHandler) DeleteProject(c *gin.Context) {
+	project, ok := h.findMyProject(c)
+	if !ok {
+		return
+	}
+
+	if err := h.developers.repo.DeleteProject(project); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project"})
+		return
+	}
+
+	for _, image := range project.Images {
+		h.mediaService.DeleteImage(c.Request.Context(), image.Key, services.ProjectImageVariants)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+// ReorderProjects PUT /api/v1/developers/me/projects/order
+// The body lists every project ID in the order they should appear on the profile.
+func (h *DeveloperProjectHandler) ReorderProjects(c *gin.Context) {
+	var req struct {
+		ProjectIDs []uint `json:"project_ids" validate:"required"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	projects, err := h.developers.repo.ListProjects(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		return
+	}
+
+	owned := make(map[uint]bool, len(projects))
+	for _, project := range projects {
+		owned[project.ID] = true
+	}
+	listed := make(map[uint]bool, len(req.ProjectIDs))
+	for _, id := range req.ProjectIDs {
+		if !owned[id] || listed[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "project_ids must list each of your projects once"})
+			return
+		}
+		listed[id] = true
+	}
+	if len(listed) != len(owned) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project_ids must list each of your projects once"})
+		return
+	}
+
+	if err := h.developers.repo.ReorderProjects(developer.ID, req.ProjectIDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder projects"})
+		return
+	}
+
+	projects, err = h.developers.repo.ListProjects(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Projects reordered successfully",
+		"data":    dto.NewProjects(projects),
+	})
+}
+
+// UploadProjectImage POST /api/v1/developers/me/projects/:id/images (multipart fields "file" and "caption")
+func (h *DeveloperProjectHandler) UploadProjectImage(c *gin.Context) {
+	project, ok := h.findMyProject(c)
+	if !ok {
+		return
+	}
+	if len(project.Images) >= maxProjectImages {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("A project can have at most %d images", maxProjectImages)})
+		return
+	}
+
+	data, _, err := readUploadedFile(c, "file", h.mediaService.MaxImageSize())
+	if err != nil {
+		if errors.Is(err, services.ErrFileTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Image must be at most %d bytes", h.mediaService.MaxImageSize())})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file must be uploaded in the \"file\" field"})
+		return
+	}
+	caption := strings.TrimSpace(c.PostForm("caption"))
+	if len([]rune(caption)) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caption must be at most 255 characters"})
+		return
+	}
+
+	prefix := fmt.Sprintf("developers/%d/projects/%d", project.DeveloperID, project.ID)
+	stored, err := h.mediaService.StoreImage(c.Request.Context(), prefix, data, services.ProjectImageVariants)
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupportedType) || errors.Is(err, imaging.ErrTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store image"})
+		return
+	}
+
+	image := &models.DeveloperProjectImage{
+		ProjectID:    project.ID,
+		URL:          stored.URLs["full"],
+		ThumbnailURL: stored.URLs["thumb"],
+		Key:          stored.Key,
+		Caption:      caption,
+	}
+	if err := h.developers.repo.CreateProjectImage(image); err != nil {
+		h.mediaService.DeleteImage(c.Request.Context(), stored.Key, services.ProjectImageVariants)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save image"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Image uploaded successfully",
+		"data":    dto.NewProjectImage(image),
+	})
+}
+
+// DeleteProjectImage DELETE /api/v1/developers/me/projects/:id/images/:image_id
+func (h *DeveloperProjectHandler) DeleteProjectImage(c *gin.Context) {
+	project, ok := h.findMyProject(c)
+	if !ok {
+		return
+	}
+
+	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid image ID"})
+		return
+	}
+
+	var image *models.DeveloperProjectImage
+	for i := range project.Images {
+		if project.Images[i].ID == uint(imageID) {
+			image = &project.Images[i]
+		}
+	}
+	if image == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+		return
+	}
+
+	if err := h.developers.repo.DeleteProjectImage(image.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete image"})
+		return
+	}
+	h.mediaService.DeleteImage(c.Request.Context(), image.Key, services.ProjectImageVariants)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Image deleted successfully"})
+}
+
+func (h *DeveloperProjectHandler) findMyProject(c *gin.Context) (*models.DeveloperProject, bool) {
+	id, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return nil, false
+	}
+
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return nil, false
+	}
+
+	project, err := h.developers.repo.FindProjectByID(id)
+	if err != nil || project.DeveloperID != developer.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return nil, false
+	}
+	return project, true
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// skillRequest describes one technology or programming language in a skill set
+type skillRequest struct {
+	ID                uint   `json:"id" validate:"required"`
+	Proficiency       string `json:"proficiency" validate:"required,oneof=beginner intermediate advanced expert"`
+	YearsOfExperience int    `json:"years_of_experience" validate:"min=0,max=60"`
+	IsPrimary         bool   `json:"is_primary"`
+}
+
+type skillSetRequest struct {
+	Skills []skillRequest `json:"skills" validate:"max=50,dive"`
+}
+
+// bindSkillSet parses a skill set and checks every ID exists exactly once.
+// count reports how many of the given IDs exist.
+func bindSkillSet(c *gin.Context, count func(ids []uint) (int64, error)) ([]skillRequest, bool) {
+	var req skillSetRequest
+	if !bindJSON(c, &req) {
+		return nil, false
+	}
+	if !checkSkills(c, req.Skills, count) {
+		return nil, false
+	}
+	return req.Skills, true
+}
+
+// checkSkills checks every skill ID exists exactly once, writing the error response on failure
+func checkSkills(c *gin.Context, skills []skillRequest, count func(ids []uint) (int64, error)) bool {
+	ids := make([]uint, 0, len(skills))
+	seen := make(map[uint]bool, len(skills))
+	for _, skill := range skills {
+		if seen[skill.ID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Each skill can only be listed once"})
+			return false
+		}
+		seen[skill.ID] = true
+		ids = append(ids, skill.ID)
+	}
+
+	if len(ids) > 0 {
+		found, err := count(ids)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check skills"})
+			return false
+		}
+		if found != int64(len(ids)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more skills do not exist"})
+			return false
+		}
+	}
+	return true
+}
+
+// checkTechnologies rejects technology IDs that are repeated or do not exist, writing the error response
+func checkTechnologies(c *gin.Context, ids []uint, count func(ids []uint) (int64, error)) bool {
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Each technology can only be listed once"})
+			return false
+		}
+		seen[id] = true
+	}
+
+	if len(ids) > 0 {
+		found, err := count(ids)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check technologies"})
+			return false
+		}
+		if found != int64(len(ids)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more technologies do not exist"})
+			return false
+		}
+	}
+	return true
+}
+
+// ReplaceMySkills PUT /api/v1/developers/me/skills
+func (h *DeveloperHandler) ReplaceMySkills(c *gin.Context) {
+	requested, ok := bindSkillSet(c, h.techRepo.CountTechnologies)
+	if !ok {
+		return
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	skills := make([]models.DeveloperTechnology, 0, len(requested))
+	for _, skill := range requested {
+		skills = append(skills, models.DeveloperTechnology{
+			DeveloperProfileID: developer.ID,
+			TechnologyID:       skill.ID,
+			Proficiency:        skill.Proficiency,
+			YearsOfExperience:  skill.YearsOfExperience,
+			IsPrimary:          skill.IsPrimary,
+		})
+	}
+
+	if err := h.repo.ReplaceSkills(developer.ID, skills); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update skills"})
+		return
+	}
+
+	skills, err := h.repo.ListSkills(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch skills"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skills updated successfully",
+		"data":    skills,
+	})
+}
+
+// ReplaceMyLanguages PUT /api/v1/developers/me/languages
+func (h *DeveloperHandler) ReplaceMyLanguages(c *gin.Context) {
+	requested, ok := bindSkillSet(c, h.techRepo.CountLanguages)
+	if !ok {
+		return
+	}
+
+	developer, ok := h.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	languages := make([]models.DeveloperLanguage, 0, len(requested))
+	for _, skill := range requested {
+		languages = append(languages, models.DeveloperLanguage{
+			DeveloperProfileID:    developer.ID,
+			ProgrammingLanguageID: skill.ID,
+			Proficiency:           skill.Proficiency,
+			YearsOfExperience:     skill.YearsOfExperience,
+			IsPrimary:             skill.IsPrimary,
+		})
+	}
+
+	if err := h.repo.ReplaceLanguages(developer.ID, languages); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update languages"})
+		return
+	}
+
+	languages, err := h.repo.ListLanguages(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch languages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Languages updated successfully",
+		"data":    languages,
+	})
+}
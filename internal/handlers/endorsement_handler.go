@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type EndorsementHandler struct {
+	endorsementService *services.EndorsementService
+	developers         *DeveloperHandler
+}
+
+func NewEndorsementHandler(endorsementService *services.EndorsementService) *EndorsementHandler {
+	return &EndorsementHandler{
+		endorsementService: endorsementService,
+		developers:         NewDeveloperHandler(),
+	}
+}
+
+// EndorseSkill POST /api/v1/developers/:id/skills/:technology_id/endorsements
+func (h *EndorsementHandler) EndorseSkill(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	developerID, technologyID, ok := endorsedSkillFromURL(c)
+	if !ok {
+		return
+	}
+
+	var req services.EndorseRequest
+	if c.Request.ContentLength > 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	developer, err := h.developers.repo.FindByID(developerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+		return
+	}
+	if _, ok := h.developers.developerAccess(c, developer); !ok {
+		return
+	}
+
+	endorsement, err := h.endorsementService.Endorse(userID, developer, technologyID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSelfEndorsement):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrSkillNotListed):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrAlreadyEndorsed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrEndorsementLimit):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to endorse skill"})
+		}
+		return
+	}
+
+	message := "Skill endorsed successfully"
+	if endorsement.IsFlagged {
+		message = "Endorsement submitted and will be counted after review"
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message,
+		"data":    endorsement,
+	})
+}
+
+// WithdrawEndorsement DELETE /api/v1/developers/:id/skills/:technology_id/endorsements
+func (h *EndorsementHandler) WithdrawEndorsement(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	developerID, technologyID, ok := endorsedSkillFromURL(c)
+	if !ok {
+		return
+	}
+
+	if err := h.endorsementService.Withdraw(userID, developerID, technologyID); err != nil {
+		if errors.Is(err, services.ErrEndorsementNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Endorsement not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to withdraw endorsement"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Endorsement withdrawn successfully"})
+}
+
+// endorsedSkillFromURL reads the developer and technology IDs, writing a 400 when either is invalid
+func endorsedSkillFromURL(c *gin.Context) (uint, uint, bool) {
+	developerID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid developer ID"})
+		return 0, 0, false
+	}
+	technologyID, err := strconv.ParseUint(c.Param("technology_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid technology ID"})
+		return 0, 0, false
+	}
+	return developerID, uint(technologyID), true
+}
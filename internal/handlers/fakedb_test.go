@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeTable is the canned content of one table. Queries only honour a plain list of selected columns
+// and `column = $n` and `column IN (...)` conditions.
+type fakeTable struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeExec is a statement that changed data, recorded but not applied
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+// useFakeDB points database.DB at an in-memory stand-in serving the given tables for the duration of the test.
+// It returns the log of UPDATE and DELETE statements run against it.
+func useFakeDB(t *testing.T, tables map[string]fakeTable) *[]fakeExec {
+	t.Helper()
+	execs := &[]fakeExec{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(fakeConnector{tables, execs})}),
+		&gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open fake database: %v", err)
+	}
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	return execs
+}
+
+var (
+	fromTable   = regexp.MustCompile(`(?i)FROM "?(\w+)"?`)
+	whereColumn = regexp.MustCompile(`(?i)"?(\w+)"?\s*(?:=\s*\$(\d+)|IN\s*\(([$\d, ]+)\))`)
+	placeholder = regexp.MustCompile(`\$(\d+)`)
+)
+
+type fakeConnector struct {
+	tables map[string]fakeTable
+	execs  *[]fakeExec
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{c.tables, c.execs}, nil
+}
+func (c fakeConnector) Driver() driver.Driver { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake database is opened through its connector")
+}
+
+type fakeConn struct {
+	tables map[string]fakeTable
+	execs  *[]fakeExec
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return c.query(query, values)
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return c.exec(query, values)
+}
+
+func (c *fakeConn) exec(query string, args []driver.Value) (driver.Result, error) {
+	*c.execs = append(*c.execs, fakeExec{query, args})
+	// Claim a row was touched so gorm's Save does not fall back to an insert
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) query(query string, args []driver.Value) (driver.Rows, error) {
+	if strings.HasPrefix(query, "INSERT ") {
+		// Inserts are not stored; they only hand back the generated id gorm asks for
+		return &fakeRows{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}, nil
+	}
+
+	match := fromTable.FindStringSubmatch(query)
+	if match == nil {
+		return nil, errors.New("fake database cannot answer: " + query)
+	}
+	table := c.tables[match[1]]
+	rows := table.filter(query, args)
+	if strings.Contains(strings.ToLower(query), "count(") {
+		return &fakeRows{columns: []string{"count"}, rows: [][]driver.Value{{int64(len(rows))}}}, nil
+	}
+	return table.project(query, rows), nil
+}
+
+// project keeps the columns listed in a `SELECT "a","b" FROM` query, or every column for `SELECT *`
+func (t fakeTable) project(query string, rows [][]driver.Value) *fakeRows {
+	list := strings.TrimSpace(query[len("SELECT "):strings.Index(strings.ToUpper(query), " FROM ")])
+	if list == "*" {
+		return &fakeRows{columns: t.columns, rows: rows}
+	}
+
+	var columns []string
+	var indexes []int
+	for _, name := range strings.Split(list, ",") {
+		name = strings.Trim(strings.TrimSpace(name), `"`)
+		for i, column := range t.columns {
+			if column == name {
+				columns = append(columns, name)
+				indexes = append(indexes, i)
+			}
+		}
+	}
+
+	projected := make([][]driver.Value, len(rows))
+	for i, row := range rows {
+		for _, index := range indexes {
+			projected[i] = append(projected[i], row[index])
+		}
+	}
+	return &fakeRows{columns: columns, rows: projected}
+}
+
+// filter keeps the rows matching every equality and IN condition on one of the table's columns
+func (t fakeTable) filter(query string, args []driver.Value) [][]driver.Value {
+	where := query
+	if i := strings.Index(strings.ToUpper(query), " WHERE "); i >= 0 {
+		where = query[i:]
+	} else {
+		return t.rows
+	}
+
+	rows := t.rows
+	for _, condition := range whereColumn.FindAllStringSubmatch(where, -1) {
+		column := -1
+		for i, name := range t.columns {
+			if name == condition[1] {
+				column = i
+			}
+		}
+		if column < 0 {
+			continue
+		}
+
+		refs := []string{condition[2]}
+		if condition[2] == "" {
+			refs = nil
+			for _, ref := range placeholder.FindAllStringSubmatch(condition[3], -1) {
+				refs = append(refs, ref[1])
+			}
+		}
+		allowed := make(map[string]bool)
+		for _, ref := range refs {
+			if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(args) {
+				allowed[fmt.Sprint(args[n-1])] = true
+			}
+		}
+
+		var kept [][]driver.Value
+		for _, row := range rows {
+			if allowed[fmt.Sprint(row[column])] {
+				kept = append(kept, row)
+			}
+		}
+		rows = kept
+	}
+	return rows
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.exec(s.query, args)
+}
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.query(s.query, args)
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	repo        *repositories.ActivityRepository
+	companyRepo *repositories.CompanyRepository
+}
+
+func NewFeedHandler() *FeedHandler {
+	db := database.GetDB()
+	return &FeedHandler{
+		repo:        repositories.NewActivityRepository(db),
+		companyRepo: repositories.NewCompanyRepository(db),
+	}
+}
+
+// FollowCompany POST /api/v1/companies/:id/follow
+func (h *FeedHandler) FollowCompany(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	exists, err := h.companyRepo.Exists(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow company"})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return
+	}
+
+	if err := h.repo.Follow(userID, companyID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow company"})
+		return
+	}
+
+	followers, _ := h.repo.CountFollowers(companyID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Company followed successfully",
+		"data":    gin.H{"following": true, "follower_count": followers},
+	})
+}
+
+// UnfollowCompany DELETE /api/v1/companies/:id/follow
+func (h *FeedHandler) UnfollowCompany(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	if err := h.repo.Unfollow(userID, companyID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unfollow company"})
+		return
+	}
+
+	followers, _ := h.repo.CountFollowers(companyID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Company unfollowed successfully",
+		"data":    gin.H{"following": false, "follower_count": followers},
+	})
+}
+
+// ListFollowing GET /api/v1/me/following
+func (h *FeedHandler) ListFollowing(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	page, limit := getPaginationFromQuery(c)
+
+	follows, total, err := h.repo.ListFollowing(userID, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch followed companies"})
+		return
+	}
+
+	companies := make([]dto.CompanySummary, 0, len(follows))
+	for i := range follows {
+		companies = append(companies, dto.NewCompanySummary(&follows[i].Company))
+	}
+
+	result := utils.PaginationResult{
+		Data:       companies,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Followed companies retrieved successfully",
+		"data":    result,
+	})
+}
+
+// GetFeed GET /api/v1/me/feed?cursor=&limit=
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	_, limit := getPaginationFromQuery(c)
+
+	var before uint64
+	if cursor := c.Query("cursor"); cursor != "" {
+		var err error
+		before, err = strconv.ParseUint(cursor, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+	}
+
+	// Fetch one extra row to know whether another page exists
+	activities, err := h.repo.Feed(userID, uint(before), limit+1)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
+		return
+	}
+
+	result := utils.CursorResult{Limit: limit}
+	if len(activities) > limit {
+		activities = activities[:limit]
+		result.NextCursor = strconv.FormatUint(uint64(activities[limit-1].ID), 10)
+		result.HasMore = true
+	}
+	result.Data = dto.NewFeedItems(activities)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Feed retrieved successfully",
+		"data":    result,
+	})
+}
+
+// recordActivity publishes a company event to followers' feeds.
+// Failures are logged rather than returned so they never fail the action that triggered them.
+func recordActivity(repo *repositories.ActivityRepository, companyID uint, activityType string, subjectID *uint, summary string) {
+	if companyID == 0 {
+		return
+	}
+	activity := &models.CompanyActivity{
+		CompanyID: companyID,
+		Type:      activityType,
+		SubjectID: subjectID,
+		Summary:   summary,
+	}
+	if err := repo.Create(activity); err != nil {
+		log.Printf("Failed to record %s activity for company %d: %v", activityType, companyID, err)
+	}
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// interviewRequest is the body accepted when creating or editing an interview report
+type interviewRequest struct {
+	JobPostID     *uint      `json:"job_post_id"`
+	Role          string     `json:"role" validate:"required,max=255"`
+	Rounds        int        `json:"rounds" validate:"required,min=1,max=20"`
+	Process       string     `json:"process" validate:"max=5000"`
+	Questions     string     `json:"questions" validate:"max=5000"`
+	Difficulty    int        `json:"difficulty" validate:"required,min=1,max=5"`
+	Outcome       string     `json:"outcome" validate:"required,oneof=offer rejected no_response"`
+	DurationDays  *int       `json:"duration_days" validate:"omitempty,min=0,max=365"`
+	InterviewedAt *time.Time `json:"interviewed_at"`
+	Anonymous     bool       `json:"anonymous"`
+}
+
+func (req *interviewRequest) apply(report *models.InterviewReport) {
+	report.JobPostID = req.JobPostID
+	report.Role = strings.TrimSpace(req.Role)
+	report.Rounds = req.Rounds
+	report.Process = req.Process
+	report.Questions = req.Questions
+	report.Difficulty = req.Difficulty
+	report.Outcome = req.Outcome
+	report.DurationDays = req.DurationDays
+	report.InterviewedAt = req.InterviewedAt
+	report.IsAnonymous = req.Anonymous
+}
+
+type InterviewHandler struct {
+	repo        *repositories.InterviewRepository
+	companyRepo *repositories.CompanyRepository
+	jobRepo     *repositories.JobRepository
+}
+
+func NewInterviewHandler() *InterviewHandler {
+	db := database.GetDB()
+	return &InterviewHandler{
+		repo:        repositories.NewInterviewRepository(db),
+		companyRepo: repositories.NewCompanyRepository(db),
+		jobRepo:     repositories.NewJobRepository(db),
+	}
+}
+
+// CreateInterview POST /api/v1/companies/:id/interviews
+func (h *InterviewHandler) CreateInterview(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	var req interviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	exists, err := h.companyRepo.Exists(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create interview report"})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return
+	}
+
+	if !h.validJobPost(req.JobPostID, companyID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Job post is not a published job of this company"})
+		return
+	}
+
+	report := &models.InterviewReport{
+		CompanyID:  companyID,
+		UserID:     userID,
+		Moderation: models.Moderation{Status: models.ModerationPending},
+	}
+	req.apply(report)
+
+	if err := h.repo.Create(report); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create interview report"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Interview report created successfully (pending approval)",
+		"data":    report,
+	})
+}
+
+// ListInterviews GET /api/v1/companies/:id/interviews
+func (h *InterviewHandler) ListInterviews(c *gin.Context) {
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	page, limit := getPaginationFromQuery(c)
+
+	filters := make(map[string]interface{})
+
+	if role := c.Query("role"); role != "" {
+		filters["role"] = role
+	}
+	if outcome := c.Query("outcome"); outcome != "" {
+		filters["outcome"] = outcome
+	}
+	if difficulty, err := strconv.Atoi(c.Query("difficulty")); err == nil {
+		filters["difficulty"] = difficulty
+	}
+	if jobPostID, err := strconv.ParseUint(c.Query("job_post_id"), 10, 32); err == nil {
+		filters["job_post_id"] = uint(jobPostID)
+	}
+
+	reports, total, err := h.repo.List(companyID, page, limit, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interview reports"})
+		return
+	}
+
+	breakdown, err := h.repo.Breakdown(companyID, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interview breakdown"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       dto.NewInterviews(reports),
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Interview reports retrieved successfully",
+		"data":      result,
+		"breakdown": breakdown,
+	})
+}
+
+// ListMyInterviews GET /api/v1/me/interviews
+func (h *InterviewHandler) ListMyInterviews(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	page, limit := getPaginationFromQuery(c)
+	status := c.Query("status")
+
+	reports, total, err := h.repo.ListByUser(userID, page, limit, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interview reports"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       reports,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Interview reports retrieved successfully",
+		"data":    result,
+	})
+}
+
+// UpdateMyInterview PUT /api/v1/me/interviews/:id
+// Edited reports go back into the moderation queue.
+func (h *InterviewHandler) UpdateMyInterview(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	reportID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interview report ID"})
+		return
+	}
+
+	var req interviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	report, err := h.repo.FindByID(reportID)
+	if err != nil || report.UserID != userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Interview report not found"})
+		return
+	}
+
+	// A job unpublished after the report was written may stay linked to it
+	unchanged := req.JobPostID != nil && report.JobPostID != nil && *req.JobPostID == *report.JobPostID
+	if !unchanged && !h.validJobPost(req.JobPostID, report.CompanyID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Job post is not a published job of this company"})
+		return
+	}
+
+	req.apply(report)
+	report.JobPost = nil
+	report.Moderation = models.Moderation{Status: models.ModerationPending}
+
+	if err := h.repo.Update(report); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update interview report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Interview report updated successfully (pending approval)",
+		"data":    report,
+	})
+}
+
+// validJobPost reports whether the optional job post is a published job of the company
+func (h *InterviewHandler) validJobPost(jobPostID *uint, companyID uint) bool {
+	if jobPostID == nil {
+		return true
+	}
+	job, err := h.jobRepo.FindAccess(*jobPostID)
+	return err == nil && job.CompanyID == companyID && job.Status == models.JobPublished
+}
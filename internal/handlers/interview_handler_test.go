@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCreateInterviewRequiresPublishedJobOfCompany(t *testing.T) {
+	tables := reviewedCompanyTables()
+	tables["job_posts"] = fakeTable{
+		columns: []string{"id", "company_id", "title", "status"},
+		rows: [][]driver.Value{
+			{int64(3), int64(1), "Backend Engineer", "published"},
+			{int64(4), int64(1), "Frontend Engineer", "unpublished"},
+			{int64(5), int64(2), "QA Engineer", "published"},
+		},
+	}
+	useFakeDB(t, tables)
+	h := NewInterviewHandler()
+
+	tests := []struct {
+		target    string
+		jobPostID string
+		want      int
+	}{
+		{"/companies/1/interviews", "null", http.StatusCreated},
+		{"/companies/1/interviews", "3", http.StatusCreated},
+		{"/companies/1/interviews", "4", http.StatusBadRequest}, // unpublished
+		{"/companies/1/interviews", "5", http.StatusBadRequest}, // another company's job
+		{"/companies/1/interviews", "6", http.StatusBadRequest}, // no such job
+		{"/companies/2/interviews", "null", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		body := fmt.Sprintf(`{"job_post_id":%s,"role":"Backend Engineer","rounds":3,"difficulty":3,"outcome":"offer"}`, tt.jobPostID)
+		if code := serveAs(namedReviewer.id, http.MethodPost, "/companies/:id/interviews", tt.target, body, h.CreateInterview).Code; code != tt.want {
+			t.Errorf("POST %s with job_post_id %s returned %d, want %d", tt.target, tt.jobPostID, code, tt.want)
+		}
+	}
+}
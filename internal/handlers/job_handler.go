@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
 	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
 	"github.com/bishworup11/bdSeeker-backend/internal/models"
 	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
@@ -12,15 +14,17 @@ import (
 )
 
 type JobHandler struct {
-	repo        *repositories.JobRepository
-	companyRepo *repositories.CompanyRepository
+	repo         *repositories.JobRepository
+	companyRepo  *repositories.CompanyRepository
+	activityRepo *repositories.ActivityRepository
 }
 
 func NewJobHandler() *JobHandler {
 	db := database.GetDB()
 	return &JobHandler{
-		repo:        repositories.NewJobRepository(db),
-		companyRepo: repositories.NewCompanyRepository(db),
+		repo:         repositories.NewJobRepository(db),
+		companyRepo:  repositories.NewCompanyRepository(db),
+		activityRepo: repositories.NewActivityRepository(db),
 	}
 }
 
@@ -28,7 +32,7 @@ func NewJobHandler() *JobHandler {
 func (h *JobHandler) ListJobs(c *gin.Context) {
 	page, limit := getPaginationFromQuery(c)
 
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{"status": models.JobPublished}
 
 	if workMode := c.Query("work_mode"); workMode != "" {
 		filters["work_mode"] = workMode
@@ -50,7 +54,7 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 	}
 
 	result := utils.PaginationResult{
-		Data:       jobs,
+		Data:       dto.NewJobs(jobs),
 		TotalCount: total,
 		Page:       page,
 		Limit:      limit,
@@ -63,23 +67,25 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 	})
 }
 
-// GetJob GET /api/v1/jobs/:id
+// GetJob GET /api/v1/jobs/:id (ID or slug)
 func (h *JobHandler) GetJob(c *gin.Context) {
-	id, err := getIDFromURL(c)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
-		return
+	id, slug := getIDOrSlugFromURL(c)
+
+	var job *models.JobPost
+	var err error
+	if slug == "" {
+		job, err = h.repo.FindByID(id)
+	} else {
+		job, err = h.repo.FindBySlug(slug)
 	}
-
-	job, err := h.repo.FindByID(id)
-	if err != nil {
+	if err != nil || (job.Status != models.JobPublished && !canManageJob(c, job)) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Job retrieved successfully",
-		"data":    job,
+		"data":    dto.NewJob(job),
 	})
 }
 
@@ -89,18 +95,19 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 	userRole, _ := middleware.GetUserRole(c)
 
 	var req struct {
-		Title              string  `json:"title" validate:"required"`
+		Title              string  `json:"title" validate:"required,max=255"`
 		Description        string  `json:"description" validate:"required"`
-		SalaryMin          float64 `json:"salary_min"`
-		SalaryMax          float64 `json:"salary_max"`
-		ExperienceMinYears int     `json:"experience_min_years"`
-		ExperienceMaxYears int     `json:"experience_max_years"`
-		WorkMode           string  `json:"work_mode"`
-		Location           string  `json:"location"`
+		SalaryMin          float64 `json:"salary_min" validate:"min=0"`
+		SalaryMax          float64 `json:"salary_max" validate:"min=0"`
+		ExperienceMinYears int     `json:"experience_min_years" validate:"min=0,max=50"`
+		ExperienceMaxYears int     `json:"experience_max_years" validate:"min=0,max=50"`
+		WorkMode           string  `json:"work_mode" validate:"omitempty,oneof=office hybrid remote onsite"`
+		Location           string  `json:"location" validate:"max=255"`
+		OfficeID           *uint   `json:"office_id"`
+		Status             string  `json:"status" validate:"omitempty,oneof=published unpublished"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -115,6 +122,17 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		companyID = company.ID
 	}
 
+	if req.OfficeID != nil {
+		office, err := h.companyRepo.FindOffice(companyID, *req.OfficeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Office does not belong to your company"})
+			return
+		}
+		if req.Location == "" {
+			req.Location = officeLocation(office)
+		}
+	}
+
 	job := &models.JobPost{
 		CompanyID:          companyID,
 		Title:              req.Title,
@@ -125,6 +143,16 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		ExperienceMaxYears: req.ExperienceMaxYears,
 		WorkMode:           req.WorkMode,
 		Location:           req.Location,
+		OfficeID:           req.OfficeID,
+		Status:             req.Status,
+	}
+	if job.Status == "" {
+		job.Status = models.JobPublished
+	}
+
+	if message := jobRangeError(job); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
 	}
 
 	if err := h.repo.Create(job); err != nil {
@@ -132,12 +160,226 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		return
 	}
 
+	if job.Status == models.JobPublished {
+		recordActivity(h.activityRepo, job.CompanyID, models.ActivityJobPosted, &job.ID, "New job: "+job.Title)
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Job created successfully",
-		"data":    job,
+		"data":    dto.NewJob(job),
+	})
+}
+
+// UpdateJob PATCH /api/v1/jobs/:id
+func (h *JobHandler) UpdateJob(c *gin.Context) {
+	jobID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	var req struct {
+		Title              *string  `json:"title" validate:"omitempty,min=1,max=255"`
+		Description        *string  `json:"description" validate:"omitempty,min=1"`
+		SalaryMin          *float64 `json:"salary_min" validate:"omitempty,min=0"`
+		SalaryMax          *float64 `json:"salary_max" validate:"omitempty,min=0"`
+		ExperienceMinYears *int     `json:"experience_min_years" validate:"omitempty,min=0,max=50"`
+		ExperienceMaxYears *int     `json:"experience_max_years" validate:"omitempty,min=0,max=50"`
+		WorkMode           *string  `json:"work_mode" validate:"omitempty,oneof=office hybrid remote onsite"`
+		Location           *string  `json:"location" validate:"omitempty,max=255"`
+		OfficeID           *uint    `json:"office_id"`
+		Status             *string  `json:"status" validate:"omitempty,oneof=published unpublished"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	job, ok := h.managedJob(c, jobID)
+	if !ok {
+		return
+	}
+	wasPublished := job.Status == models.JobPublished
+
+	if req.Title != nil {
+		job.Title = *req.Title
+	}
+	if req.Description != nil {
+		job.Description = *req.Description
+	}
+	if req.SalaryMin != nil {
+		job.SalaryMin = *req.SalaryMin
+	}
+	if req.SalaryMax != nil {
+		job.SalaryMax = *req.SalaryMax
+	}
+	if req.ExperienceMinYears != nil {
+		job.ExperienceMinYears = *req.ExperienceMinYears
+	}
+	if req.ExperienceMaxYears != nil {
+		job.ExperienceMaxYears = *req.ExperienceMaxYears
+	}
+	if req.WorkMode != nil {
+		job.WorkMode = *req.WorkMode
+	}
+	if req.Location != nil {
+		job.Location = *req.Location
+	}
+	if req.OfficeID != nil {
+		office, err := h.companyRepo.FindOffice(job.CompanyID, *req.OfficeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Office does not belong to the job's company"})
+			return
+		}
+		job.OfficeID = req.OfficeID
+		if req.Location == nil {
+			job.Location = officeLocation(office)
+		}
+	}
+	if req.Status != nil {
+		job.Status = *req.Status
+	}
+
+	if message := jobRangeError(job); message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
+	if err := h.repo.Update(job); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
+		return
+	}
+
+	// Only the first publication is announced; unpublished jobs are hidden from feeds until they are republished
+	if !wasPublished && job.Status == models.JobPublished {
+		announced, err := h.activityRepo.HasActivity(models.ActivityJobPosted, job.ID)
+		if err != nil {
+			log.Printf("Failed to check job_posted activity for job %d: %v", job.ID, err)
+		} else if !announced {
+			recordActivity(h.activityRepo, job.CompanyID, models.ActivityJobPosted, &job.ID, "New job: "+job.Title)
+		}
+	}
+
+	job, err = h.repo.FindByID(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job updated successfully",
+		"data":    dto.NewJob(job),
+	})
+}
+
+// DeleteJob DELETE /api/v1/jobs/:id
+func (h *JobHandler) DeleteJob(c *gin.Context) {
+	jobID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, ok := h.managedJob(c, jobID)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(job.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete job"})
+		return
+	}
+	if err := h.activityRepo.DeleteBySubject(models.ActivityJobPosted, job.ID); err != nil {
+		log.Printf("Failed to delete job_posted activity for job %d: %v", job.ID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job deleted successfully"})
+}
+
+// ListMyJobs GET /api/v1/companies/me/jobs
+func (h *JobHandler) ListMyJobs(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	page, limit := getPaginationFromQuery(c)
+
+	company, err := h.companyRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company profile not found"})
+		return
+	}
+
+	filters := map[string]interface{}{"company_id": company.ID}
+	if status := c.Query("status"); status != "" {
+		if status != models.JobPublished && status != models.JobUnpublished {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "status must be published or unpublished"})
+			return
+		}
+		filters["status"] = status
+	}
+
+	jobs, total, err := h.repo.List(page, limit, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
+		return
+	}
+
+	result := utils.PaginationResult{
+		Data:       dto.NewJobs(jobs),
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Jobs retrieved successfully",
+		"data":    result,
 	})
 }
 
+// managedJob loads a job the caller may edit, writing a 404 or 403 when it is missing or not theirs
+func (h *JobHandler) managedJob(c *gin.Context, id uint) (*models.JobPost, bool) {
+	job, err := h.repo.FindByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return nil, false
+	}
+	if !canManageJob(c, job) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the company that posted this job can change it"})
+		return nil, false
+	}
+	return job, true
+}
+
+// visibleJob checks that the caller may see the job, writing a 404 when it is missing or unpublished and not theirs
+func (h *JobHandler) visibleJob(c *gin.Context, id uint) bool {
+	job, err := h.repo.FindAccess(id)
+	if err != nil || (job.Status != models.JobPublished && !canManageJob(c, job)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return false
+	}
+	return true
+}
+
+// canManageJob reports whether the caller is an admin or owns the company that posted the job
+func canManageJob(c *gin.Context, job *models.JobPost) bool {
+	if role, _ := middleware.GetUserRole(c); role == "admin" {
+		return true
+	}
+	userID, ok := middleware.GetUserID(c)
+	return ok && job.CompanyID != 0 && job.Company.UserID != nil && *job.Company.UserID == userID
+}
+
+// jobRangeError returns why a job's salary or experience range is invalid, or "" when both are valid.
+// A salary_max of 0 means the salary is not disclosed and an experience_max_years of 0 means there is no upper limit.
+func jobRangeError(job *models.JobPost) string {
+	if job.SalaryMax > 0 && job.SalaryMin > job.SalaryMax {
+		return "salary_min must not be greater than salary_max"
+	}
+	if job.ExperienceMaxYears > 0 && job.ExperienceMinYears > job.ExperienceMaxYears {
+		return "experience_min_years must not be greater than experience_max_years"
+	}
+	return ""
+}
+
 // ReactToJob POST /api/v1/jobs/:id/reactions
 func (h *JobHandler) ReactToJob(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
@@ -156,6 +398,10 @@ func (h *JobHandler) ReactToJob(c *gin.Context) {
 		return
 	}
 
+	if !h.visibleJob(c, jobID) {
+		return
+	}
+
 	// Check if reaction exists
 	existing, err := h.repo.FindReaction(userID, jobID, "")
 	if err == nil && existing != nil {
@@ -203,6 +449,10 @@ func (h *JobHandler) CommentOnJob(c *gin.Context) {
 		return
 	}
 
+	if !h.visibleJob(c, jobID) {
+		return
+	}
+
 	comment := &models.PostComment{
 		UserID:    userID,
 		JobPostID: jobID,
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetJobDoesNotLeakUsers(t *testing.T) {
+	tables := reviewedCompanyTables()
+	tables["job_posts"] = fakeTable{
+		columns: []string{"id", "company_id", "title", "slug", "description", "status", "created_at", "updated_at"},
+		rows:    [][]driver.Value{{int64(3), int64(1), "Backend Engineer", "backend-engineer-acme", "Go", "published", reviewTestTime, reviewTestTime}},
+	}
+	tables["post_comments"] = fakeTable{
+		columns: []string{"id", "user_id", "job_post_id", "content", "created_at", "updated_at"},
+		rows:    [][]driver.Value{{int64(1), namedReviewer.id, int64(3), "Is this remote?", reviewTestTime, reviewTestTime}},
+	}
+	useFakeDB(t, tables)
+	h := NewJobHandler()
+
+	body := serve(t, http.MethodGet, "/jobs/:id", "/jobs/3", h.GetJob).Body.String()
+	for _, leak := range []string{companyOwner.email, companyOwner.fullName, namedReviewer.email, `"password_hash"`} {
+		if strings.Contains(body, leak) {
+			t.Errorf("response leaks %s: %s", leak, body)
+		}
+	}
+	if !strings.Contains(body, namedReviewer.fullName) {
+		t.Errorf("comment author name missing: %s", body)
+	}
+}
+
+func TestJobsHideCompanyOwner(t *testing.T) {
+	tables := reviewedCompanyTables()
+	tables["job_posts"] = fakeTable{
+		columns: []string{"id", "company_id", "title", "slug", "description", "status", "created_at", "updated_at"},
+		rows:    [][]driver.Value{{int64(3), int64(1), "Backend Engineer", "backend-engineer-acme", "Go", "published", reviewTestTime, reviewTestTime}},
+	}
+	useFakeDB(t, tables)
+	h := NewJobHandler()
+
+	var job struct {
+		Data struct {
+			Company map[string]interface{} `json:"company"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(serve(t, http.MethodGet, "/jobs/:id", "/jobs/3", h.GetJob).Body.Bytes(), &job); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	var list struct {
+		Data struct {
+			Data []struct {
+				Company map[string]interface{} `json:"company"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(serve(t, http.MethodGet, "/jobs", "/jobs", h.ListJobs).Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(list.Data.Data) != 1 {
+		t.Fatalf("ListJobs() returned %d jobs, want 1", len(list.Data.Data))
+	}
+
+	for name, company := range map[string]map[string]interface{}{"GetJob": job.Data.Company, "ListJobs": list.Data.Data[0].Company} {
+		if company["company_name"] != "Acme" {
+			t.Errorf("%s: company = %v, want Acme", name, company)
+		}
+		if _, ok := company["user_id"]; ok {
+			t.Errorf("%s: company exposes its owner: %v", name, company)
+		}
+	}
+}
+
+func TestJobRangeError(t *testing.T) {
+	tests := []struct {
+		name  string
+		job   models.JobPost
+		valid bool
+	}{
+		{"both ranges set", models.JobPost{SalaryMin: 50000, SalaryMax: 80000, ExperienceMinYears: 2, ExperienceMaxYears: 5}, true},
+		{"undisclosed salary", models.JobPost{SalaryMin: 50000, ExperienceMinYears: 2, ExperienceMaxYears: 5}, true},
+		{"open-ended experience", models.JobPost{ExperienceMinYears: 3}, true},
+		{"salary min above max", models.JobPost{SalaryMin: 90000, SalaryMax: 80000}, false},
+		{"experience min above max", models.JobPost{ExperienceMinYears: 6, ExperienceMaxYears: 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if message := jobRangeError(&tt.job); (message == "") != tt.valid {
+				t.Errorf("jobRangeError() = %q, want valid = %v", message, tt.valid)
+			}
+		})
+	}
+}
+
+// serveAs sends a JSON request on behalf of userID and returns the response without checking its status
+func serveAs(userID int64, method, pattern, target, body string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, pattern, func(c *gin.Context) {
+		c.Set(string(middleware.UserIDKey), uint(userID))
+		c.Set(string(middleware.UserRoleKey), "developer")
+	}, handler)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestInteractionsRequirePublishedJob(t *testing.T) {
+	tables := reviewedCompanyTables()
+	tables["job_posts"] = fakeTable{
+		columns: []string{"id", "company_id", "title", "slug", "description", "status", "created_at", "updated_at"},
+		rows:    [][]driver.Value{{int64(3), int64(1), "Backend Engineer", "backend-engineer-acme", "Go", "unpublished", reviewTestTime, reviewTestTime}},
+	}
+	tables["post_reactions"] = fakeTable{columns: []string{"id", "user_id", "job_post_id", "type"}}
+	useFakeDB(t, tables)
+	h := NewJobHandler()
+
+	requests := []struct {
+		pattern string
+		body    string
+		handler gin.HandlerFunc
+	}{
+		{"/jobs/:id/reactions", `{"type":"like"}`, h.ReactToJob},
+		{"/jobs/:id/comments", `{"content":"Still hiring?"}`, h.CommentOnJob},
+	}
+	for _, req := range requests {
+		target := strings.Replace(req.pattern, ":id", "3", 1)
+		if code := serveAs(namedReviewer.id, http.MethodPost, req.pattern, target, req.body, req.handler).Code; code != http.StatusNotFound {
+			t.Errorf("POST %s by another user returned %d, want 404", target, code)
+		}
+		if code := serveAs(companyOwner.id, http.MethodPost, req.pattern, target, req.body, req.handler).Code; code != http.StatusCreated {
+			t.Errorf("POST %s by the owner returned %d, want 201", target, code)
+		}
+	}
+}
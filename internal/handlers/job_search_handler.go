@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type JobSearchHandler struct {
+	jobSearchService *services.JobSearchService
+	developers       *DeveloperHandler
+}
+
+func NewJobSearchHandler(jobSearchService *services.JobSearchService) *JobSearchHandler {
+	return &JobSearchHandler{
+		jobSearchService: jobSearchService,
+		developers:       NewDeveloperHandler(),
+	}
+}
+
+// UpdateMyJobSearch PUT /api/v1/developers/me/job-search
+// Replaces the caller's open-to-work status and preferences; companies see them on the profile and can filter by them.
+func (h *JobSearchHandler) UpdateMyJobSearch(c *gin.Context) {
+	var req services.UpdateJobSearchRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	if err := h.jobSearchService.Update(developer, &req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job search preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job search preferences updated successfully",
+		"data":    dto.NewJobSearch(developer),
+	})
+}
+
+// RefreshMyJobSearch POST /api/v1/developers/me/job-search/refresh
+// Keeps the caller's open-to-work status from expiring for another full period.
+func (h *JobSearchHandler) RefreshMyJobSearch(c *gin.Context) {
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	if err := h.jobSearchService.Refresh(developer); err != nil {
+		if errors.Is(err, services.ErrNotOpenToWork) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh open-to-work status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Open-to-work status refreshed successfully",
+		"data":    dto.NewJobSearch(developer),
+	})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/pkg/jsonresume"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize bounds JSON Resume documents, which are plain text and rarely exceed a few kilobytes
+const maxImportSize = 1 << 20
+
+type JSONResumeHandler struct {
+	jsonResumeService *services.JSONResumeService
+	developers        *DeveloperHandler
+}
+
+func NewJSONResumeHandler(jsonResumeService *services.JSONResumeService) *JSONResumeHandler {
+	return &JSONResumeHandler{
+		jsonResumeService: jsonResumeService,
+		developers:        NewDeveloperHandler(),
+	}
+}
+
+// ExportMyProfile GET /api/v1/developers/me/export?format=jsonresume
+func (h *JSONResumeHandler) ExportMyProfile(c *gin.Context) {
+	if format := c.DefaultQuery("format", "jsonresume"); format != "jsonresume" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported export format, supported: jsonresume"})
+		return
+	}
+
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	resume, err := h.jsonResumeService.Export(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export developer profile"})
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": developer.Slug + ".json"}))
+	c.JSON(http.StatusOK, resume)
+}
+
+// ImportMyProfile POST /api/v1/developers/me/import?dry_run=true
+// Upserts a JSON Resume document into the profile. A dry run only reports the changes.
+func (h *JSONResumeHandler) ImportMyProfile(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be true or false"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	var doc jsonresume.Resume
+	if !bindJSON(c, &doc) {
+		return
+	}
+
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.jsonResumeService.Import(developer.ID, &doc, dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import developer profile"})
+		return
+	}
+
+	message := "Profile imported successfully"
+	if dryRun {
+		message = "Import previewed, no changes were saved"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    result,
+	})
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/database"
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/pkg/imaging"
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	mediaService  *services.MediaService
+	companyRepo   *repositories.CompanyRepository
+	developerRepo *repositories.DeveloperRepository
+	activityRepo  *repositories.ActivityRepository
+}
+
+func NewMediaHandler(mediaService *services.MediaService) *MediaHandler {
+	db := database.GetDB()
+	return &MediaHandler{
+		mediaService:  mediaService,
+		companyRepo:   repositories.NewCompanyRepository(db),
+		developerRepo: repositories.NewDeveloperRepository(db),
+		activityRepo:  repositories.NewActivityRepository(db),
+	}
+}
+
+// companyImage describes where a kind of company image is stored on the profile
+type companyImage struct {
+	name        string
+	variants    []services.ImageVariant
+	urlColumn   string
+	thumbColumn string
+	keyColumn   string
+	currentKey  func(company *models.CompanyProfile) string
+}
+
+var (
+	companyLogo = companyImage{
+		name:        "logo",
+		variants:    services.LogoVariants,
+		urlColumn:   "logo_url",
+		thumbColumn: "logo_thumbnail_url",
+		keyColumn:   "logo_key",
+		currentKey:  func(company *models.CompanyProfile) string { return company.LogoKey },
+	}
+	companyCover = companyImage{
+		name:        "cover",
+		variants:    services.CoverVariants,
+		urlColumn:   "cover_url",
+		thumbColumn: "cover_thumbnail_url",
+		keyColumn:   "cover_key",
+		currentKey:  func(company *models.CompanyProfile) string { return company.CoverKey },
+	}
+)
+
+// UploadCompanyLogo POST /api/v1/companies/me/logo (multipart field "file")
+func (h *MediaHandler) UploadCompanyLogo(c *gin.Context) {
+	h.uploadCompanyImage(c, companyLogo)
+}
+
+// UploadCompanyCover POST /api/v1/companies/me/cover (multipart field "file")
+func (h *MediaHandler) UploadCompanyCover(c *gin.Context) {
+	h.uploadCompanyImage(c, companyCover)
+}
+
+// DeleteCompanyLogo DELETE /api/v1/companies/me/logo
+func (h *MediaHandler) DeleteCompanyLogo(c *gin.Context) {
+	h.deleteCompanyImage(c, companyLogo)
+}
+
+// DeleteCompanyCover DELETE /api/v1/companies/me/cover
+func (h *MediaHandler) DeleteCompanyCover(c *gin.Context) {
+	h.deleteCompanyImage(c, companyCover)
+}
+
+func (h *MediaHandler) uploadCompanyImage(c *gin.Context, kind companyImage) {
+	userID, _ := middleware.GetUserID(c)
+
+	company, err := h.companyRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User must have a company profile to upload images"})
+		return
+	}
+
+	data, _, err := readUploadedFile(c, "file", h.mediaService.MaxImageSize())
+	if err != nil {
+		if errors.Is(err, services.ErrFileTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Image must be at most %d bytes", h.mediaService.MaxImageSize())})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file must be uploaded in the \"file\" field"})
+		return
+	}
+
+	prefix := fmt.Sprintf("companies/%d/%s", company.ID, kind.name)
+	stored, err := h.mediaService.StoreImage(c.Request.Context(), prefix, data, kind.variants)
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupportedType) || errors.Is(err, imaging.ErrTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store image"})
+		return
+	}
+
+	fields := map[string]interface{}{
+		kind.urlColumn:   stored.URLs["full"],
+		kind.thumbColumn: stored.URLs["thumb"],
+		kind.keyColumn:   stored.Key,
+	}
+	if err := h.companyRepo.UpdateFields(company.ID, fields); err != nil {
+		h.mediaService.DeleteImage(c.Request.Context(), stored.Key, kind.variants)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update company"})
+		return
+	}
+
+	// Remove the previous image only once the profile points at the new one
+	h.mediaService.DeleteImage(c.Request.Context(), kind.currentKey(company), kind.variants)
+
+	recordActivity(h.activityRepo, company.ID, models.ActivityProfileUpdated, nil,
+		company.CompanyName+" updated its "+kind.name)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Image uploaded successfully",
+		"data": gin.H{
+			"url":           stored.URLs["full"],
+			"thumbnail_url": stored.URLs["thumb"],
+		},
+	})
+}
+
+func (h *MediaHandler) deleteCompanyImage(c *gin.Context, kind companyImage) {
+	userID, _ := middleware.GetUserID(c)
+
+	company, err := h.companyRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return
+	}
+
+	fields := map[string]interface{}{
+		kind.urlColumn:   "",
+		kind.thumbColumn: "",
+		kind.keyColumn:   "",
+	}
+	if err := h.companyRepo.UpdateFields(company.ID, fields); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update company"})
+		return
+	}
+
+	h.mediaService.DeleteImage(c.Request.Context(), kind.currentKey(company), kind.variants)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Image deleted successfully",
+		"data":    nil,
+	})
+}
+
+// UploadDeveloperAvatar POST /api/v1/developers/me/avatar (multipart field "file")
+func (h *MediaHandler) UploadDeveloperAvatar(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	developer, err := h.developerRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User must have a developer profile to upload a profile picture"})
+		return
+	}
+
+	data, _, err := readUploadedFile(c, "file", h.mediaService.MaxImageSize())
+	if err != nil {
+		if errors.Is(err, services.ErrFileTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Image must be at most %d bytes", h.mediaService.MaxImageSize())})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file must be uploaded in the \"file\" field"})
+		return
+	}
+
+	prefix := fmt.Sprintf("developers/%d/avatar", developer.ID)
+	stored, err := h.mediaService.StoreImage(c.Request.Context(), prefix, data, services.AvatarVariants)
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupportedType) || errors.Is(err, imaging.ErrTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store image"})
+		return
+	}
+
+	fields := map[string]interface{}{
+		"avatar_url":           stored.URLs["full"],
+		"avatar_thumbnail_url": stored.URLs["thumb"],
+		"avatar_key":           stored.Key,
+	}
+	if err := h.developerRepo.UpdateFields(developer.ID, fields); err != nil {
+		h.mediaService.DeleteImage(c.Request.Context(), stored.Key, services.AvatarVariants)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update developer profile"})
+		return
+	}
+
+	// Remove the previous picture only once the profile points at the new one
+	h.mediaService.DeleteImage(c.Request.Context(), developer.AvatarKey, services.AvatarVariants)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Image uploaded successfully",
+		"data": gin.H{
+			"url":           stored.URLs["full"],
+			"thumbnail_url": stored.URLs["thumb"],
+		},
+	})
+}
+
+// DeleteDeveloperAvatar DELETE /api/v1/developers/me/avatar
+func (h *MediaHandler) DeleteDeveloperAvatar(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	developer, err := h.developerRepo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer profile not found"})
+		return
+	}
+
+	fields := map[string]interface{}{
+		"avatar_url":           "",
+		"avatar_thumbnail_url": "",
+		"avatar_key":           "",
+	}
+	if err := h.developerRepo.UpdateFields(developer.ID, fields); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update developer profile"})
+		return
+	}
+
+	h.mediaService.DeleteImage(c.Request.Context(), developer.AvatarKey, services.AvatarVariants)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Image deleted successfully",
+		"data":    nil,
+	})
+}
+
+// readUploadedFile reads a multipart file field and its client file name, rejecting files larger than maxSize
+func readUploadedFile(c *gin.Context, field string, maxSize int64) ([]byte, string, error) {
+	// Allow some room for the multipart envelope around the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	file, header, err := c.Request.FormFile(field)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, "", services.ErrFileTooLarge
+		}
+		return nil, "", err
+	}
+	defer file.Close()
+
+	if header.Size > maxSize {
+		return nil, "", services.ErrFileTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", services.ErrFileTooLarge
+	}
+	return data, header.Filename, nil
+}
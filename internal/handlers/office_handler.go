@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// officeRequest is the body accepted when creating or editing an office
+type officeRequest struct {
+	Name           string   `json:"name" validate:"max=100"`
+	Division       string   `json:"division" validate:"required"`
+	District       string   `json:"district" validate:"required,max=100"`
+	Area           string   `json:"area" validate:"max=100"`
+	AddressLine    string   `json:"address_line" validate:"max=255"`
+	PostalCode     string   `json:"postal_code" validate:"max=20"`
+	Latitude       *float64 `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude      *float64 `json:"longitude" validate:"omitempty,min=-180,max=180"`
+	IsHeadquarters bool     `json:"is_headquarters"`
+}
+
+func (req *officeRequest) apply(office *models.CompanyOffice) {
+	office.Name = req.Name
+	office.Division = req.Division
+	office.District = req.District
+	office.Area = req.Area
+	office.AddressLine = req.AddressLine
+	office.PostalCode = req.PostalCode
+	office.Latitude = req.Latitude
+	office.Longitude = req.Longitude
+	office.IsHeadquarters = req.IsHeadquarters
+}
+
+// normalizeDivision returns the canonical spelling of a Bangladesh division, or "" when unknown
+func normalizeDivision(division string) string {
+	division = strings.TrimSpace(division)
+	for _, known := range models.Divisions {
+		if strings.EqualFold(known, division) {
+			return known
+		}
+	}
+	return ""
+}
+
+// bindOffice parses and validates an office request, writing the error response on failure
+func bindOffice(c *gin.Context) (*officeRequest, bool) {
+	var req officeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, false
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return nil, false
+	}
+
+	req.Division = normalizeDivision(req.Division)
+	if req.Division == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Division must be one of " + strings.Join(models.Divisions, ", ")})
+		return nil, false
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Latitude and longitude must be provided together"})
+		return nil, false
+	}
+	return &req, true
+}
+
+// ListOffices GET /api/v1/companies/:id/offices
+func (h *CompanyHandler) ListOffices(c *gin.Context) {
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	exists, err := h.repo.Exists(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch offices"})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return
+	}
+
+	offices, err := h.repo.ListOffices(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch offices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Offices retrieved successfully",
+		"data":    offices,
+	})
+}
+
+// CreateOffice POST /api/v1/companies/me/offices
+func (h *CompanyHandler) CreateOffice(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	req, ok := bindOffice(c)
+	if !ok {
+		return
+	}
+
+	company, err := h.repo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User must have a company profile to add offices"})
+		return
+	}
+
+	office := &models.CompanyOffice{CompanyID: company.ID}
+	req.apply(office)
+
+	if err := h.repo.SaveOffice(office); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create office"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Office created successfully",
+		"data":    office,
+	})
+}
+
+// UpdateOffice PUT /api/v1/companies/me/offices/:id
+func (h *CompanyHandler) UpdateOffice(c *gin.Context) {
+	office, ok := h.findMyOffice(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindOffice(c)
+	if !ok {
+		return
+	}
+	oldLocation := officeLocation(office)
+	req.apply(office)
+
+	if err := h.repo.UpdateOffice(office, oldLocation, officeLocation(office)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update office"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Office updated successfully",
+		"data":    office,
+	})
+}
+
+// DeleteOffice DELETE /api/v1/companies/me/offices/:id
+func (h *CompanyHandler) DeleteOffice(c *gin.Context) {
+	office, ok := h.findMyOffice(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteOffice(office); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete office"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Office deleted successfully"})
+}
+
+// findMyOffice loads the office in the URL if it belongs to the caller's company
+func (h *CompanyHandler) findMyOffice(c *gin.Context) (*models.CompanyOffice, bool) {
+	userID, _ := middleware.GetUserID(c)
+	officeID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid office ID"})
+		return nil, false
+	}
+
+	company, err := h.repo.FindByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company profile not found"})
+		return nil, false
+	}
+
+	office, err := h.repo.FindOffice(company.ID, officeID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Office not found"})
+		return nil, false
+	}
+	return office, true
+}
+
+// officeLocation is the free-text location used for jobs posted at an office, e.g. "Gulshan, Dhaka"
+func officeLocation(office *models.CompanyOffice) string {
+	parts := make([]string, 0, 2)
+	if office.Area != "" {
+		parts = append(parts, office.Area)
+	}
+	parts = append(parts, office.District)
+	return strings.Join(parts, ", ")
+}
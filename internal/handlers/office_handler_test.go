@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUpdateOfficeRelocatesItsJobs(t *testing.T) {
+	tables := reviewedCompanyTables()
+	tables["company_offices"] = fakeTable{
+		columns: []string{"id", "company_id", "name", "division", "district", "area"},
+		rows:    [][]driver.Value{{int64(3), int64(1), "Main", "Dhaka", "Dhaka", "Banani"}},
+	}
+	execs := useFakeDB(t, tables)
+	h := NewCompanyHandler()
+
+	body := `{"name":"Main","division":"Dhaka","district":"Dhaka","area":"Gulshan"}`
+	if code := serveAs(companyOwner.id, http.MethodPut, "/companies/me/offices/:id", "/companies/me/offices/3", body, h.UpdateOffice).Code; code != http.StatusOK {
+		t.Fatalf("PUT office returned %d", code)
+	}
+
+	for _, exec := range *execs {
+		if strings.Contains(exec.query, `UPDATE "job_posts" SET "location"`) {
+			// SET location = $1, updated_at = $2 WHERE office_id = $3 AND location = $4
+			if len(exec.args) != 4 || exec.args[0] != "Gulshan, Dhaka" || exec.args[2] != int64(3) || exec.args[3] != "Banani, Dhaka" {
+				t.Errorf("jobs relocated with %v, want office 3 moved from %q to %q", exec.args, "Banani, Dhaka", "Gulshan, Dhaka")
+			}
+			return
+		}
+	}
+	t.Errorf("jobs at the office were not relocated: %v", *execs)
+}
+
+func TestListOfficesOfMissingCompany(t *testing.T) {
+	useFakeDB(t, reviewedCompanyTables())
+	h := NewCompanyHandler()
+
+	if code := serveAs(namedReviewer.id, http.MethodGet, "/companies/:id/offices", "/companies/2/offices", "", h.ListOffices).Code; code != http.StatusNotFound {
+		t.Errorf("GET offices of a missing company returned %d, want %d", code, http.StatusNotFound)
+	}
+}
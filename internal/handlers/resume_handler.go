@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/pkg/document"
+	"github.com/gin-gonic/gin"
+)
+
+type ResumeHandler struct {
+	resumeService *services.ResumeService
+	developers    *DeveloperHandler
+}
+
+func NewResumeHandler(resumeService *services.ResumeService) *ResumeHandler {
+	return &ResumeHandler{
+		resumeService: resumeService,
+		developers:    NewDeveloperHandler(),
+	}
+}
+
+// resumeDraftRequest is a reviewed resume draft. Entries use the same rules as when added one by one.
+type resumeDraftRequest struct {
+	Experiences []experienceRequest `json:"experiences" validate:"max=50,dive"`
+	Educations  []educationRequest  `json:"educations" validate:"max=20,dive"`
+	Skills      []skillRequest      `json:"skills" validate:"max=50,dive"`
+	Languages   []skillRequest      `json:"languages" validate:"max=50,dive"`
+}
+
+func resumeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrResumeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resume not found"})
+	case errors.Is(err, services.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, document.ErrUnsupportedFormat):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process resume"})
+	}
+}
+
+// UploadMyResume POST /api/v1/developers/me/resume
+func (h *ResumeHandler) UploadMyResume(c *gin.Context) {
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	data, filename, err := readUploadedFile(c, "file", h.resumeService.MaxResumeSize())
+	if err != nil {
+		if errors.Is(err, services.ErrFileTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Resume must be at most %d bytes", h.resumeService.MaxResumeSize())})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file must be uploaded in the \"file\" field"})
+		return
+	}
+
+	upload, err := h.resumeService.Upload(c.Request.Context(), developer.ID, filename, data)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Resume uploaded successfully",
+		"data":    upload,
+	})
+}
+
+// GetMyResume GET /api/v1/developers/me/resume
+func (h *ResumeHandler) GetMyResume(c *gin.Context) {
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	upload, err := h.resumeService.Current(developer.ID)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Resume retrieved successfully",
+		"data":    upload,
+	})
+}
+
+// DownloadMyResume GET /api/v1/developers/me/resume/file
+func (h *ResumeHandler) DownloadMyResume(c *gin.Context) {
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+	h.sendResume(c, developer.ID)
+}
+
+// DeleteMyResume DELETE /api/v1/developers/me/resume
+func (h *ResumeHandler) DeleteMyResume(c *gin.Context) {
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	if err := h.resumeService.Delete(c.Request.Context(), developer.ID); err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Resume deleted successfully"})
+}
+
+// ConfirmResumeDraft POST /api/v1/developers/me/resume/confirm
+// Adds the reviewed entries to the profile. Skills the developer already has are left unchanged.
+func (h *ResumeHandler) ConfirmResumeDraft(c *gin.Context) {
+	var req resumeDraftRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !checkSkills(c, req.Skills, h.developers.techRepo.CountTechnologies) ||
+		!checkSkills(c, req.Languages, h.developers.techRepo.CountLanguages) {
+		return
+	}
+
+	developer, ok := h.developers.myDeveloper(c)
+	if !ok {
+		return
+	}
+
+	experiences := make([]models.DeveloperExperience, len(req.Experiences))
+	for i := range req.Experiences {
+		req.Experiences[i].apply(&experiences[i])
+		experiences[i].DeveloperID = developer.ID
+	}
+	educations := make([]models.DeveloperEducation, len(req.Educations))
+	for i := range req.Educations {
+		req.Educations[i].apply(&educations[i])
+		educations[i].DeveloperID = developer.ID
+	}
+	skills := make([]models.DeveloperTechnology, 0, len(req.Skills))
+	for _, skill := range req.Skills {
+		skills = append(skills, models.DeveloperTechnology{
+			DeveloperProfileID: developer.ID,
+			TechnologyID:       skill.ID,
+			Proficiency:        skill.Proficiency,
+			YearsOfExperience:  skill.YearsOfExperience,
+			IsPrimary:          skill.IsPrimary,
+		})
+	}
+	languages := make([]models.DeveloperLanguage, 0, len(req.Languages))
+	for _, lang := range req.Languages {
+		languages = append(languages, models.DeveloperLanguage{
+			DeveloperProfileID:    developer.ID,
+			ProgrammingLanguageID: lang.ID,
+			Proficiency:           lang.Proficiency,
+			YearsOfExperience:     lang.YearsOfExperience,
+			IsPrimary:             lang.IsPrimary,
+		})
+	}
+
+	if err := h.developers.repo.AddProfileEntries(experiences, educations, skills, languages); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save profile entries"})
+		return
+	}
+
+	developer, err := h.developers.repo.FindByID(developer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch developer profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Resume entries added successfully",
+		"data":    dto.NewDeveloper(developer, dto.DeveloperAccess{Full: true}),
+	})
+}
+
+// DownloadDeveloperResume GET /api/v1/developers/:id/resume
+// Available to the developer, admins and companies the developer applied to or accepted a contact request from.
+func (h *ResumeHandler) DownloadDeveloperResume(c *gin.Context) {
+	developerID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid developer ID"})
+		return
+	}
+
+	developer, err := h.developers.repo.FindByID(developerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+		return
+	}
+
+	access, ok := h.developers.developerAccess(c, developer)
+	if !ok {
+		return
+	}
+	if !access.Full && !access.CanContact {
+		c.JSON(http.StatusForbidden, gin.H{"error": "The resume is only shared with companies the developer applied to or accepted a contact request from"})
+		return
+	}
+
+	h.sendResume(c, developer.ID)
+}
+
+func (h *ResumeHandler) sendResume(c *gin.Context, developerID uint) {
+	reader, resume, err := h.resumeService.Open(c.Request.Context(), developerID)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Content-Type", resume.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": resume.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, reader)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SalaryHandler struct {
+	salaryService *services.SalaryService
+}
+
+func NewSalaryHandler(salaryService *services.SalaryService) *SalaryHandler {
+	return &SalaryHandler{salaryService: salaryService}
+}
+
+// SubmitSalary POST /api/v1/companies/:id/salaries
+func (h *SalaryHandler) SubmitSalary(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	var req services.SubmitSalaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	report, err := h.salaryService.Submit(userID, companyID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Salary report submitted successfully"
+	if report.IsOutlier {
+		message = "Salary report submitted successfully (pending review)"
+	}
+
+	// Only echo back what the reporter sent; the report stays anonymous everywhere else
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message,
+		"data": gin.H{
+			"id":                  report.ID,
+			"job_title":           report.JobTitle,
+			"level":               report.Level,
+			"years_of_experience": report.YearsOfExperience,
+			"base_salary":         report.BaseSalary,
+			"annual_bonus":        report.AnnualBonus,
+			"location":            report.Location,
+			"status":              report.Moderation.Status,
+		},
+	})
+}
+
+// GetCompanySalaries GET /api/v1/companies/:id/salaries
+func (h *SalaryHandler) GetCompanySalaries(c *gin.Context) {
+	companyID, err := getIDFromURL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		return
+	}
+
+	summary, err := h.salaryService.Summary(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch salaries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Salaries retrieved successfully",
+		"data":    summary,
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
+	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type VerificationHandler struct {
+	verificationService *services.VerificationService
+}
+
+func NewVerificationHandler(verificationService *services.VerificationService) *VerificationHandler {
+	return &VerificationHandler{verificationService: verificationService}
+}
+
+// StartVerification POST /api/v1/companies/me/verification
+func (h *VerificationHandler) StartVerification(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req services.StartVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); validationErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	challenge, err := h.verificationService.Start(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Verification started successfully",
+		"data":    challenge,
+	})
+}
+
+// CheckVerification POST /api/v1/companies/me/verification/check
+func (h *VerificationHandler) CheckVerification(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+
+	verification, err := h.verificationService.Check(userID, req.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Verification checked successfully",
+		"data":    verification,
+	})
+}
+
+// GetVerification GET /api/v1/companies/me/verification
+func (h *VerificationHandler) GetVerification(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	verification, err := h.verificationService.Status(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Verification not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Verification retrieved successfully",
+		"data":    verification,
+	})
+}
@@ -20,29 +20,11 @@ const (
 // AuthMiddleware validates JWT tokens from cookies or Authorization header
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var tokenString string
-
-		// First, try to get token from cookie (preferred for browser clients)
-		token, err := utils.GetAuthCookie(c.Request)
-		if err == nil && token != "" {
-			tokenString = token
-		} else {
-			// Fallback to Authorization header (for API clients like Postman)
-			authHeader := c.GetHeader("Authorization")
-			if authHeader == "" {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
-				c.Abort()
-				return
-			}
-
-			// Extract token from "Bearer <token>"
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-				c.Abort()
-				return
-			}
-			tokenString = parts[1]
+		tokenString, errMessage := tokenFromRequest(c)
+		if errMessage != "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": errMessage})
+			c.Abort()
+			return
 		}
 
 		// Validate token
@@ -55,15 +37,54 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Set user info in Gin context
-		c.Set(string(UserIDKey), claims.UserID)
-		c.Set(string(UserEmailKey), claims.Email)
-		c.Set(string(UserRoleKey), claims.Role)
+		setClaims(c, claims)
+		c.Next()
+	}
+}
 
+// OptionalAuthMiddleware identifies the caller when a valid token is present,
+// but lets anonymous requests and invalid tokens through without user info
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tokenString, errMessage := tokenFromRequest(c); errMessage == "" {
+			if claims, err := utils.ValidateToken(tokenString, config.AppConfig.JWTSecret); err == nil {
+				setClaims(c, claims)
+			}
+		}
 		c.Next()
 	}
 }
 
+// tokenFromRequest returns the JWT from the auth cookie or Authorization header,
+// or an error message when neither holds one
+func tokenFromRequest(c *gin.Context) (string, string) {
+	// First, try to get token from cookie (preferred for browser clients)
+	token, err := utils.GetAuthCookie(c.Request)
+	if err == nil && token != "" {
+		return token, ""
+	}
+
+	// Fallback to Authorization header (for API clients like Postman)
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return "", "Authentication required"
+	}
+
+	// Extract token from "Bearer <token>"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", "Invalid authorization header format"
+	}
+	return parts[1], ""
+}
+
+// setClaims stores the user info from a validated token in the Gin context
+func setClaims(c *gin.Context, claims *utils.JWTClaims) {
+	c.Set(string(UserIDKey), claims.UserID)
+	c.Set(string(UserEmailKey), claims.Email)
+	c.Set(string(UserRoleKey), claims.Role)
+}
+
 // RoleMiddleware checks if the user has one of the allowed roles
 func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Company activity types shown in followers' feeds
+const (
+	ActivityJobPosted        = "job_posted"
+	ActivityEmployerResponse = "employer_response"
+	ActivityProfileUpdated   = "profile_updated"
+	ActivityCompanyVerified  = "company_verified"
+)
+
+// CompanyFollower links a user to a company they follow
+type CompanyFollower struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_follower_user_company,priority:1" json:"user_id"`
+	CompanyID uint      `gorm:"not null;uniqueIndex:idx_follower_user_company,priority:2;index" json:"company_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	User    User           `gorm:"foreignKey:UserID" json:"-"`
+	Company CompanyProfile `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// CompanyActivity is an event published by a company to its followers' feeds
+type CompanyActivity struct {
+	ID        uint      `gorm:"primaryKey;index:idx_activity_company_id,priority:2" json:"id"`
+	CompanyID uint      `gorm:"not null;index:idx_activity_company_id,priority:1" json:"company_id"`
+	Type      string    `gorm:"size:50;not null" json:"type"` // job_posted, employer_response, profile_updated, company_verified
+	SubjectID *uint     `json:"subject_id,omitempty"`         // the job post (job_posted) or review (employer_response)
+	Summary   string    `gorm:"size:500" json:"summary"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	Company CompanyProfile `gorm:"foreignKey:CompanyID" json:"company"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// Company claim statuses
+const (
+	ClaimPending  = "pending"
+	ClaimApproved = "approved"
+	ClaimRejected = "rejected"
+)
+
+// Ownership transfer reasons
+const (
+	TransferClaimApproved = "claim_approved"
+	TransferAdmin         = "admin_transfer"
+	TransferReleased      = "released"
+)
+
+// CompanyClaim is a user's request to take ownership of an unowned company page.
+// Proof is a verified work email at the company's domain and/or an uploaded document.
+type CompanyClaim struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	CompanyID          uint       `gorm:"not null;index" json:"company_id"`
+	UserID             uint       `gorm:"not null;index" json:"user_id"`
+	JobTitle           string     `gorm:"size:255" json:"job_title"`
+	Message            string     `gorm:"type:text" json:"message"`
+	WorkEmail          string     `gorm:"size:255" json:"work_email,omitempty"`
+	EmailCode          string     `gorm:"size:100" json:"-"`
+	EmailCodeExpiresAt *time.Time `json:"-"`
+	EmailVerifiedAt    *time.Time `json:"email_verified_at,omitempty"`
+	DocumentKey        string     `gorm:"size:255" json:"-"`
+	DocumentName       string     `gorm:"size:255" json:"document_name,omitempty"`
+	DocumentType       string     `gorm:"size:100" json:"document_type,omitempty"`
+	Status             string     `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, approved, rejected
+	ReviewedBy         *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt         *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote         string     `gorm:"type:text" json:"review_note,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	// Relations
+	Company CompanyProfile `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	User    User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// CompanyOwnershipTransfer is the audit trail of every change of a company's owner
+type CompanyOwnershipTransfer struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	CompanyID  uint      `gorm:"not null;index" json:"company_id"`
+	FromUserID *uint     `json:"from_user_id"`
+	ToUserID   *uint     `json:"to_user_id"`
+	ClaimID    *uint     `json:"claim_id,omitempty"`
+	ActorID    uint      `gorm:"not null" json:"actor_id"`
+	Reason     string    `gorm:"size:50;not null" json:"reason"` // claim_approved, admin_transfer, released
+	Note       string    `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relations
+	Actor User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
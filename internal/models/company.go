@@ -6,24 +6,100 @@ import (
 	"gorm.io/gorm"
 )
 
-// CompanyProfile represents a company's profile
+// CompanyProfile represents a company's profile.
+// Pages seeded by admins have no owner (UserID is nil) until a claim is approved.
 type CompanyProfile struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	UserID      uint           `gorm:"not null;uniqueIndex" json:"user_id"`
-	CompanyName string         `gorm:"size:255;not null" json:"company_name"`
-	Description string         `gorm:"type:text" json:"description"`
-	Website     string         `gorm:"size:255" json:"website"`
-	Location    string         `gorm:"size:255" json:"location"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	UserID            *uint          `gorm:"uniqueIndex" json:"user_id"`
+	CompanyName       string         `gorm:"size:255;not null" json:"company_name"`
+	Slug              string         `gorm:"size:100;uniqueIndex" json:"slug"`
+	Description       string         `gorm:"type:text" json:"description"`
+	Website           string         `gorm:"size:255" json:"website"`
+	Location          string         `gorm:"size:255" json:"location"`
+	LogoURL           string         `gorm:"size:500" json:"logo_url"`
+	LogoThumbnailURL  string         `gorm:"size:500" json:"logo_thumbnail_url"`
+	LogoKey           string         `gorm:"size:255" json:"-"`
+	CoverURL          string         `gorm:"size:500" json:"cover_url"`
+	CoverThumbnailURL string         `gorm:"size:500" json:"cover_thumbnail_url"`
+	CoverKey          string         `gorm:"size:255" json:"-"`
+	IsVerified        bool           `gorm:"default:false;index" json:"is_verified"`
+	VerifiedAt        *time.Time     `json:"verified_at,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	User         User                    `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Technologies []Technology            `gorm:"many2many:company_technologies;" json:"technologies,omitempty"`
-	JobPosts     []JobPost               `gorm:"foreignKey:CompanyID" json:"job_posts,omitempty"`
-	Ratings      []CompanyRating         `gorm:"foreignKey:CompanyID" json:"ratings,omitempty"`
-	Reviews      []CompanyReview         `gorm:"foreignKey:CompanyID" json:"reviews,omitempty"`
+	User         *User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Technologies []Technology    `gorm:"many2many:company_technologies;" json:"technologies,omitempty"`
+	JobPosts     []JobPost       `gorm:"foreignKey:CompanyID" json:"job_posts,omitempty"`
+	Ratings      []CompanyRating `gorm:"foreignKey:CompanyID" json:"ratings,omitempty"`
+	Reviews      []CompanyReview `gorm:"foreignKey:CompanyID" json:"reviews,omitempty"`
+	Offices      []CompanyOffice `gorm:"foreignKey:CompanyID" json:"offices,omitempty"`
+}
+
+// Administrative divisions of Bangladesh
+var Divisions = []string{"Dhaka", "Chattogram", "Rajshahi", "Khulna", "Barishal", "Sylhet", "Rangpur", "Mymensingh"}
+
+// CompanyOffice is one of a company's office locations
+type CompanyOffice struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	CompanyID      uint           `gorm:"not null;index" json:"company_id"`
+	Name           string         `gorm:"size:255" json:"name"` // e.g. "Gulshan HQ"
+	Division       string         `gorm:"size:50;not null;index" json:"division"`
+	District       string         `gorm:"size:100;not null;index" json:"district"`
+	Area           string         `gorm:"size:255" json:"area"`
+	AddressLine    string         `gorm:"size:500" json:"address_line"`
+	PostalCode     string         `gorm:"size:20" json:"postal_code"`
+	Latitude       *float64       `json:"latitude,omitempty"`
+	Longitude      *float64       `json:"longitude,omitempty"`
+	IsHeadquarters bool           `gorm:"default:false" json:"is_headquarters"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// CompanySlugRedirect keeps a company's previous slug so old links permanently redirect after a rename
+type CompanySlugRedirect struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CompanyID uint      `gorm:"not null;index" json:"company_id"`
+	Slug      string    `gorm:"size:100;not null;uniqueIndex" json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Company verification methods
+const (
+	VerificationMethodDNS   = "dns"
+	VerificationMethodHTTP  = "http"
+	VerificationMethodEmail = "email"
+)
+
+// Company verification statuses
+const (
+	VerificationPending  = "pending"
+	VerificationVerified = "verified"
+	VerificationFailed   = "failed"
+	VerificationExpired  = "expired"
+)
+
+// CompanyVerification is a challenge proving that a company controls the domain of its website
+type CompanyVerification struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	CompanyID     uint       `gorm:"not null;index" json:"company_id"`
+	Domain        string     `gorm:"size:255;not null" json:"domain"`
+	Method        string     `gorm:"size:20;not null" json:"method"` // dns, http, email
+	Email         string     `gorm:"size:255" json:"email,omitempty"`
+	Token         string     `gorm:"size:100;not null" json:"-"`
+	Status        string     `gorm:"size:20;not null;default:'pending'" json:"status"` // pending, verified, failed, expired
+	Attempts      int        `gorm:"default:0" json:"attempts"`
+	LastError     string     `gorm:"size:500" json:"last_error,omitempty"`
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	ExpiresAt     time.Time  `gorm:"not null" json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Relations
+	Company CompanyProfile `gorm:"foreignKey:CompanyID" json:"-"`
 }
 
 // CompanyRating represents a rating given to a company
@@ -42,20 +118,49 @@ type CompanyRating struct {
 
 // CompanyReview represents a review for a company
 type CompanyReview struct {
-	ID         uint           `gorm:"primaryKey" json:"id"`
-	CompanyID  uint           `gorm:"not null;index" json:"company_id"`
-	UserID     uint           `gorm:"not null;index" json:"user_id"`
-	Content    string         `gorm:"type:text;not null" json:"content"`
-	IsApproved bool           `gorm:"default:false" json:"is_approved"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                  uint           `gorm:"primaryKey" json:"id"`
+	CompanyID           uint           `gorm:"not null;index" json:"company_id"`
+	UserID              uint           `gorm:"not null;index" json:"user_id"`
+	Title               string         `gorm:"size:150" json:"title"`
+	Pros                string         `gorm:"type:text" json:"pros"`
+	Cons                string         `gorm:"type:text" json:"cons"`
+	AdviceToManagement  string         `gorm:"type:text" json:"advice_to_management"`
+	Content             string         `gorm:"type:text" json:"content,omitempty"` // legacy free-text body, migrated to Pros
+	IsAnonymous         bool           `gorm:"default:false" json:"is_anonymous"`
+	JobTitle            string         `gorm:"size:255;index" json:"job_title"`
+	EmploymentStatus    string         `gorm:"size:20;index" json:"employment_status"` // current, former
+	YearsAtCompany      *int           `json:"years_at_company"`
+	IsApproved          bool           `gorm:"default:false" json:"is_approved"`
+	Moderation          Moderation     `gorm:"embedded" json:"moderation"`
+	EmployerResponse    string         `gorm:"type:text" json:"employer_response"`
+	EmployerRespondedAt *time.Time     `json:"employer_responded_at"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	Company   CompanyProfile            `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
-	User      User                      `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Reactions []CompanyReviewReaction   `gorm:"foreignKey:ReviewID" json:"reactions,omitempty"`
-	Comments  []CompanyReviewComment    `gorm:"foreignKey:ReviewID" json:"comments,omitempty"`
+	Company   CompanyProfile          `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	User      User                    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Reactions []CompanyReviewReaction `gorm:"foreignKey:ReviewID" json:"reactions,omitempty"`
+	Comments  []CompanyReviewComment  `gorm:"foreignKey:ReviewID" json:"comments,omitempty"`
+	Revisions []CompanyReviewRevision `gorm:"foreignKey:ReviewID" json:"revisions,omitempty"`
+}
+
+// CompanyReviewRevision is a snapshot of a review taken before its author edited it
+type CompanyReviewRevision struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	ReviewID           uint      `gorm:"not null;uniqueIndex:idx_review_revision,priority:1" json:"review_id"`
+	Revision           int       `gorm:"not null;uniqueIndex:idx_review_revision,priority:2" json:"revision"`
+	Title              string    `gorm:"size:150" json:"title"`
+	Pros               string    `gorm:"type:text" json:"pros"`
+	Cons               string    `gorm:"type:text" json:"cons"`
+	AdviceToManagement string    `gorm:"type:text" json:"advice_to_management"`
+	IsAnonymous        bool      `json:"is_anonymous"`
+	JobTitle           string    `gorm:"size:255" json:"job_title"`
+	EmploymentStatus   string    `gorm:"size:20" json:"employment_status"`
+	YearsAtCompany     *int      `json:"years_at_company"`
+	Status             string    `gorm:"size:20" json:"status"` // moderation status at the time of the edit
+	CreatedAt          time.Time `json:"created_at"`
 }
 
 // CompanyReviewReaction represents a reaction to a company review
@@ -102,3 +207,16 @@ type CompanyReviewReply struct {
 	Comment CompanyReviewComment `gorm:"foreignKey:CommentID" json:"comment,omitempty"`
 	User    User                 `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
+
+// CompanyAggregates holds the per-company figures used when comparing companies
+type CompanyAggregates struct {
+	RatingCounts   map[int]int64
+	RatingCount    int64
+	AverageRating  float64
+	ReviewCount    int64
+	OpenJobCount   int64
+	JobsWithSalary int64
+	SalaryMin      float64
+	SalaryMax      float64
+	JobLocations   []string
+}
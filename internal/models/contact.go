@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Contact request statuses
+const (
+	ContactRequestPending  = "pending"
+	ContactRequestAccepted = "accepted"
+	ContactRequestDeclined = "declined"
+)
+
+// ContactRequest asks a developer to share their contact details with a company.
+// Accepting it gives the company the same contact access as a job application.
+type ContactRequest struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	CompanyID     uint       `gorm:"not null;index" json:"company_id"`
+	DeveloperID   uint       `gorm:"not null;index" json:"developer_id"`
+	RequestedByID uint       `gorm:"not null" json:"requested_by_id"`
+	Message       string     `gorm:"type:text" json:"message"`
+	Status        string     `gorm:"size:20;not null;default:pending;index" json:"status"`
+	RespondedAt   *time.Time `json:"responded_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Relations
+	Company   *CompanyProfile   `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	Developer *DeveloperProfile `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
+}
@@ -10,11 +10,35 @@ import (
 type DeveloperProfile struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	UserID    uint           `gorm:"not null;uniqueIndex" json:"user_id"`
+	Slug      string         `gorm:"size:100;uniqueIndex" json:"slug"`
 	Bio       string         `gorm:"type:text" json:"bio"`
+	Location  string         `gorm:"size:255;index" json:"location"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Privacy settings
+	Visibility          string `gorm:"size:20;not null;default:public;index" json:"visibility"` // public, signed_in, companies
+	HideEmail           bool   `gorm:"default:false" json:"hide_email"`                         // even from companies with contact access
+	HideCurrentEmployer bool   `gorm:"default:false" json:"hide_current_employer"`
+	HideEducation       bool   `gorm:"default:false" json:"hide_education"`
+
+	// Profile picture
+	AvatarURL          string `gorm:"size:500" json:"avatar_url"`
+	AvatarThumbnailURL string `gorm:"size:500" json:"avatar_thumbnail_url"`
+	AvatarKey          string `gorm:"size:255" json:"-"`
+
+	// Job search, shown to companies only. The status lapses to not_looking when it expires.
+	OpenToWorkStatus    string     `gorm:"size:20;not null;default:not_looking;index" json:"open_to_work_status"`
+	OpenToWorkExpiresAt *time.Time `gorm:"index" json:"open_to_work_expires_at"`
+	DesiredRoles        StringList `gorm:"type:jsonb;not null;default:'[]'" json:"desired_roles"`
+	PreferredWorkModes  StringList `gorm:"type:jsonb;not null;default:'[]'" json:"preferred_work_modes"`
+	PreferredLocations  StringList `gorm:"type:jsonb;not null;default:'[]'" json:"preferred_locations"`
+	ExpectedSalaryMin   float64    `json:"expected_salary_min"` // monthly, in BDT
+	ExpectedSalaryMax   float64    `json:"expected_salary_max"`
+	NoticePeriodDays    *int       `json:"notice_period_days"`
+	AvailableFrom       *time.Time `json:"available_from"` // null when available now
+
 	// Relations
 	User                 User                    `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Experiences          []DeveloperExperience   `gorm:"foreignKey:DeveloperID" json:"experiences,omitempty"`
@@ -22,6 +46,61 @@ type DeveloperProfile struct {
 	Certificates         []DeveloperCertificate  `gorm:"foreignKey:DeveloperID" json:"certificates,omitempty"`
 	Technologies         []Technology            `gorm:"many2many:developer_technologies;" json:"technologies,omitempty"`
 	ProgrammingLanguages []ProgrammingLanguage   `gorm:"many2many:developer_languages;" json:"programming_languages,omitempty"`
+	Skills               []DeveloperTechnology   `gorm:"foreignKey:DeveloperProfileID" json:"skills,omitempty"`
+	Languages            []DeveloperLanguage     `gorm:"foreignKey:DeveloperProfileID" json:"languages,omitempty"`
+	Projects             []DeveloperProject      `gorm:"foreignKey:DeveloperID" json:"projects,omitempty"`
+}
+
+// Developer profile visibility
+const (
+	VisibilityPublic    = "public"    // anyone
+	VisibilitySignedIn  = "signed_in" // any signed-in user
+	VisibilityCompanies = "companies" // company accounts only
+)
+
+// Open-to-work statuses
+const (
+	OpenToWorkActivelyLooking = "actively_looking"
+	OpenToWorkOpenToOffers    = "open_to_offers"
+	OpenToWorkNotLooking      = "not_looking"
+)
+
+// OpenToWorkStatuses lists every open-to-work status
+var OpenToWorkStatuses = []string{OpenToWorkActivelyLooking, OpenToWorkOpenToOffers, OpenToWorkNotLooking}
+
+// Skill proficiency levels
+const (
+	ProficiencyBeginner     = "beginner"
+	ProficiencyIntermediate = "intermediate"
+	ProficiencyAdvanced     = "advanced"
+	ProficiencyExpert       = "expert"
+)
+
+// ProficiencyLevels lists the proficiency levels from lowest to highest
+var ProficiencyLevels = []string{ProficiencyBeginner, ProficiencyIntermediate, ProficiencyAdvanced, ProficiencyExpert}
+
+// DeveloperTechnology is the developer_technologies join row, describing how well a developer knows a technology
+type DeveloperTechnology struct {
+	DeveloperProfileID uint   `gorm:"primaryKey" json:"-"`
+	TechnologyID       uint   `gorm:"primaryKey" json:"technology_id"`
+	Proficiency        string `gorm:"size:20;not null;default:intermediate;index" json:"proficiency"`
+	YearsOfExperience  int    `gorm:"not null;default:0" json:"years_of_experience"`
+	IsPrimary          bool   `gorm:"not null;default:false" json:"is_primary"`
+
+	// Relations
+	Technology *Technology `gorm:"foreignKey:TechnologyID" json:"technology,omitempty"`
+}
+
+// DeveloperLanguage is the developer_languages join row, describing how well a developer knows a programming language
+type DeveloperLanguage struct {
+	DeveloperProfileID    uint   `gorm:"primaryKey" json:"-"`
+	ProgrammingLanguageID uint   `gorm:"primaryKey" json:"programming_language_id"`
+	Proficiency           string `gorm:"size:20;not null;default:intermediate;index" json:"proficiency"`
+	YearsOfExperience     int    `gorm:"not null;default:0" json:"years_of_experience"`
+	IsPrimary             bool   `gorm:"not null;default:false" json:"is_primary"`
+
+	// Relations
+	ProgrammingLanguage *ProgrammingLanguage `gorm:"foreignKey:ProgrammingLanguageID" json:"programming_language,omitempty"`
 }
 
 // DeveloperExperience represents work experience
@@ -38,7 +117,7 @@ type DeveloperExperience struct {
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	Developer DeveloperProfile `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
+	Developer *DeveloperProfile `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
 }
 
 // DeveloperEducation represents educational background
@@ -57,7 +136,7 @@ type DeveloperEducation struct {
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	Developer DeveloperProfile `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
+	Developer *DeveloperProfile `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
 }
 
 // DeveloperCertificate represents professional certificates
@@ -76,5 +155,54 @@ type DeveloperCertificate struct {
 	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	Developer DeveloperProfile `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
+	Developer *DeveloperProfile     `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
+	Reminders []CertificateReminder `gorm:"foreignKey:CertificateID" json:"-"`
+}
+
+// Certificate statuses shown on profiles
+const (
+	CertificateValid   = "valid"
+	CertificateExpired = "expired"
+)
+
+// CertificateReminder records an expiry reminder sent for a certificate. Reminders are tied to the expiration
+// date, so a renewed certificate is reminded again.
+type CertificateReminder struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	CertificateID  uint      `gorm:"not null;uniqueIndex:idx_certificate_reminder,priority:1" json:"certificate_id"`
+	ExpirationDate time.Time `gorm:"not null;uniqueIndex:idx_certificate_reminder,priority:2" json:"expiration_date"`
+	WindowDays     int       `gorm:"not null;uniqueIndex:idx_certificate_reminder,priority:3" json:"window_days"` // reminded this many days or less before expiry
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// DeveloperProject is a side, open source or freelance project shown on a developer profile
+type DeveloperProject struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	DeveloperID uint           `gorm:"not null;index" json:"developer_id"`
+	Title       string         `gorm:"size:255;not null" json:"title"`
+	Description string         `gorm:"type:text" json:"description"`
+	Role        string         `gorm:"size:255" json:"role"`
+	RepoURL     string         `gorm:"size:500" json:"repo_url"`
+	DemoURL     string         `gorm:"size:500" json:"demo_url"`
+	StartDate   *time.Time     `json:"start_date"`
+	EndDate     *time.Time     `json:"end_date"`
+	Position    int            `gorm:"not null;default:0" json:"position"` // order on the profile, lowest first
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Technologies []Technology            `gorm:"many2many:developer_project_technologies;" json:"technologies,omitempty"`
+	Images       []DeveloperProjectImage `gorm:"foreignKey:ProjectID" json:"images,omitempty"`
+}
+
+// DeveloperProjectImage is a picture in a project's gallery
+type DeveloperProjectImage struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ProjectID    uint      `gorm:"not null;index" json:"project_id"`
+	URL          string    `gorm:"size:500;not null" json:"url"`
+	ThumbnailURL string    `gorm:"size:500" json:"thumbnail_url"`
+	Key          string    `gorm:"size:255;not null" json:"-"`
+	Caption      string    `gorm:"size:255" json:"caption"`
+	CreatedAt    time.Time `json:"created_at"`
 }
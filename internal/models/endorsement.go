@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SkillEndorsement is a user vouching for one of a developer's skills.
+// Endorsements that look like part of a reciprocal ring are held for moderation and not counted until approved.
+// Withdrawn endorsements are soft deleted so they still count towards the endorser's daily limit.
+type SkillEndorsement struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	DeveloperID  uint           `gorm:"not null;uniqueIndex:idx_endorsement_skill_endorser,priority:1" json:"developer_id"`
+	TechnologyID uint           `gorm:"not null;uniqueIndex:idx_endorsement_skill_endorser,priority:2" json:"technology_id"`
+	EndorserID   uint           `gorm:"not null;uniqueIndex:idx_endorsement_skill_endorser,priority:3;index" json:"endorser_id"`
+	Context      string         `gorm:"size:255" json:"context"` // e.g. "worked together at X"
+	IsFlagged    bool           `gorm:"default:false;index" json:"is_flagged"`
+	FlagReason   string         `gorm:"size:255" json:"flag_reason,omitempty"`
+	Moderation   Moderation     `gorm:"embedded" json:"moderation"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Developer  *DeveloperProfile `gorm:"foreignKey:DeveloperID" json:"developer,omitempty"`
+	Technology *Technology       `gorm:"foreignKey:TechnologyID" json:"technology,omitempty"`
+	Endorser   *User             `gorm:"foreignKey:EndorserID" json:"endorser,omitempty"`
+}
+
+// EndorsementDetail is a counted endorsement of a developer's skill with what is shown about its endorser
+type EndorsementDetail struct {
+	TechnologyID       uint
+	Context            string
+	FullName           string
+	DeveloperSlug      string // empty unless the endorser has a developer profile the viewer may see
+	AvatarThumbnailURL string
+	Proficiency        string // the endorser's own proficiency in the skill, if they list it
+	CreatedAt          time.Time
+}
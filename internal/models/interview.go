@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Interview outcomes
+const (
+	InterviewOutcomeOffer      = "offer"
+	InterviewOutcomeRejected   = "rejected"
+	InterviewOutcomeNoResponse = "no_response"
+)
+
+// InterviewReport is a candidate's account of an interview at a company.
+// It goes through the same moderation as company reviews before it is shown publicly.
+type InterviewReport struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	CompanyID     uint           `gorm:"not null;index" json:"company_id"`
+	UserID        uint           `gorm:"not null;index" json:"user_id"`
+	JobPostID     *uint          `gorm:"index" json:"job_post_id,omitempty"` // set when the interview was for one of our listings
+	Role          string         `gorm:"size:255;not null;index" json:"role"`
+	Rounds        int            `gorm:"not null" json:"rounds"`
+	Process       string         `gorm:"type:text" json:"process"`
+	Questions     string         `gorm:"type:text" json:"questions"`
+	Difficulty    int            `gorm:"not null;index" json:"difficulty"`      // 1 (very easy) to 5 (very hard)
+	Outcome       string         `gorm:"size:20;not null;index" json:"outcome"` // offer, rejected, no_response
+	DurationDays  *int           `json:"duration_days,omitempty"`               // from first contact to the final answer
+	InterviewedAt *time.Time     `json:"interviewed_at,omitempty"`
+	IsAnonymous   bool           `gorm:"default:false" json:"is_anonymous"`
+	Moderation    Moderation     `gorm:"embedded" json:"moderation"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Company CompanyProfile `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	User    User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	JobPost *JobPost       `gorm:"foreignKey:JobPostID" json:"job_post,omitempty"`
+}
@@ -11,6 +11,7 @@ type JobPost struct {
 	ID                uint           `gorm:"primaryKey" json:"id"`
 	CompanyID         uint           `gorm:"not null;index" json:"company_id"`
 	Title             string         `gorm:"size:255;not null" json:"title"`
+	Slug              string         `gorm:"size:100;uniqueIndex" json:"slug"`
 	Description       string         `gorm:"type:text;not null" json:"description"`
 	SalaryMin         float64        `json:"salary_min"`
 	SalaryMax         float64        `json:"salary_max"`
@@ -18,16 +19,25 @@ type JobPost struct {
 	ExperienceMaxYears int           `json:"experience_max_years"`
 	WorkMode          string         `gorm:"size:50" json:"work_mode"` // office, hybrid, remote, onsite
 	Location          string         `gorm:"size:255" json:"location"`
+	OfficeID          *uint          `gorm:"index" json:"office_id"`
+	Status            string         `gorm:"size:20;not null;default:published;index" json:"status"` // published or unpublished
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
 	Company   CompanyProfile `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	Office    *CompanyOffice `gorm:"foreignKey:OfficeID" json:"office,omitempty"`
 	Reactions []PostReaction `gorm:"foreignKey:JobPostID" json:"reactions,omitempty"`
 	Comments  []PostComment  `gorm:"foreignKey:JobPostID" json:"comments,omitempty"`
 }
 
+// Job post statuses; unpublished posts are only shown to their company and admins
+const (
+	JobPublished   = "published"
+	JobUnpublished = "unpublished"
+)
+
 // PostReaction represents a reaction to a job post
 type PostReaction struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
@@ -71,3 +81,14 @@ type CommentReply struct {
 	Comment PostComment `gorm:"foreignKey:CommentID" json:"comment,omitempty"`
 	User    User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
+
+// Work modes of a job post, also used for developers' preferences
+const (
+	WorkModeOffice = "office"
+	WorkModeHybrid = "hybrid"
+	WorkModeRemote = "remote"
+	WorkModeOnsite = "onsite"
+)
+
+// WorkModes lists every work mode
+var WorkModes = []string{WorkModeOffice, WorkModeHybrid, WorkModeRemote, WorkModeOnsite}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Moderation statuses shared by user-submitted content
+const (
+	ModerationPending      = "pending"
+	ModerationApproved     = "approved"
+	ModerationRejected     = "rejected"
+	ModerationNeedsChanges = "needs_changes"
+)
+
+// Moderation holds the moderation state of user-submitted content
+type Moderation struct {
+	Status         string     `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, approved, rejected, needs_changes
+	ReasonCode     string     `gorm:"size:50" json:"reason_code,omitempty"`                   // spam, offensive, personal_info, off_topic, unverifiable, other
+	ModerationNote string     `gorm:"type:text" json:"moderation_note,omitempty"`
+	ModeratedBy    *uint      `json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+}
+
+// ModerationEvent records a single moderation decision for audit purposes
+type ModerationEvent struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ContentType string    `gorm:"size:50;not null;index:idx_moderation_content" json:"content_type"` // review
+	ContentID   uint      `gorm:"not null;index:idx_moderation_content" json:"content_id"`
+	ModeratorID uint      `gorm:"not null;index" json:"moderator_id"`
+	Status      string    `gorm:"size:20;not null" json:"status"`
+	ReasonCode  string    `gorm:"size:50" json:"reason_code,omitempty"`
+	Note        string    `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Relations
+	Moderator User `gorm:"foreignKey:ModeratorID" json:"moderator,omitempty"`
+}
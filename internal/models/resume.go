@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// DeveloperResume is the resume file a developer uploaded. The original is kept so companies the developer
+// applied to can download it; the extracted text is kept to rebuild the profile draft.
+type DeveloperResume struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	DeveloperID   uint      `gorm:"not null;uniqueIndex" json:"developer_id"`
+	FileKey       string    `gorm:"size:255;not null" json:"-"`
+	FileName      string    `gorm:"size:255" json:"file_name"`
+	ContentType   string    `gorm:"size:100" json:"content_type"`
+	Size          int64     `json:"size"`
+	ExtractedText string    `gorm:"type:text" json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SalaryReport is an anonymous salary submission for a company.
+// Individual reports are never shown publicly; only aggregates above a minimum sample size are.
+type SalaryReport struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	CompanyID         uint           `gorm:"not null;index:idx_salary_company_title,priority:1" json:"company_id"`
+	UserID            uint           `gorm:"not null;index" json:"user_id"`
+	JobTitle          string         `gorm:"size:255;not null" json:"job_title"`
+	NormalizedTitle   string         `gorm:"size:255;not null;index:idx_salary_company_title,priority:2" json:"normalized_title"`
+	Level             string         `gorm:"size:20;not null" json:"level"` // intern, junior, mid, senior, lead, principal, manager
+	YearsOfExperience int            `gorm:"not null" json:"years_of_experience"`
+	BaseSalary        float64        `gorm:"not null" json:"base_salary"`   // monthly, BDT
+	AnnualBonus       float64        `gorm:"default:0" json:"annual_bonus"` // BDT
+	Location          string         `gorm:"size:255" json:"location"`
+	IsOutlier         bool           `gorm:"default:false;index" json:"is_outlier"`
+	OutlierReason     string         `gorm:"size:255" json:"outlier_reason,omitempty"`
+	Moderation        Moderation     `gorm:"embedded" json:"moderation"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Company CompanyProfile `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	User    User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
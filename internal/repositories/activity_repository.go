@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// Follow operations
+func (r *ActivityRepository) Follow(userID, companyID uint) error {
+	follower := &models.CompanyFollower{UserID: userID, CompanyID: companyID}
+	return r.db.Where(follower).FirstOrCreate(follower).Error
+}
+
+func (r *ActivityRepository) Unfollow(userID, companyID uint) error {
+	return r.db.Where("user_id = ? AND company_id = ?", userID, companyID).Delete(&models.CompanyFollower{}).Error
+}
+
+func (r *ActivityRepository) IsFollowing(userID, companyID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyFollower{}).Where("user_id = ? AND company_id = ?", userID, companyID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ActivityRepository) CountFollowers(companyID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyFollower{}).Where("company_id = ?", companyID).Count(&count).Error
+	return count, err
+}
+
+func (r *ActivityRepository) ListFollowing(userID uint, page, limit int) ([]models.CompanyFollower, int64, error) {
+	var follows []models.CompanyFollower
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.db.Model(&models.CompanyFollower{}).Where("user_id = ?", userID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).Preload("Company").Order("created_at DESC").Find(&follows).Error
+	return follows, total, err
+}
+
+// Activity operations
+func (r *ActivityRepository) Create(activity *models.CompanyActivity) error {
+	return r.db.Create(activity).Error
+}
+
+// HasActivity reports whether an activity of the given type about subjectID was ever recorded
+func (r *ActivityRepository) HasActivity(activityType string, subjectID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyActivity{}).
+		Where("type = ? AND subject_id = ?", activityType, subjectID).Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteBySubject removes the activities of the given type about subjectID
+func (r *ActivityRepository) DeleteBySubject(activityType string, subjectID uint) error {
+	return r.db.Where("type = ? AND subject_id = ?", activityType, subjectID).Delete(&models.CompanyActivity{}).Error
+}
+
+// Feed returns activities of the companies a user follows, newest first, with IDs below beforeID.
+// A single join against the follower index keeps this fast regardless of how many companies are followed.
+// New job activities are hidden while their job is unpublished or deleted.
+func (r *ActivityRepository) Feed(userID, beforeID uint, limit int) ([]models.CompanyActivity, error) {
+	var activities []models.CompanyActivity
+
+	query := r.db.Model(&models.CompanyActivity{}).
+		Joins("JOIN company_followers ON company_followers.company_id = company_activities.company_id").
+		Where("company_followers.user_id = ?", userID).
+		Where("company_activities.type <> ? OR EXISTS (SELECT 1 FROM job_posts WHERE job_posts.id = company_activities.subject_id "+
+			"AND job_posts.status = ? AND job_posts.deleted_at IS NULL)", models.ActivityJobPosted, models.JobPublished).
+		Where("company_activities.type <> ? OR EXISTS (SELECT 1 FROM company_reviews WHERE company_reviews.id = company_activities.subject_id "+
+			"AND company_reviews.is_approved = ? AND company_reviews.deleted_at IS NULL)", models.ActivityEmployerResponse, true)
+
+	if beforeID > 0 {
+		query = query.Where("company_activities.id < ?", beforeID)
+	}
+
+	err := query.Order("company_activities.id DESC").Limit(limit).
+		Preload("Company", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "company_name", "slug", "logo_thumbnail_url", "is_verified")
+		}).Find(&activities).Error
+	return activities, err
+}
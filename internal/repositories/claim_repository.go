@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ClaimRepository struct {
+	db *gorm.DB
+}
+
+func NewClaimRepository(db *gorm.DB) *ClaimRepository {
+	return &ClaimRepository{db: db}
+}
+
+func (r *ClaimRepository) Create(claim *models.CompanyClaim) error {
+	return r.db.Create(claim).Error
+}
+
+func (r *ClaimRepository) FindByID(id uint) (*models.CompanyClaim, error) {
+	var claim models.CompanyClaim
+	err := r.db.Preload("Company").Preload("User").First(&claim, id).Error
+	return &claim, err
+}
+
+func (r *ClaimRepository) Update(claim *models.CompanyClaim) error {
+	return r.db.Omit("Company", "User").Save(claim).Error
+}
+
+// HasPending reports whether the user already has an open claim for the company
+func (r *ClaimRepository) HasPending(userID, companyID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyClaim{}).
+		Where("user_id = ? AND company_id = ? AND status = ?", userID, companyID, models.ClaimPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ClaimRepository) ListByUser(userID uint) ([]models.CompanyClaim, error) {
+	var claims []models.CompanyClaim
+	err := r.db.Where("user_id = ?", userID).Preload("Company").Order("created_at DESC").Find(&claims).Error
+	return claims, err
+}
+
+func (r *ClaimRepository) ListByStatus(page, limit int, status string) ([]models.CompanyClaim, int64, error) {
+	var claims []models.CompanyClaim
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.db.Model(&models.CompanyClaim{}).Where("status = ?", status)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).Preload("Company").Preload("User").
+		Order("created_at ASC").Find(&claims).Error
+	return claims, total, err
+}
+
+// TransferOwnership changes the owner of a company and records the transfer.
+// When it comes from an approved claim, the claim is saved and other open claims for the company are rejected.
+// The company must still be owned by transfer.FromUserID, or be unowned when it is nil; otherwise nothing changes
+// and gorm.ErrRecordNotFound is returned, so two concurrent transfers cannot both succeed.
+func (r *ClaimRepository) TransferOwnership(transfer *models.CompanyOwnershipTransfer, claim *models.CompanyClaim) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.CompanyProfile{}).Where("id = ?", transfer.CompanyID)
+		if transfer.FromUserID == nil {
+			query = query.Where("user_id IS NULL")
+		} else {
+			query = query.Where("user_id = ?", *transfer.FromUserID)
+		}
+		result := query.Update("user_id", transfer.ToUserID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if claim != nil {
+			if err := tx.Omit("Company", "User").Save(claim).Error; err != nil {
+				return err
+			}
+
+			now := time.Now()
+			if err := tx.Model(&models.CompanyClaim{}).
+				Where("company_id = ? AND status = ? AND id <> ?", transfer.CompanyID, models.ClaimPending, claim.ID).
+				Updates(map[string]interface{}{
+					"status":      models.ClaimRejected,
+					"reviewed_by": claim.ReviewedBy,
+					"reviewed_at": now,
+					"review_note": "The company has been claimed by another user",
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(transfer).Error
+	})
+}
+
+func (r *ClaimRepository) ListTransfers(companyID uint) ([]models.CompanyOwnershipTransfer, error) {
+	var transfers []models.CompanyOwnershipTransfer
+	err := r.db.Where("company_id = ?", companyID).
+		Preload("Actor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "full_name", "email")
+		}).
+		Order("created_at ASC").Find(&transfers).Error
+	return transfers, err
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"github.com/bishworup11/bdSeeker-backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CompanyRepository struct {
@@ -14,16 +15,117 @@ func NewCompanyRepository(db *gorm.DB) *CompanyRepository {
 }
 
 func (r *CompanyRepository) Create(company *models.CompanyProfile) error {
-	return r.db.Create(company).Error
+	return r.createIn(r.db, company)
+}
+
+func (r *CompanyRepository) createIn(db *gorm.DB, company *models.CompanyProfile) error {
+	return retrySlugConflicts(db, func(tx *gorm.DB) error {
+		if err := assignCompanySlug(tx, company); err != nil {
+			return err
+		}
+		return tx.Create(company).Error
+	})
+}
+
+// CreateWithTechnologies creates a company together with its tech stack, so neither is saved without the other
+func (r *CompanyRepository) CreateWithTechnologies(company *models.CompanyProfile, techIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.createIn(tx, company); err != nil {
+			return err
+		}
+		return replaceCompanyTechnologies(tx, company, techIDs)
+	})
 }
 
 func (r *CompanyRepository) FindByID(id uint) (*models.CompanyProfile, error) {
+	return r.findOne("id = ?", id)
+}
+
+func (r *CompanyRepository) FindBySlug(slug string) (*models.CompanyProfile, error) {
+	return r.findOne("slug = ?", slug)
+}
+
+// Exists reports whether a company with the given ID exists
+func (r *CompanyRepository) Exists(id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyProfile{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *CompanyRepository) findOne(query string, args ...interface{}) (*models.CompanyProfile, error) {
 	var company models.CompanyProfile
 	err := r.db.Preload("User").Preload("Technologies").Preload("Ratings").
-		Preload("Reviews", "is_approved = ?", true).First(&company, id).Error
+		Preload("Reviews", "is_approved = ?", true).Preload("Reviews.User", selectPublicUser).
+		Preload("Offices", orderOffices).
+		Where(query, args...).First(&company).Error
 	return &company, err
 }
 
+// FindSlugRedirect returns the current slug of the company that previously used oldSlug
+func (r *CompanyRepository) FindSlugRedirect(oldSlug string) (string, error) {
+	var current string
+	err := r.db.Model(&models.CompanySlugRedirect{}).
+		Joins("JOIN company_profiles ON company_profiles.id = company_slug_redirects.company_id AND company_profiles.deleted_at IS NULL").
+		Where("company_slug_redirects.slug = ?", oldSlug).
+		Select("company_profiles.slug").
+		Take(&current).Error
+	return current, err
+}
+
+// UpdateProfile saves the company. When the name changed it gets a new slug and the old one is kept as a redirect.
+func (r *CompanyRepository) UpdateProfile(company *models.CompanyProfile, nameChanged bool) error {
+	return r.updateIn(r.db, company, nameChanged)
+}
+
+// UpdateWithTechnologies updates a company together with its tech stack, so neither is saved without the other
+func (r *CompanyRepository) UpdateWithTechnologies(company *models.CompanyProfile, nameChanged bool, techIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.updateIn(tx, company, nameChanged); err != nil {
+			return err
+		}
+		return replaceCompanyTechnologies(tx, company, techIDs)
+	})
+}
+
+func (r *CompanyRepository) updateIn(db *gorm.DB, company *models.CompanyProfile, nameChanged bool) error {
+	oldSlug := company.Slug
+	return retrySlugConflicts(db, func(tx *gorm.DB) error {
+		if nameChanged {
+			company.Slug = oldSlug
+			if err := assignCompanySlug(tx, company); err != nil {
+				return err
+			}
+
+			if company.Slug != oldSlug {
+				// Renaming back to a previous name takes the old slug back out of the redirects
+				if err := tx.Where("company_id = ? AND slug = ?", company.ID, company.Slug).
+					Delete(&models.CompanySlugRedirect{}).Error; err != nil {
+					return err
+				}
+				if oldSlug != "" {
+					redirect := &models.CompanySlugRedirect{CompanyID: company.ID, Slug: oldSlug}
+					if err := tx.Create(redirect).Error; err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return tx.Omit(clause.Associations).Save(company).Error
+	})
+}
+
+func replaceCompanyTechnologies(db *gorm.DB, company *models.CompanyProfile, techIDs []uint) error {
+	association := db.Model(company).Association("Technologies")
+	if len(techIDs) == 0 {
+		return association.Clear()
+	}
+	var technologies []models.Technology
+	if err := db.Where("id IN ?", techIDs).Order("name").Find(&technologies).Error; err != nil {
+		return err
+	}
+	return association.Replace(technologies)
+}
+
 func (r *CompanyRepository) FindByUserID(userID uint) (*models.CompanyProfile, error) {
 	var company models.CompanyProfile
 	err := r.db.Where("user_id = ?", userID).Preload("Technologies").First(&company).Error
@@ -34,6 +136,11 @@ func (r *CompanyRepository) Update(company *models.CompanyProfile) error {
 	return r.db.Save(company).Error
 }
 
+// UpdateFields updates only the given columns of a company profile
+func (r *CompanyRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&models.CompanyProfile{}).Where("id = ?", id).Updates(fields).Error
+}
+
 func (r *CompanyRepository) Delete(id uint) error {
 	return r.db.Delete(&models.CompanyProfile{}, id).Error
 }
@@ -46,7 +153,10 @@ func (r *CompanyRepository) List(page, limit int, location string, techIDs []uin
 	query := r.db.Model(&models.CompanyProfile{})
 
 	if location != "" {
-		query = query.Where("location ILIKE ?", "%"+location+"%")
+		pattern := "%" + location + "%"
+		query = query.Where("company_profiles.location ILIKE ? OR EXISTS ("+
+			"SELECT 1 FROM company_offices WHERE company_offices.company_id = company_profiles.id AND company_offices.deleted_at IS NULL"+
+			" AND "+officeLocationMatch+")", pattern, pattern, pattern, pattern)
 	}
 
 	if len(techIDs) > 0 {
@@ -59,10 +169,104 @@ func (r *CompanyRepository) List(page, limit int, location string, techIDs []uin
 		return nil, 0, err
 	}
 
-	err := query.Offset(offset).Limit(limit).Preload("Technologies").Preload("User").Find(&companies).Error
+	err := query.Offset(offset).Limit(limit).Preload("Technologies").
+		Preload("Offices", orderOffices).Find(&companies).Error
 	return companies, total, err
 }
 
+// officeLocationMatch matches a company_offices row against one ILIKE pattern in each of its location columns
+const officeLocationMatch = "(company_offices.division ILIKE ? OR company_offices.district ILIKE ? OR company_offices.area ILIKE ?)"
+
+// orderOffices lists the headquarters first
+func orderOffices(db *gorm.DB) *gorm.DB {
+	return db.Order("is_headquarters DESC, id ASC")
+}
+
+// Office operations
+func (r *CompanyRepository) ListOffices(companyID uint) ([]models.CompanyOffice, error) {
+	var offices []models.CompanyOffice
+	err := orderOffices(r.db.Where("company_id = ?", companyID)).Find(&offices).Error
+	return offices, err
+}
+
+func (r *CompanyRepository) FindOffice(companyID, officeID uint) (*models.CompanyOffice, error) {
+	var office models.CompanyOffice
+	err := r.db.Where("company_id = ?", companyID).First(&office, officeID).Error
+	return &office, err
+}
+
+// SaveOffice creates or updates an office. A company has at most one headquarters,
+// so marking an office as headquarters clears the flag on the others.
+func (r *CompanyRepository) SaveOffice(office *models.CompanyOffice) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return saveOfficeIn(tx, office)
+	})
+}
+
+// UpdateOffice saves an edited office and moves the jobs posted there from its old
+// location text to the new one. Jobs whose location was typed in by hand are left alone.
+func (r *CompanyRepository) UpdateOffice(office *models.CompanyOffice, oldLocation, newLocation string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveOfficeIn(tx, office); err != nil {
+			return err
+		}
+		if oldLocation == newLocation {
+			return nil
+		}
+		return tx.Model(&models.JobPost{}).
+			Where("office_id = ? AND location = ?", office.ID, oldLocation).
+			Update("location", newLocation).Error
+	})
+}
+
+func saveOfficeIn(db *gorm.DB, office *models.CompanyOffice) error {
+	if office.IsHeadquarters {
+		if err := db.Model(&models.CompanyOffice{}).
+			Where("company_id = ? AND id <> ?", office.CompanyID, office.ID).
+			Update("is_headquarters", false).Error; err != nil {
+			return err
+		}
+	}
+	return db.Save(office).Error
+}
+
+// DeleteOffice removes an office; jobs at that office keep their location text
+func (r *CompanyRepository) DeleteOffice(office *models.CompanyOffice) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.JobPost{}).Where("office_id = ?", office.ID).
+			Update("office_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(office).Error
+	})
+}
+
+// Verification operations
+func (r *CompanyRepository) CreateVerification(verification *models.CompanyVerification) error {
+	return r.db.Create(verification).Error
+}
+
+func (r *CompanyRepository) FindLatestVerification(companyID uint) (*models.CompanyVerification, error) {
+	var verification models.CompanyVerification
+	err := r.db.Where("company_id = ?", companyID).Order("created_at DESC").First(&verification).Error
+	return &verification, err
+}
+
+func (r *CompanyRepository) UpdateVerification(verification *models.CompanyVerification) error {
+	return r.db.Save(verification).Error
+}
+
+// MarkVerified completes a verification challenge and sets the company's verified badge
+func (r *CompanyRepository) MarkVerified(verification *models.CompanyVerification) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(verification).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.CompanyProfile{}).Where("id = ?", verification.CompanyID).
+			Updates(map[string]interface{}{"is_verified": true, "verified_at": verification.VerifiedAt}).Error
+	})
+}
+
 // Rating operations
 func (r *CompanyRepository) CreateRating(rating *models.CompanyRating) error {
 	return r.db.Create(rating).Error
@@ -100,11 +304,74 @@ func (r *CompanyRepository) UpdateReview(review *models.CompanyReview) error {
 	return r.db.Save(review).Error
 }
 
+// UpdateReviewWithRevision edits a user's review with edit, keeping a snapshot of its previous version.
+// The review is locked while the snapshot is taken and saved, so concurrent edits each snapshot the version
+// they replace. It returns gorm.ErrRecordNotFound when the user has no review with that ID.
+func (r *CompanyRepository) UpdateReviewWithRevision(reviewID, userID uint, edit func(review *models.CompanyReview)) (*models.CompanyReview, error) {
+	var review models.CompanyReview
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).
+			Preload("User").Preload("Reactions").Preload("Comments").First(&review, reviewID).Error; err != nil {
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&models.CompanyReviewRevision{}).Where("review_id = ?", review.ID).
+			Select("COALESCE(MAX(revision), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		revision := &models.CompanyReviewRevision{
+			ReviewID:           review.ID,
+			Revision:           latest + 1,
+			Title:              review.Title,
+			Pros:               review.Pros,
+			Cons:               review.Cons,
+			AdviceToManagement: review.AdviceToManagement,
+			IsAnonymous:        review.IsAnonymous,
+			JobTitle:           review.JobTitle,
+			EmploymentStatus:   review.EmploymentStatus,
+			YearsAtCompany:     review.YearsAtCompany,
+			Status:             review.Moderation.Status,
+		}
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+
+		edit(&review)
+		return tx.Omit("Company", "User", "Reactions", "Comments", "Revisions").Save(&review).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *CompanyRepository) ListReviewsByUser(userID uint, page, limit int, status string) ([]models.CompanyReview, int64, error) {
+	var reviews []models.CompanyReview
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.db.Model(&models.CompanyReview{}).Where("user_id = ?", userID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).Preload("Revisions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("revision ASC")
+	}).Order("updated_at DESC").Find(&reviews).Error
+	return reviews, total, err
+}
+
 func (r *CompanyRepository) DeleteReview(id uint) error {
 	return r.db.Delete(&models.CompanyReview{}, id).Error
 }
 
-func (r *CompanyRepository) ListReviews(companyID uint, page, limit int, approvedOnly bool) ([]models.CompanyReview, int64, error) {
+func (r *CompanyRepository) ListReviews(companyID uint, page, limit int, approvedOnly bool, filters map[string]interface{}) ([]models.CompanyReview, int64, error) {
 	var reviews []models.CompanyReview
 	var total int64
 
@@ -115,15 +382,32 @@ func (r *CompanyRepository) ListReviews(companyID uint, page, limit int, approve
 		query = query.Where("is_approved = ?", true)
 	}
 
+	if status, ok := filters["employment_status"].(string); ok && status != "" {
+		query = query.Where("employment_status = ?", status)
+	}
+
+	if jobTitle, ok := filters["job_title"].(string); ok && jobTitle != "" {
+		query = query.Where("job_title ILIKE ?", "%"+jobTitle+"%")
+	}
+
+	if minYears, ok := filters["min_years"].(int); ok && minYears > 0 {
+		query = query.Where("years_at_company >= ?", minYears)
+	}
+
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Offset(offset).Limit(limit).Preload("User").Preload("Reactions").
-		Preload("Comments", "is_approved = ?", true).Find(&reviews).Error
+	err := query.Offset(offset).Limit(limit).Preload("User", selectPublicUser).Preload("Reactions").
+		Preload("Comments", "is_approved = ?", true).Order("created_at DESC").Find(&reviews).Error
 	return reviews, total, err
 }
 
+// selectPublicUser limits a preloaded User to the columns that are safe to show publicly
+func selectPublicUser(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "full_name")
+}
+
 // Review reaction operations
 func (r *CompanyRepository) CreateReviewReaction(reaction *models.CompanyReviewReaction) error {
 	return r.db.Create(reaction).Error
@@ -158,3 +442,105 @@ func (r *CompanyRepository) UpdateReviewComment(comment *models.CompanyReviewCom
 func (r *CompanyRepository) CreateReviewReply(reply *models.CompanyReviewReply) error {
 	return r.db.Create(reply).Error
 }
+
+// FindManyForComparison loads the given companies with their tech stack and offices only
+func (r *CompanyRepository) FindManyForComparison(ids []uint) ([]models.CompanyProfile, error) {
+	var companies []models.CompanyProfile
+	err := r.db.Where("id IN ?", ids).Preload("Technologies").Preload("Offices", orderOffices).Find(&companies).Error
+	return companies, err
+}
+
+// ComparisonAggregates computes ratings, review counts, job counts and salary ranges for several companies,
+// with one grouped query per figure instead of one query per company
+func (r *CompanyRepository) ComparisonAggregates(ids []uint) (map[uint]*models.CompanyAggregates, error) {
+	aggregates := make(map[uint]*models.CompanyAggregates, len(ids))
+	for _, id := range ids {
+		aggregates[id] = &models.CompanyAggregates{RatingCounts: map[int]int64{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}}
+	}
+
+	var ratings []struct {
+		CompanyID uint
+		Rating    int
+		Count     int64
+	}
+	err := r.db.Model(&models.CompanyRating{}).
+		Select("company_id, rating, COUNT(*) AS count").
+		Where("company_id IN ?", ids).
+		Group("company_id, rating").
+		Scan(&ratings).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range ratings {
+		agg := aggregates[row.CompanyID]
+		agg.RatingCounts[row.Rating] = row.Count
+		agg.RatingCount += row.Count
+		agg.AverageRating += float64(row.Rating * int(row.Count))
+	}
+	for _, agg := range aggregates {
+		if agg.RatingCount > 0 {
+			agg.AverageRating /= float64(agg.RatingCount)
+		}
+	}
+
+	var reviews []struct {
+		CompanyID uint
+		Count     int64
+	}
+	err = r.db.Model(&models.CompanyReview{}).
+		Select("company_id, COUNT(*) AS count").
+		Where("company_id IN ? AND status = ?", ids, models.ModerationApproved).
+		Group("company_id").
+		Scan(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range reviews {
+		aggregates[row.CompanyID].ReviewCount = row.Count
+	}
+
+	var jobs []struct {
+		CompanyID      uint
+		Count          int64
+		JobsWithSalary int64
+		SalaryMin      float64
+		SalaryMax      float64
+	}
+	err = r.db.Model(&models.JobPost{}).
+		Select("company_id, COUNT(*) AS count, "+
+			"COUNT(*) FILTER (WHERE salary_min > 0 OR salary_max > 0) AS jobs_with_salary, "+
+			"COALESCE(MIN(NULLIF(salary_min, 0)), 0) AS salary_min, "+
+			"COALESCE(MAX(NULLIF(salary_max, 0)), 0) AS salary_max").
+		Where("company_id IN ? AND status = ?", ids, models.JobPublished).
+		Group("company_id").
+		Scan(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range jobs {
+		agg := aggregates[row.CompanyID]
+		agg.OpenJobCount = row.Count
+		agg.JobsWithSalary = row.JobsWithSalary
+		agg.SalaryMin = row.SalaryMin
+		agg.SalaryMax = row.SalaryMax
+	}
+
+	var locations []struct {
+		CompanyID uint
+		Location  string
+	}
+	err = r.db.Model(&models.JobPost{}).
+		Distinct("company_id", "location").
+		Where("company_id IN ? AND status = ? AND location <> ''", ids, models.JobPublished).
+		Order("location").
+		Scan(&locations).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range locations {
+		agg := aggregates[row.CompanyID]
+		agg.JobLocations = append(agg.JobLocations, row.Location)
+	}
+
+	return aggregates, nil
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// completenessCriterion is one weighted item of a profile completeness score.
+// condition is SQL evaluated on the profile row, so the same definition scores one profile and filters lists.
+type completenessCriterion struct {
+	Key       string
+	Label     string
+	Weight    int
+	condition string
+}
+
+// Weights of each profile type add up to 100
+var (
+	developerCompleteness = []completenessCriterion{
+		{"bio", "Write a short bio", 20, "TRIM(developer_profiles.bio) <> ''"},
+		{"experience", "Add at least one work experience", 25,
+			"EXISTS (SELECT 1 FROM developer_experiences WHERE developer_experiences.developer_id = developer_profiles.id AND developer_experiences.deleted_at IS NULL)"},
+		{"skills", "Add your skills", 20,
+			"(EXISTS (SELECT 1 FROM developer_technologies WHERE developer_technologies.developer_profile_id = developer_profiles.id) OR " +
+				"EXISTS (SELECT 1 FROM developer_languages WHERE developer_languages.developer_profile_id = developer_profiles.id))"},
+		{"education", "Add your education", 15,
+			"EXISTS (SELECT 1 FROM developer_educations WHERE developer_educations.developer_id = developer_profiles.id AND developer_educations.deleted_at IS NULL)"},
+		{"avatar", "Upload a profile picture", 20, "developer_profiles.avatar_url <> ''"},
+	}
+	companyCompleteness = []completenessCriterion{
+		{"description", "Describe the company", 30, "TRIM(company_profiles.description) <> ''"},
+		{"website", "Add the company website", 20, "TRIM(company_profiles.website) <> ''"},
+		{"tech_stack", "Add the company's tech stack", 25,
+			"EXISTS (SELECT 1 FROM company_technologies WHERE company_technologies.company_profile_id = company_profiles.id)"},
+		{"logo", "Upload a logo", 25, "company_profiles.logo_url <> ''"},
+	}
+)
+
+// developerCompletenessSQL is a developer's completeness score from 0 to 100
+var developerCompletenessSQL = completenessScoreSQL(developerCompleteness)
+
+func completenessScoreSQL(criteria []completenessCriterion) string {
+	terms := make([]string, len(criteria))
+	for i, criterion := range criteria {
+		terms[i] = fmt.Sprintf("CASE WHEN %s THEN %d ELSE 0 END", criterion.condition, criterion.Weight)
+	}
+	return "(" + strings.Join(terms, " + ") + ")"
+}
+
+// CompletenessItem is a checklist item that would raise a profile's completeness score
+type CompletenessItem struct {
+	Key    string `json:"key"`
+	Label  string `json:"label"`
+	Weight int    `json:"weight"`
+}
+
+// Completeness is a profile's score from 0 to 100 and what it is missing
+type Completeness struct {
+	Score   int                `json:"score"`
+	Missing []CompletenessItem `json:"missing"`
+}
+
+// completeness evaluates every criterion for one profile
+func completeness(db *gorm.DB, model interface{}, id uint, criteria []completenessCriterion) (*Completeness, error) {
+	columns := make([]string, len(criteria))
+	met := make([]bool, len(criteria))
+	dest := make([]interface{}, len(criteria))
+	for i, criterion := range criteria {
+		// Columns added by later migrations are NULL on older rows, which a bool cannot be scanned from
+		columns[i] = "COALESCE((" + criterion.condition + "), false)"
+		dest[i] = &met[i]
+	}
+
+	if err := db.Model(model).Select(strings.Join(columns, ", ")).Where("id = ?", id).Row().Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	result := &Completeness{Missing: []CompletenessItem{}}
+	for i, criterion := range criteria {
+		if met[i] {
+			result.Score += criterion.Weight
+		} else {
+			result.Missing = append(result.Missing, CompletenessItem{Key: criterion.Key, Label: criterion.Label, Weight: criterion.Weight})
+		}
+	}
+	return result, nil
+}
+
+// Completeness scores a developer profile
+func (r *DeveloperRepository) Completeness(id uint) (*Completeness, error) {
+	return completeness(r.db, &models.DeveloperProfile{}, id, developerCompleteness)
+}
+
+// Completeness scores a company profile
+func (r *CompanyRepository) Completeness(id uint) (*Completeness, error) {
+	return completeness(r.db, &models.CompanyProfile{}, id, companyCompleteness)
+}
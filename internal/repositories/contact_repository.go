@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ContactRepository struct {
+	db *gorm.DB
+}
+
+func NewContactRepository(db *gorm.DB) *ContactRepository {
+	return &ContactRepository{db: db}
+}
+
+func (r *ContactRepository) Create(request *models.ContactRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *ContactRepository) FindByID(id uint) (*models.ContactRequest, error) {
+	var request models.ContactRequest
+	err := r.db.First(&request, id).Error
+	return &request, err
+}
+
+func (r *ContactRepository) Update(request *models.ContactRequest) error {
+	return r.db.Model(request).Select("status", "responded_at").Updates(request).Error
+}
+
+// FindOpen returns the company's pending or accepted request to the developer, if any
+func (r *ContactRepository) FindOpen(companyID, developerID uint) (*models.ContactRequest, error) {
+	var request models.ContactRequest
+	err := r.db.Where("company_id = ? AND developer_id = ? AND status IN ?", companyID, developerID,
+		[]string{models.ContactRequestPending, models.ContactRequestAccepted}).
+		Order("created_at DESC").First(&request).Error
+	return &request, err
+}
+
+// ListForDeveloper returns the requests a developer received, newest first
+func (r *ContactRepository) ListForDeveloper(developerID uint, status string) ([]models.ContactRequest, error) {
+	var requests []models.ContactRequest
+	query := r.db.Where("developer_id = ?", developerID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Preload("Company", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "company_name", "slug", "logo_thumbnail_url", "is_verified")
+	}).Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+// ListForCompany returns the requests a company sent, newest first
+func (r *ContactRepository) ListForCompany(companyID uint, status string) ([]models.ContactRequest, error) {
+	var requests []models.ContactRequest
+	query := r.db.Where("company_id = ?", companyID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Preload("Developer").Preload("Developer.User", selectPublicUser).
+		Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+// HasContactAccess reports whether a company may see a developer's contact details:
+// the developer accepted a contact request from it or applied to one of its jobs
+func (r *ContactRepository) HasContactAccess(companyID uint, developer *models.DeveloperProfile) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ContactRequest{}).
+		Where("company_id = ? AND developer_id = ? AND status = ?", companyID, developer.ID, models.ContactRequestAccepted).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = r.db.Model(&models.PostReaction{}).
+		Joins("JOIN job_posts ON job_posts.id = post_reactions.job_post_id").
+		Where("post_reactions.user_id = ? AND post_reactions.type = ? AND job_posts.company_id = ?", developer.UserID, "apply", companyID).
+		Count(&count).Error
+	return count > 0, err
+}
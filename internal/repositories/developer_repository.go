@@ -1,8 +1,12 @@
 package repositories
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/bishworup11/bdSeeker-backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DeveloperRepository struct {
@@ -14,16 +18,44 @@ func NewDeveloperRepository(db *gorm.DB) *DeveloperRepository {
 }
 
 func (r *DeveloperRepository) Create(developer *models.DeveloperProfile) error {
-	return r.db.Create(developer).Error
+	return retrySlugConflicts(r.db, func(tx *gorm.DB) error {
+		if err := assignDeveloperSlug(tx, developer); err != nil {
+			return err
+		}
+		return tx.Create(developer).Error
+	})
 }
 
 func (r *DeveloperRepository) FindByID(id uint) (*models.DeveloperProfile, error) {
+	return r.findOne("id = ?", id)
+}
+
+func (r *DeveloperRepository) FindBySlug(slug string) (*models.DeveloperProfile, error) {
+	return r.findOne("slug = ?", slug)
+}
+
+func (r *DeveloperRepository) findOne(query string, args ...interface{}) (*models.DeveloperProfile, error) {
 	var developer models.DeveloperProfile
 	err := r.db.Preload("User").Preload("Technologies").Preload("ProgrammingLanguages").
-		Preload("Experiences").Preload("Educations").Preload("Certificates").First(&developer, id).Error
+		Preload("Skills", orderSkills).Preload("Skills.Technology").
+		Preload("Languages", orderSkills).Preload("Languages.ProgrammingLanguage").
+		Preload("Experiences", orderByStartDate).Preload("Educations", orderByStartDate).
+		Preload("Certificates", func(db *gorm.DB) *gorm.DB { return db.Order("issue_date DESC") }).
+		Preload("Projects", orderProjects).Preload("Projects.Technologies").Preload("Projects.Images", orderProjectImages).
+		Where(query, args...).First(&developer).Error
 	return &developer, err
 }
 
+// orderSkills lists primary skills first, then by years of use
+func orderSkills(db *gorm.DB) *gorm.DB {
+	return db.Order("is_primary DESC, years_of_experience DESC")
+}
+
+// orderByStartDate lists the most recent experience or education first
+func orderByStartDate(db *gorm.DB) *gorm.DB {
+	return db.Order("start_date DESC")
+}
+
 func (r *DeveloperRepository) FindByUserID(userID uint) (*models.DeveloperProfile, error) {
 	var developer models.DeveloperProfile
 	err := r.db.Where("user_id = ?", userID).Preload("Technologies").Preload("ProgrammingLanguages").First(&developer).Error
@@ -31,41 +63,473 @@ func (r *DeveloperRepository) FindByUserID(userID uint) (*models.DeveloperProfil
 }
 
 func (r *DeveloperRepository) Update(developer *models.DeveloperProfile) error {
-	return r.db.Save(developer).Error
+	return r.db.Omit(clause.Associations).Save(developer).Error
+}
+
+// UpdateFields updates only the given columns of a developer profile
+func (r *DeveloperRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&models.DeveloperProfile{}).Where("id = ?", id).Updates(fields).Error
 }
 
 func (r *DeveloperRepository) Delete(id uint) error {
 	return r.db.Delete(&models.DeveloperProfile{}, id).Error
 }
 
-func (r *DeveloperRepository) List(page, limit int, techIDs, langIDs []uint) ([]models.DeveloperProfile, int64, error) {
+// ScheduleOpenToWorkExpiry gives open-to-work statuses without an expiry one at expiresAt
+func (r *DeveloperRepository) ScheduleOpenToWorkExpiry(expiresAt time.Time) (int64, error) {
+	result := r.db.Model(&models.DeveloperProfile{}).
+		Where("open_to_work_status <> ? AND open_to_work_expires_at IS NULL", models.OpenToWorkNotLooking).
+		UpdateColumn("open_to_work_expires_at", expiresAt)
+	return result.RowsAffected, result.Error
+}
+
+// ExpireOpenToWork sets every open-to-work status that expired before now back to not_looking.
+// The profile's updated_at is kept since the developer did not edit it.
+func (r *DeveloperRepository) ExpireOpenToWork(now time.Time) (int64, error) {
+	result := r.db.Model(&models.DeveloperProfile{}).
+		Where("open_to_work_status <> ? AND open_to_work_expires_at < ?", models.OpenToWorkNotLooking, now).
+		UpdateColumns(map[string]interface{}{
+			"open_to_work_status":     models.OpenToWorkNotLooking,
+			"open_to_work_expires_at": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// experienceYearsSQL is a developer's total years of work experience.
+// Overlapping roles are merged so concurrent jobs count once, and current roles run until now.
+const experienceYearsSQL = `(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (period_end - period_start))), 0) / 31557600 FROM (
+	SELECT MIN(start_date) AS period_start, MAX(end_date) AS period_end FROM (
+		SELECT start_date, end_date, SUM(starts_period) OVER (ORDER BY start_date, end_date) AS period FROM (
+			SELECT start_date, COALESCE(end_date, NOW()) AS end_date,
+				CASE WHEN start_date <= MAX(COALESCE(end_date, NOW())) OVER (
+					ORDER BY start_date, COALESCE(end_date, NOW()) ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+				) THEN 0 ELSE 1 END AS starts_period
+			FROM developer_experiences
+			WHERE developer_experiences.developer_id = developer_profiles.id AND developer_experiences.deleted_at IS NULL
+		) AS marked
+	) AS grouped GROUP BY period
+) AS periods)`
+
+// List returns developers matching the filters:
+// tech_ids and lang_ids ([]uint, all must match, optionally at min_proficiency or above),
+// min_experience and max_experience (years), location, open_to_work (bool), degree, field_of_study,
+// certificate (name or issuer) and certificate_valid (bool, only unexpired certificates match), search (bio and experience titles), min_completeness (score from 0 to 100), visibilities ([]string) and sort_by.
+// Hidden education and current employers only match when show_hidden (bool) is set or for the developer whose
+// user ID is viewer_id (uint).
+// Job search filters: open_to_work_statuses and work_modes ([]string, any may match), desired_role and
+// preferred_location (matched against any listed value), max_expected_salary, max_notice_days and available_by (time.Time).
+func (r *DeveloperRepository) List(page, limit int, filters map[string]interface{}) ([]models.DeveloperProfile, int64, error) {
 	var developers []models.DeveloperProfile
 	var total int64
 
 	offset := (page - 1) * limit
-	query := r.db.Model(&models.DeveloperProfile{})
-
-	if len(techIDs) > 0 {
-		query = query.Joins("JOIN developer_technologies ON developer_technologies.developer_profile_id = developer_profiles.id").
-			Where("developer_technologies.technology_id IN ?", techIDs).
-			Distinct()
-	}
-
-	if len(langIDs) > 0 {
-		query = query.Joins("JOIN developer_languages ON developer_languages.developer_profile_id = developer_profiles.id").
-			Where("developer_languages.programming_language_id IN ?", langIDs).
-			Distinct()
-	}
+	query := r.filtered(filters)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	switch filters["sort_by"] {
+	case "experience_desc":
+		query = query.Order(experienceYearsSQL + " DESC")
+	case "experience_asc":
+		query = query.Order(experienceYearsSQL + " ASC")
+	case "completeness_desc":
+		query = query.Order(developerCompletenessSQL + " DESC").Order("developer_profiles.created_at DESC")
+	case "updated_desc":
+		query = query.Order("developer_profiles.updated_at DESC")
+	case "created_asc":
+		query = query.Order("developer_profiles.created_at ASC")
+	default:
+		query = query.Order("developer_profiles.created_at DESC")
+	}
+
 	err := query.Offset(offset).Limit(limit).Preload("User").Preload("Technologies").
 		Preload("ProgrammingLanguages").Find(&developers).Error
 	return developers, total, err
 }
 
+func (r *DeveloperRepository) filtered(filters map[string]interface{}) *gorm.DB {
+	query := r.db.Model(&models.DeveloperProfile{})
+	levels := models.ProficiencyLevels
+	if minProficiency, ok := filters["min_proficiency"].(string); ok {
+		if atLeast := proficienciesAtLeast(minProficiency); atLeast != nil {
+			levels = atLeast
+		}
+	}
+
+	if techIDs, ok := filters["tech_ids"].([]uint); ok && len(techIDs) > 0 {
+		// Technologies used in projects count as skills too, unless a minimum proficiency is asked for
+		matched := r.db.Model(&models.DeveloperTechnology{}).
+			Select("developer_profile_id AS developer_id, technology_id").
+			Where("technology_id IN ? AND proficiency IN ?", techIDs, levels)
+		if _, ok := filters["min_proficiency"].(string); !ok {
+			matched = r.db.Raw("(?) UNION (?)", matched, r.db.Table("developer_project_technologies").
+				Select("developer_projects.developer_id, developer_project_technologies.technology_id").
+				Joins("JOIN developer_projects ON developer_projects.id = developer_project_technologies.developer_project_id").
+				Where("developer_projects.deleted_at IS NULL AND developer_project_technologies.technology_id IN ?", techIDs))
+		}
+		query = query.Where("developer_profiles.id IN (?)", r.db.Table("(?) AS matched", matched).
+			Select("developer_id").
+			Group("developer_id").
+			Having("COUNT(*) = ?", len(techIDs)))
+	}
+
+	if langIDs, ok := filters["lang_ids"].([]uint); ok && len(langIDs) > 0 {
+		query = query.Where("developer_profiles.id IN (?)", r.db.Model(&models.DeveloperLanguage{}).
+			Select("developer_profile_id").
+			Where("programming_language_id IN ? AND proficiency IN ?", langIDs, levels).
+			Group("developer_profile_id").
+			Having("COUNT(*) = ?", len(langIDs)))
+	}
+
+	if minExp, ok := filters["min_experience"].(float64); ok && minExp > 0 {
+		query = query.Where(experienceYearsSQL+" >= ?", minExp)
+	}
+
+	if maxExp, ok := filters["max_experience"].(float64); ok && maxExp >= 0 {
+		query = query.Where(experienceYearsSQL+" <= ?", maxExp)
+	}
+
+	if minCompleteness, ok := filters["min_completeness"].(int); ok && minCompleteness > 0 {
+		query = query.Where(developerCompletenessSQL+" >= ?", minCompleteness)
+	}
+
+	if location, ok := filters["location"].(string); ok && location != "" {
+		query = query.Where("developer_profiles.location ILIKE ?", "%"+location+"%")
+	}
+
+	if visibilities, ok := filters["visibilities"].([]string); ok {
+		query = query.Where("developer_profiles.visibility IN ?", visibilities)
+	}
+
+	if openToWork, ok := filters["open_to_work"].(bool); ok {
+		if openToWork {
+			query = query.Where("developer_profiles.open_to_work_status <> ?", models.OpenToWorkNotLooking)
+		} else {
+			query = query.Where("developer_profiles.open_to_work_status = ?", models.OpenToWorkNotLooking)
+		}
+	}
+
+	if statuses, ok := filters["open_to_work_statuses"].([]string); ok && len(statuses) > 0 {
+		query = query.Where("developer_profiles.open_to_work_status IN ?", statuses)
+	}
+
+	if workModes, ok := filters["work_modes"].([]string); ok && len(workModes) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(developer_profiles.preferred_work_modes) AS item WHERE item IN ?)", workModes)
+	}
+
+	if role, ok := filters["desired_role"].(string); ok && role != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(developer_profiles.desired_roles) AS item WHERE item ILIKE ?)", "%"+role+"%")
+	}
+
+	if location, ok := filters["preferred_location"].(string); ok && location != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(developer_profiles.preferred_locations) AS item WHERE item ILIKE ?)", "%"+location+"%")
+	}
+
+	// Developers who did not state an expected salary fit any budget
+	if maxSalary, ok := filters["max_expected_salary"].(float64); ok {
+		query = query.Where("developer_profiles.expected_salary_min <= ?", maxSalary)
+	}
+
+	if maxNotice, ok := filters["max_notice_days"].(int); ok {
+		query = query.Where("developer_profiles.notice_period_days <= ?", maxNotice)
+	}
+
+	if availableBy, ok := filters["available_by"].(time.Time); ok {
+		query = query.Where("developer_profiles.available_from IS NULL OR developer_profiles.available_from <= ?", availableBy)
+	}
+
+	// Developers can hide their education and current employer from everyone but themselves and admins
+	showHidden, _ := filters["show_hidden"].(bool)
+	viewerID, _ := filters["viewer_id"].(uint)
+
+	degree, _ := filters["degree"].(string)
+	field, _ := filters["field_of_study"].(string)
+	if degree != "" || field != "" {
+		education := r.db.Model(&models.DeveloperEducation{}).Select("developer_id")
+		if degree != "" {
+			education = education.Where("degree ILIKE ?", "%"+degree+"%")
+		}
+		if field != "" {
+			education = education.Where("field_of_study ILIKE ?", "%"+field+"%")
+		}
+		query = query.Where("developer_profiles.id IN (?)", education)
+		if !showHidden {
+			query = query.Where("developer_profiles.hide_education = ? OR developer_profiles.user_id = ?", false, viewerID)
+		}
+	}
+
+	certificate, _ := filters["certificate"].(string)
+	validOnly, _ := filters["certificate_valid"].(bool)
+	if certificate != "" || validOnly {
+		certificates := r.db.Model(&models.DeveloperCertificate{}).Select("developer_id")
+		if certificate != "" {
+			pattern := "%" + certificate + "%"
+			certificates = certificates.Where("certificate_name ILIKE ? OR issuing_organization ILIKE ?", pattern, pattern)
+		}
+		if validOnly {
+			certificates = certificates.Where("expiration_date IS NULL OR expiration_date >= NOW()")
+		}
+		query = query.Where("developer_profiles.id IN (?)", certificates)
+	}
+
+	if search, ok := filters["search"].(string); ok && search != "" {
+		pattern := "%" + search + "%"
+		experiences := r.db.Model(&models.DeveloperExperience{}).Select("developer_id").Where("title ILIKE ?", pattern)
+		if !showHidden {
+			// The current role of a developer hiding their current employer is not searched
+			experiences = experiences.Where("end_date IS NOT NULL OR developer_id IN (?)", r.db.Model(&models.DeveloperProfile{}).
+				Select("id").Where("hide_current_employer = ? OR user_id = ?", false, viewerID))
+		}
+		query = query.Where("developer_profiles.bio ILIKE ? OR developer_profiles.id IN (?)", pattern, experiences)
+	}
+
+	return query
+}
+
+// FacetCount is the number of matching developers with one value of a facet
+type FacetCount struct {
+	ID    uint   `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// DeveloperFacets summarizes the developers matching a search
+type DeveloperFacets struct {
+	Technologies []FacetCount `json:"technologies"`
+	Languages    []FacetCount `json:"languages"`
+	Experience   []FacetCount `json:"experience"`
+	OpenToWork   *int64       `json:"open_to_work,omitempty"` // only shown to companies
+}
+
+// developerFacetLimit caps the technology and language facets to the most common values
+const developerFacetLimit = 20
+
+// experienceBuckets are the experience facet ranges; each ends below the next one's lower bound
+var experienceBuckets = []struct {
+	Name  string
+	Below float64 // upper bound in years, 0 for the last bucket
+}{
+	{"0-2", 2},
+	{"2-5", 5},
+	{"5-10", 10},
+	{"10+", 0},
+}
+
+// Facets counts the technologies, languages, experience ranges and open-to-work status of the matching developers
+func (r *DeveloperRepository) Facets(filters map[string]interface{}) (*DeveloperFacets, error) {
+	facets := &DeveloperFacets{
+		Technologies: []FacetCount{},
+		Languages:    []FacetCount{},
+		Experience:   make([]FacetCount, len(experienceBuckets)),
+	}
+	matching := r.filtered(filters).Select("developer_profiles.id")
+
+	if err := r.db.Table("developer_technologies").
+		Select("technologies.id, technologies.name, COUNT(*) AS count").
+		Joins("JOIN technologies ON technologies.id = developer_technologies.technology_id").
+		Where("developer_technologies.developer_profile_id IN (?)", matching).
+		Group("technologies.id, technologies.name").
+		Order("count DESC, technologies.name").Limit(developerFacetLimit).
+		Scan(&facets.Technologies).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Table("developer_languages").
+		Select("programming_languages.id, programming_languages.name, COUNT(*) AS count").
+		Joins("JOIN programming_languages ON programming_languages.id = developer_languages.programming_language_id").
+		Where("developer_languages.developer_profile_id IN (?)", matching).
+		Group("programming_languages.id, programming_languages.name").
+		Order("count DESC, programming_languages.name").Limit(developerFacetLimit).
+		Scan(&facets.Languages).Error; err != nil {
+		return nil, err
+	}
+
+	bucketCase := "CASE"
+	args := make([]interface{}, 0, len(experienceBuckets))
+	for i, bucket := range experienceBuckets[:len(experienceBuckets)-1] {
+		bucketCase += fmt.Sprintf(" WHEN years < ? THEN %d", i)
+		args = append(args, bucket.Below)
+	}
+	bucketCase += fmt.Sprintf(" ELSE %d END", len(experienceBuckets)-1)
+
+	var buckets []struct {
+		Bucket int
+		Count  int64
+	}
+	if err := r.db.Table("(?) AS matching", r.filtered(filters).Select(experienceYearsSQL+" AS years")).
+		Select(bucketCase+" AS bucket, COUNT(*) AS count", args...).
+		Group("bucket").Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	for i, bucket := range experienceBuckets {
+		facets.Experience[i].Name = bucket.Name
+	}
+	for _, bucket := range buckets {
+		facets.Experience[bucket.Bucket].Count = bucket.Count
+	}
+
+	var openToWork int64
+	if err := r.filtered(filters).Where("developer_profiles.open_to_work_status <> ?", models.OpenToWorkNotLooking).
+		Count(&openToWork).Error; err != nil {
+		return nil, err
+	}
+	facets.OpenToWork = &openToWork
+	return facets, nil
+}
+
+// proficienciesAtLeast returns minProficiency and every level above it, or nil when it is empty or unknown
+func proficienciesAtLeast(minProficiency string) []string {
+	for i, level := range models.ProficiencyLevels {
+		if level == minProficiency {
+			return models.ProficiencyLevels[i:]
+		}
+	}
+	return nil
+}
+
+// ReplaceSkills atomically replaces every technology of a developer
+func (r *DeveloperRepository) ReplaceSkills(developerID uint, skills []models.DeveloperTechnology) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("developer_profile_id = ?", developerID).Delete(&models.DeveloperTechnology{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&skills).Error
+	})
+}
+
+// ReplaceLanguages atomically replaces every programming language of a developer
+func (r *DeveloperRepository) ReplaceLanguages(developerID uint, languages []models.DeveloperLanguage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("developer_profile_id = ?", developerID).Delete(&models.DeveloperLanguage{}).Error; err != nil {
+			return err
+		}
+		if len(languages) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&languages).Error
+	})
+}
+
+// ListSkills returns a developer's technologies with their details
+func (r *DeveloperRepository) ListSkills(developerID uint) ([]models.DeveloperTechnology, error) {
+	var skills []models.DeveloperTechnology
+	err := orderSkills(r.db.Where("developer_profile_id = ?", developerID)).Preload("Technology").Find(&skills).Error
+	return skills, err
+}
+
+// ListLanguages returns a developer's programming languages with their details
+func (r *DeveloperRepository) ListLanguages(developerID uint) ([]models.DeveloperLanguage, error) {
+	var languages []models.DeveloperLanguage
+	err := orderSkills(r.db.Where("developer_profile_id = ?", developerID)).Preload("ProgrammingLanguage").Find(&languages).Error
+	return languages, err
+}
+
+// AddProfileEntries creates experiences and educations and adds skills in one transaction.
+// Skills the developer already has are left unchanged.
+func (r *DeveloperRepository) AddProfileEntries(experiences []models.DeveloperExperience, educations []models.DeveloperEducation, skills []models.DeveloperTechnology, languages []models.DeveloperLanguage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(experiences) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&experiences).Error; err != nil {
+				return err
+			}
+		}
+		if len(educations) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&educations).Error; err != nil {
+				return err
+			}
+		}
+		if len(skills) > 0 {
+			if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&skills).Error; err != nil {
+				return err
+			}
+		}
+		if len(languages) > 0 {
+			return tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&languages).Error
+		}
+		return nil
+	})
+}
+
+// ProfileChanges is a set of profile rows written in one transaction.
+// Entries with an ID are updated, the others created.
+type ProfileChanges struct {
+	Profile      *models.DeveloperProfile // bio and location are updated when set
+	Experiences  []models.DeveloperExperience
+	Educations   []models.DeveloperEducation
+	Certificates []models.DeveloperCertificate
+	Skills       []models.DeveloperTechnology // added, or given the new proficiency when already present
+	Languages    []models.DeveloperLanguage
+}
+
+// Empty reports whether there is nothing to write
+func (c *ProfileChanges) Empty() bool {
+	return c.Profile == nil && len(c.Experiences) == 0 && len(c.Educations) == 0 &&
+		len(c.Certificates) == 0 && len(c.Skills) == 0 && len(c.Languages) == 0
+}
+
+// ApplyProfileChanges writes every change or none of them
+func (r *DeveloperRepository) ApplyProfileChanges(changes *ProfileChanges) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if changes.Profile != nil {
+			if err := tx.Model(changes.Profile).Select("bio", "location").Updates(changes.Profile).Error; err != nil {
+				return err
+			}
+		}
+		for i := range changes.Experiences {
+			if err := tx.Omit(clause.Associations).Save(&changes.Experiences[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range changes.Educations {
+			if err := tx.Omit(clause.Associations).Save(&changes.Educations[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range changes.Certificates {
+			if err := tx.Omit(clause.Associations).Save(&changes.Certificates[i]).Error; err != nil {
+				return err
+			}
+		}
+		if len(changes.Skills) > 0 {
+			upsert := clause.OnConflict{
+				Columns:   []clause.Column{{Name: "developer_profile_id"}, {Name: "technology_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"proficiency"}),
+			}
+			if err := tx.Omit(clause.Associations).Clauses(upsert).Create(&changes.Skills).Error; err != nil {
+				return err
+			}
+		}
+		if len(changes.Languages) > 0 {
+			upsert := clause.OnConflict{
+				Columns:   []clause.Column{{Name: "developer_profile_id"}, {Name: "programming_language_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"proficiency"}),
+			}
+			return tx.Omit(clause.Associations).Clauses(upsert).Create(&changes.Languages).Error
+		}
+		return nil
+	})
+}
+
+// Resume operations
+func (r *DeveloperRepository) FindResume(developerID uint) (*models.DeveloperResume, error) {
+	var resume models.DeveloperResume
+	err := r.db.Where("developer_id = ?", developerID).First(&resume).Error
+	return &resume, err
+}
+
+func (r *DeveloperRepository) SaveResume(resume *models.DeveloperResume) error {
+	return r.db.Save(resume).Error
+}
+
+func (r *DeveloperRepository) DeleteResume(id uint) error {
+	return r.db.Delete(&models.DeveloperResume{}, id).Error
+}
+
 // Experience operations
 func (r *DeveloperRepository) CreateExperience(exp *models.DeveloperExperience) error {
 	return r.db.Create(exp).Error
@@ -78,7 +542,7 @@ func (r *DeveloperRepository) FindExperienceByID(id uint) (*models.DeveloperExpe
 }
 
 func (r *DeveloperRepository) UpdateExperience(exp *models.DeveloperExperience) error {
-	return r.db.Save(exp).Error
+	return r.db.Omit(clause.Associations).Save(exp).Error
 }
 
 func (r *DeveloperRepository) DeleteExperience(id uint) error {
@@ -97,7 +561,7 @@ func (r *DeveloperRepository) FindEducationByID(id uint) (*models.DeveloperEduca
 }
 
 func (r *DeveloperRepository) UpdateEducation(edu *models.DeveloperEducation) error {
-	return r.db.Save(edu).Error
+	return r.db.Omit(clause.Associations).Save(edu).Error
 }
 
 func (r *DeveloperRepository) DeleteEducation(id uint) error {
@@ -116,9 +580,125 @@ func (r *DeveloperRepository) FindCertificateByID(id uint) (*models.DeveloperCer
 }
 
 func (r *DeveloperRepository) UpdateCertificate(cert *models.DeveloperCertificate) error {
-	return r.db.Save(cert).Error
+	return r.db.Omit(clause.Associations).Save(cert).Error
 }
 
 func (r *DeveloperRepository) DeleteCertificate(id uint) error {
 	return r.db.Delete(&models.DeveloperCertificate{}, id).Error
 }
+
+// ListCertificatesExpiringBetween returns certificates expiring in [from, to) with their owner and sent reminders
+func (r *DeveloperRepository) ListCertificatesExpiringBetween(from, to time.Time) ([]models.DeveloperCertificate, error) {
+	var certs []models.DeveloperCertificate
+	err := r.db.Where("expiration_date >= ? AND expiration_date < ?", from, to).
+		Preload("Developer.User").Preload("Reminders").
+		Order("expiration_date").Find(&certs).Error
+	return certs, err
+}
+
+// ClaimCertificateReminder records a reminder unless one for the same certificate, expiration date and window
+// already exists, and reports whether this call recorded it. Only the claimant should send the reminder.
+func (r *DeveloperRepository) ClaimCertificateReminder(reminder *models.CertificateReminder) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseCertificateReminder removes a claimed reminder so a later run can send it again
+func (r *DeveloperRepository) ReleaseCertificateReminder(reminder *models.CertificateReminder) error {
+	return r.db.Delete(&models.CertificateReminder{}, reminder.ID).Error
+}
+
+// Project operations
+
+// orderProjects lists projects in the order the developer chose
+func orderProjects(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// orderProjectImages lists gallery images in upload order
+func orderProjectImages(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+// CreateProject adds a project at the end of the developer's list with the given technologies
+func (r *DeveloperRepository) CreateProject(project *models.DeveloperProject, techIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.DeveloperProject{}).Where("developer_id = ?", project.DeveloperID).
+			Select("COALESCE(MAX(position), 0) + 1").Scan(&project.Position).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(project).Error; err != nil {
+			return err
+		}
+		return replaceProjectTechnologies(tx, project, techIDs)
+	})
+}
+
+func (r *DeveloperRepository) FindProjectByID(id uint) (*models.DeveloperProject, error) {
+	var project models.DeveloperProject
+	err := r.db.Preload("Technologies").Preload("Images", orderProjectImages).First(&project, id).Error
+	return &project, err
+}
+
+// UpdateProject saves a project and replaces its technologies
+func (r *DeveloperRepository) UpdateProject(project *models.DeveloperProject, techIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(project).Error; err != nil {
+			return err
+		}
+		return replaceProjectTechnologies(tx, project, techIDs)
+	})
+}
+
+func replaceProjectTechnologies(tx *gorm.DB, project *models.DeveloperProject, techIDs []uint) error {
+	association := tx.Model(project).Association("Technologies")
+	if len(techIDs) == 0 {
+		return association.Clear()
+	}
+	var technologies []models.Technology
+	if err := tx.Where("id IN ?", techIDs).Order("name").Find(&technologies).Error; err != nil {
+		return err
+	}
+	return association.Replace(technologies)
+}
+
+// DeleteProject removes a project with its technologies and gallery entries; the image files are left to the caller
+func (r *DeveloperRepository) DeleteProject(project *models.DeveloperProject) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(project).Association("Technologies").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", project.ID).Delete(&models.DeveloperProjectImage{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(project).Error
+	})
+}
+
+// ReorderProjects stores the order of a developer's projects; projectIDs must list every one of them
+func (r *DeveloperRepository) ReorderProjects(developerID uint, projectIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range projectIDs {
+			if err := tx.Model(&models.DeveloperProject{}).Where("id = ? AND developer_id = ?", id, developerID).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *DeveloperRepository) ListProjects(developerID uint) ([]models.DeveloperProject, error) {
+	var projects []models.DeveloperProject
+	err := orderProjects(r.db.Where("developer_id = ?", developerID)).
+		Preload("Technologies").Preload("Images", orderProjectImages).Find(&projects).Error
+	return projects, err
+}
+
+func (r *DeveloperRepository) CreateProjectImage(image *models.DeveloperProjectImage) error {
+	return r.db.Create(image).Error
+}
+
+func (r *DeveloperRepository) DeleteProjectImage(id uint) error {
+	return r.db.Delete(&models.DeveloperProjectImage{}, id).Error
+}
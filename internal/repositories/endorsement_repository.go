@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EndorsementRepository struct {
+	db *gorm.DB
+}
+
+func NewEndorsementRepository(db *gorm.DB) *EndorsementRepository {
+	return &EndorsementRepository{db: db}
+}
+
+func (r *EndorsementRepository) Create(endorsement *models.SkillEndorsement) error {
+	return r.db.Create(endorsement).Error
+}
+
+func (r *EndorsementRepository) FindByID(id uint) (*models.SkillEndorsement, error) {
+	var endorsement models.SkillEndorsement
+	err := r.db.First(&endorsement, id).Error
+	return &endorsement, err
+}
+
+// Find returns the endorsement a user gave one of a developer's skills
+func (r *EndorsementRepository) Find(developerID, technologyID, endorserID uint) (*models.SkillEndorsement, error) {
+	var endorsement models.SkillEndorsement
+	err := r.db.Where("developer_id = ? AND technology_id = ? AND endorser_id = ?", developerID, technologyID, endorserID).
+		First(&endorsement).Error
+	return &endorsement, err
+}
+
+// FindWithWithdrawn is Find including a withdrawn endorsement
+func (r *EndorsementRepository) FindWithWithdrawn(developerID, technologyID, endorserID uint) (*models.SkillEndorsement, error) {
+	var endorsement models.SkillEndorsement
+	err := r.db.Unscoped().Where("developer_id = ? AND technology_id = ? AND endorser_id = ?", developerID, technologyID, endorserID).
+		First(&endorsement).Error
+	return &endorsement, err
+}
+
+// Give saves a new endorsement, or overwrites the withdrawn one given for the same skill by the same user when
+// restore is set. It reports false and saves nothing when the endorser already gave limit endorsements since a
+// point in time, withdrawn ones included. The count and the write run in one transaction holding an advisory lock
+// on the endorser, so concurrent requests cannot all slip under the limit.
+func (r *EndorsementRepository) Give(endorsement *models.SkillEndorsement, restore bool, since time.Time, limit int64) (bool, error) {
+	given := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", endorsement.EndorserID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Unscoped().Model(&models.SkillEndorsement{}).
+			Where("endorser_id = ? AND created_at >= ?", endorsement.EndorserID, since).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= limit {
+			return nil
+		}
+
+		given = true
+		if restore {
+			return tx.Unscoped().Omit(clause.Associations).Save(endorsement).Error
+		}
+		return tx.Create(endorsement).Error
+	})
+	return given && err == nil, err
+}
+
+// Delete withdraws an endorsement. The row is soft deleted so it keeps counting towards the daily limit.
+func (r *EndorsementRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SkillEndorsement{}, id).Error
+}
+
+// RingLength returns how many users would form an endorsement ring if endorserID endorsed targetUserID:
+// the length of the shortest chain of endorsements leading from targetUserID back to endorserID, plus one.
+// It returns 0 when there is no such chain of at most maxUsers-1 endorsements. Rejected and withdrawn endorsements are ignored.
+func (r *EndorsementRepository) RingLength(endorserID, targetUserID uint, maxUsers int) (int, error) {
+	var depth *int
+	err := r.db.Raw(`WITH RECURSIVE edges AS (
+		SELECT DISTINCT skill_endorsements.endorser_id AS from_user, developer_profiles.user_id AS to_user
+		FROM skill_endorsements
+		JOIN developer_profiles ON developer_profiles.id = skill_endorsements.developer_id
+		WHERE skill_endorsements.status <> ? AND skill_endorsements.deleted_at IS NULL
+	), reached AS (
+		SELECT to_user AS user_id, 1 AS depth FROM edges WHERE from_user = ?
+		UNION
+		SELECT edges.to_user, reached.depth + 1 FROM reached JOIN edges ON edges.from_user = reached.user_id
+		WHERE reached.depth < ?
+	)
+	SELECT MIN(depth) FROM reached WHERE user_id = ?`,
+		models.ModerationRejected, targetUserID, maxUsers-1, endorserID).Scan(&depth).Error
+	if err != nil || depth == nil {
+		return 0, err
+	}
+	return *depth + 1, nil
+}
+
+// ListApproved returns the approved endorsements of a developer's skills, newest first.
+// visibilities are the profile visibilities the viewer may see, deciding whether an endorser's profile is linked.
+func (r *EndorsementRepository) ListApproved(developerID uint, visibilities []string) ([]models.EndorsementDetail, error) {
+	var details []models.EndorsementDetail
+	err := r.db.Table("skill_endorsements").
+		Select(`skill_endorsements.technology_id, skill_endorsements.context, skill_endorsements.created_at, users.full_name,
+			CASE WHEN endorser.visibility IN ? THEN endorser.slug ELSE '' END AS developer_slug,
+			CASE WHEN endorser.visibility IN ? THEN endorser.avatar_thumbnail_url ELSE '' END AS avatar_thumbnail_url,
+			COALESCE(developer_technologies.proficiency, '') AS proficiency`, visibilities, visibilities).
+		Joins("JOIN users ON users.id = skill_endorsements.endorser_id AND users.deleted_at IS NULL").
+		Joins("LEFT JOIN developer_profiles AS endorser ON endorser.user_id = skill_endorsements.endorser_id AND endorser.deleted_at IS NULL").
+		Joins("LEFT JOIN developer_technologies ON developer_technologies.developer_profile_id = endorser.id AND "+
+			"developer_technologies.technology_id = skill_endorsements.technology_id").
+		Where("skill_endorsements.developer_id = ? AND skill_endorsements.status = ? AND skill_endorsements.deleted_at IS NULL",
+			developerID, models.ModerationApproved).
+		Order("skill_endorsements.created_at DESC").
+		Scan(&details).Error
+	return details, err
+}
+
+// ListByStatus returns endorsements in a moderation status for admins, optionally only flagged ones
+func (r *EndorsementRepository) ListByStatus(page, limit int, status string, flaggedOnly bool) ([]models.SkillEndorsement, int64, error) {
+	var endorsements []models.SkillEndorsement
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.db.Model(&models.SkillEndorsement{}).Where("status = ?", status)
+
+	if flaggedOnly {
+		query = query.Where("is_flagged = ?", true)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).Preload("Endorser").Preload("Developer.User").Preload("Technology").
+		Order("created_at DESC").Find(&endorsements).Error
+	return endorsements, total, err
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type InterviewRepository struct {
+	db *gorm.DB
+}
+
+func NewInterviewRepository(db *gorm.DB) *InterviewRepository {
+	return &InterviewRepository{db: db}
+}
+
+// InterviewBreakdown aggregates the approved interview reports of a company
+type InterviewBreakdown struct {
+	Total               int64            `json:"total"`
+	AverageDifficulty   float64          `json:"average_difficulty"`
+	AverageRounds       float64          `json:"average_rounds"`
+	AverageDurationDays float64          `json:"average_duration_days"`
+	Difficulty          map[int]int64    `json:"difficulty"`
+	Outcomes            map[string]int64 `json:"outcomes"`
+}
+
+func (r *InterviewRepository) Create(report *models.InterviewReport) error {
+	return r.db.Create(report).Error
+}
+
+func (r *InterviewRepository) FindByID(id uint) (*models.InterviewReport, error) {
+	var report models.InterviewReport
+	err := r.db.Preload("User").Preload("Company").Preload("JobPost").First(&report, id).Error
+	return &report, err
+}
+
+func (r *InterviewRepository) Update(report *models.InterviewReport) error {
+	return r.db.Omit("Company", "User", "JobPost").Save(report).Error
+}
+
+// List returns approved interview reports of a company, newest first
+func (r *InterviewRepository) List(companyID uint, page, limit int, filters map[string]interface{}) ([]models.InterviewReport, int64, error) {
+	var reports []models.InterviewReport
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.approved(companyID)
+	query = applyInterviewFilters(query, filters)
+
+	if outcome, ok := filters["outcome"].(string); ok && outcome != "" {
+		query = query.Where("outcome = ?", outcome)
+	}
+
+	if difficulty, ok := filters["difficulty"].(int); ok && difficulty > 0 {
+		query = query.Where("difficulty = ?", difficulty)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).
+		Preload("User", selectPublicUser).
+		Preload("JobPost", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "company_id", "title")
+		}).
+		Order("created_at DESC").Find(&reports).Error
+	return reports, total, err
+}
+
+// Breakdown aggregates difficulty and outcomes of approved reports.
+// Only the role and job filters apply, so the breakdown still shows every outcome and difficulty.
+func (r *InterviewRepository) Breakdown(companyID uint, filters map[string]interface{}) (*InterviewBreakdown, error) {
+	breakdown := &InterviewBreakdown{
+		Difficulty: map[int]int64{1: 0, 2: 0, 3: 0, 4: 0, 5: 0},
+		Outcomes: map[string]int64{
+			models.InterviewOutcomeOffer:      0,
+			models.InterviewOutcomeRejected:   0,
+			models.InterviewOutcomeNoResponse: 0,
+		},
+	}
+
+	var averages struct {
+		Total               int64
+		AverageDifficulty   float64
+		AverageRounds       float64
+		AverageDurationDays float64
+	}
+	err := applyInterviewFilters(r.approved(companyID), filters).
+		Select("COUNT(*) AS total, " +
+			"COALESCE(AVG(difficulty), 0) AS average_difficulty, " +
+			"COALESCE(AVG(rounds), 0) AS average_rounds, " +
+			"COALESCE(AVG(duration_days), 0) AS average_duration_days").
+		Scan(&averages).Error
+	if err != nil {
+		return nil, err
+	}
+	breakdown.Total = averages.Total
+	breakdown.AverageDifficulty = averages.AverageDifficulty
+	breakdown.AverageRounds = averages.AverageRounds
+	breakdown.AverageDurationDays = averages.AverageDurationDays
+
+	var difficulties []struct {
+		Difficulty int
+		Count      int64
+	}
+	err = applyInterviewFilters(r.approved(companyID), filters).
+		Select("difficulty, COUNT(*) AS count").Group("difficulty").
+		Scan(&difficulties).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range difficulties {
+		breakdown.Difficulty[d.Difficulty] = d.Count
+	}
+
+	var outcomes []struct {
+		Outcome string
+		Count   int64
+	}
+	err = applyInterviewFilters(r.approved(companyID), filters).
+		Select("outcome, COUNT(*) AS count").Group("outcome").
+		Scan(&outcomes).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range outcomes {
+		breakdown.Outcomes[o.Outcome] = o.Count
+	}
+
+	return breakdown, nil
+}
+
+func (r *InterviewRepository) ListByUser(userID uint, page, limit int, status string) ([]models.InterviewReport, int64, error) {
+	var reports []models.InterviewReport
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.db.Model(&models.InterviewReport{}).Where("user_id = ?", userID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).Preload("Company").Order("updated_at DESC").Find(&reports).Error
+	return reports, total, err
+}
+
+func (r *InterviewRepository) ListByStatus(page, limit int, status string) ([]models.InterviewReport, int64, error) {
+	var reports []models.InterviewReport
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.db.Model(&models.InterviewReport{}).Where("status = ?", status)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).Preload("User").Preload("Company").
+		Order("created_at ASC").Find(&reports).Error
+	return reports, total, err
+}
+
+func (r *InterviewRepository) approved(companyID uint) *gorm.DB {
+	return r.db.Model(&models.InterviewReport{}).
+		Where("company_id = ? AND status = ?", companyID, models.ModerationApproved)
+}
+
+func applyInterviewFilters(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
+	if role, ok := filters["role"].(string); ok && role != "" {
+		query = query.Where("role ILIKE ?", "%"+role+"%")
+	}
+
+	if jobPostID, ok := filters["job_post_id"].(uint); ok && jobPostID > 0 {
+		query = query.Where("job_post_id = ?", jobPostID)
+	}
+
+	return query
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"github.com/bishworup11/bdSeeker-backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type JobRepository struct {
@@ -14,18 +15,41 @@ func NewJobRepository(db *gorm.DB) *JobRepository {
 }
 
 func (r *JobRepository) Create(job *models.JobPost) error {
-	return r.db.Create(job).Error
+	return retrySlugConflicts(r.db, func(tx *gorm.DB) error {
+		if err := assignJobSlug(tx, job); err != nil {
+			return err
+		}
+		return tx.Create(job).Error
+	})
 }
 
 func (r *JobRepository) FindByID(id uint) (*models.JobPost, error) {
+	return r.findOne("id = ?", id)
+}
+
+func (r *JobRepository) FindBySlug(slug string) (*models.JobPost, error) {
+	return r.findOne("slug = ?", slug)
+}
+
+// FindAccess loads only a job's status and the owner of its company, enough to decide who may see it
+func (r *JobRepository) FindAccess(id uint) (*models.JobPost, error) {
 	var job models.JobPost
-	err := r.db.Preload("Company.User").Preload("Reactions").
-		Preload("Comments.User").Preload("Comments.Replies.User").First(&job, id).Error
+	err := r.db.Select("id", "company_id", "status").
+		Preload("Company", func(db *gorm.DB) *gorm.DB { return db.Select("id", "user_id") }).
+		First(&job, id).Error
+	return &job, err
+}
+
+func (r *JobRepository) findOne(query string, args ...interface{}) (*models.JobPost, error) {
+	var job models.JobPost
+	err := r.db.Preload("Company").Preload("Office").Preload("Reactions").
+		Preload("Comments.User", selectPublicUser).Preload("Comments.Replies.User", selectPublicUser).
+		Where(query, args...).First(&job).Error
 	return &job, err
 }
 
 func (r *JobRepository) Update(job *models.JobPost) error {
-	return r.db.Save(job).Error
+	return r.db.Omit(clause.Associations).Save(job).Error
 }
 
 func (r *JobRepository) Delete(id uint) error {
@@ -44,12 +68,19 @@ func (r *JobRepository) List(page, limit int, filters map[string]interface{}) ([
 		query = query.Where("company_id = ?", companyID)
 	}
 
+	if status, ok := filters["status"].(string); ok && status != "" {
+		query = query.Where("status = ?", status)
+	}
+
 	if workMode, ok := filters["work_mode"].(string); ok && workMode != "" {
 		query = query.Where("work_mode = ?", workMode)
 	}
 
 	if location, ok := filters["location"].(string); ok && location != "" {
-		query = query.Where("location ILIKE ?", "%"+location+"%")
+		pattern := "%" + location + "%"
+		query = query.Where("job_posts.location ILIKE ? OR job_posts.office_id IN ("+
+			"SELECT company_offices.id FROM company_offices WHERE company_offices.deleted_at IS NULL AND "+officeLocationMatch+")",
+			pattern, pattern, pattern, pattern)
 	}
 
 	if search, ok := filters["search"].(string); ok && search != "" {
@@ -94,7 +125,7 @@ func (r *JobRepository) List(page, limit int, filters map[string]interface{}) ([
 		return nil, 0, err
 	}
 
-	err := query.Offset(offset).Limit(limit).Preload("Company.User").Find(&jobs).Error
+	err := query.Offset(offset).Limit(limit).Preload("Company").Preload("Office").Find(&jobs).Error
 	return jobs, total, err
 }
 
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"hash/fnv"
+
+	"gorm.io/gorm"
+)
+
+type LockRepository struct {
+	db *gorm.DB
+}
+
+func NewLockRepository(db *gorm.DB) *LockRepository {
+	return &LockRepository{db: db}
+}
+
+// RunExclusive runs job unless another instance holds the advisory lock called name, and reports whether it ran.
+// The lock is transaction scoped, so Postgres releases it when the job finishes, fails or the connection drops.
+func (r *LockRepository) RunExclusive(ctx context.Context, name string, job func()) (bool, error) {
+	key := advisoryLockKey(name)
+	ran := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var acquired bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if acquired {
+			job()
+			ran = true
+		}
+		return nil
+	})
+	return ran, err
+}
+
+// advisoryLockKey maps a lock name to the 64-bit key Postgres advisory locks use
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ModerationRepository struct {
+	db *gorm.DB
+}
+
+func NewModerationRepository(db *gorm.DB) *ModerationRepository {
+	return &ModerationRepository{db: db}
+}
+
+// Record saves the moderated content and its audit event in a single transaction
+func (r *ModerationRepository) Record(content interface{}, event *models.ModerationEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(content).Error; err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+}
+
+func (r *ModerationRepository) ListEvents(contentType string, contentID uint) ([]models.ModerationEvent, error) {
+	var events []models.ModerationEvent
+	err := r.db.Where("content_type = ? AND content_id = ?", contentType, contentID).
+		Order("created_at ASC").Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type SalaryRepository struct {
+	db *gorm.DB
+}
+
+func NewSalaryRepository(db *gorm.DB) *SalaryRepository {
+	return &SalaryRepository{db: db}
+}
+
+// SalaryStats holds aggregated percentiles of approved salary reports
+type SalaryStats struct {
+	JobTitle       string  `json:"job_title,omitempty"`
+	SampleSize     int64   `json:"sample_size"`
+	P25            float64 `json:"p25"`
+	P50            float64 `json:"p50"`
+	P75            float64 `json:"p75"`
+	AvgAnnualBonus float64 `json:"avg_annual_bonus"`
+}
+
+const salaryPercentileColumns = "COUNT(*) AS sample_size, " +
+	"percentile_cont(0.25) WITHIN GROUP (ORDER BY base_salary) AS p25, " +
+	"percentile_cont(0.5) WITHIN GROUP (ORDER BY base_salary) AS p50, " +
+	"percentile_cont(0.75) WITHIN GROUP (ORDER BY base_salary) AS p75, " +
+	"COALESCE(AVG(annual_bonus), 0) AS avg_annual_bonus"
+
+func (r *SalaryRepository) Create(report *models.SalaryReport) error {
+	return r.db.Create(report).Error
+}
+
+func (r *SalaryRepository) FindByID(id uint) (*models.SalaryReport, error) {
+	var report models.SalaryReport
+	err := r.db.Preload("User").Preload("Company").First(&report, id).Error
+	return &report, err
+}
+
+// HasRecentReport reports whether the user already submitted a salary for the same company and title since the given time
+func (r *SalaryRepository) HasRecentReport(userID, companyID uint, normalizedTitle string, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.SalaryReport{}).
+		Where("user_id = ? AND company_id = ? AND normalized_title = ? AND created_at >= ?", userID, companyID, normalizedTitle, since).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CompanyStats aggregates approved reports for a company, optionally restricted to a normalized title
+func (r *SalaryRepository) CompanyStats(companyID uint, normalizedTitle string) (*SalaryStats, error) {
+	var stats SalaryStats
+	query := r.approved().Where("company_id = ?", companyID)
+	if normalizedTitle != "" {
+		query = query.Where("normalized_title = ?", normalizedTitle)
+	}
+	err := query.Select(salaryPercentileColumns).Scan(&stats).Error
+	return &stats, err
+}
+
+// TitleStats aggregates approved reports of a company per title, keeping only titles with at least minSample reports
+func (r *SalaryRepository) TitleStats(companyID uint, minSample int) ([]SalaryStats, error) {
+	var stats []SalaryStats
+	err := r.approved().Where("company_id = ?", companyID).
+		Select("normalized_title AS job_title, "+salaryPercentileColumns).
+		Group("normalized_title").
+		Having("COUNT(*) >= ?", minSample).
+		Order("sample_size DESC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *SalaryRepository) ListByStatus(page, limit int, status string, outliersOnly bool) ([]models.SalaryReport, int64, error) {
+	var reports []models.SalaryReport
+	var total int64
+
+	offset := (page - 1) * limit
+	query := r.db.Model(&models.SalaryReport{}).Where("status = ?", status)
+
+	if outliersOnly {
+		query = query.Where("is_outlier = ?", true)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Offset(offset).Limit(limit).Preload("User").Preload("Company").
+		Order("created_at DESC").Find(&reports).Error
+	return reports, total, err
+}
+
+func (r *SalaryRepository) approved() *gorm.DB {
+	return r.db.Model(&models.SalaryReport{}).Where("status = ?", models.ModerationApproved)
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/pkg/slug"
+	"gorm.io/gorm"
+)
+
+// uniqueSlug makes a slug from text that no other row of model uses, soft-deleted rows included.
+// fallback is used when text has no usable characters.
+func uniqueSlug(db *gorm.DB, model interface{}, id uint, text, fallback string) (string, error) {
+	return slug.Unique(slugBase(text, fallback), func(candidate string) (bool, error) {
+		var count int64
+		err := db.Unscoped().Model(model).Where("slug = ? AND id <> ?", candidate, id).Count(&count).Error
+		return count > 0, err
+	})
+}
+
+// How often a write is attempted when concurrent writes keep taking the slug it picked
+const slugAttempts = 3
+
+// retrySlugConflicts runs write, which picks a slug and saves the row, in its own transaction, or a savepoint when db
+// is already in one. A slug is checked before it is saved, so a concurrent write can take it in between; the unique
+// index then rejects the save and write runs again, picking the next free slug.
+func retrySlugConflicts(db *gorm.DB, write func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 0; attempt < slugAttempts; attempt++ {
+		if err = db.Transaction(write); !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return err
+		}
+	}
+	return err
+}
+
+// reservedSlugs clash with static routes such as /companies/me
+var reservedSlugs = map[string]bool{
+	"me":      true,
+	"compare": true,
+}
+
+// slugBase makes the slug for text. Numeric slugs would be read as IDs, so they get the fallback prefix like reserved ones.
+func slugBase(text, fallback string) string {
+	base := slug.Make(text)
+	if base == "" {
+		return fallback
+	}
+	if slug.IsNumeric(base) || reservedSlugs[base] {
+		return fallback + "-" + base
+	}
+	return base
+}
+
+// assignCompanySlug sets a unique slug from the company name.
+// Old slugs of other companies are still redirecting, so they are not reused.
+func assignCompanySlug(db *gorm.DB, company *models.CompanyProfile) error {
+	s, err := slug.Unique(slugBase(company.CompanyName, "company"), func(candidate string) (bool, error) {
+		var count int64
+		err := db.Unscoped().Model(&models.CompanyProfile{}).
+			Where("slug = ? AND id <> ?", candidate, company.ID).Count(&count).Error
+		if err != nil || count > 0 {
+			return count > 0, err
+		}
+		err = db.Model(&models.CompanySlugRedirect{}).
+			Where("slug = ? AND company_id <> ?", candidate, company.ID).Count(&count).Error
+		return count > 0, err
+	})
+	if err != nil {
+		return err
+	}
+	company.Slug = s
+	return nil
+}
+
+// assignJobSlug sets a unique slug from the job title and the company name, e.g. "backend-engineer-pathao"
+func assignJobSlug(db *gorm.DB, job *models.JobPost) error {
+	text := job.Title
+	var companyNames []string
+	if err := db.Model(&models.CompanyProfile{}).Where("id = ?", job.CompanyID).Pluck("company_name", &companyNames).Error; err != nil {
+		return err
+	}
+	if len(companyNames) > 0 {
+		text += " " + companyNames[0]
+	}
+
+	s, err := uniqueSlug(db, &models.JobPost{}, job.ID, text, "job")
+	if err != nil {
+		return err
+	}
+	job.Slug = s
+	return nil
+}
+
+// assignDeveloperSlug sets a unique slug from the developer's name
+func assignDeveloperSlug(db *gorm.DB, developer *models.DeveloperProfile) error {
+	var names []string
+	if err := db.Model(&models.User{}).Where("id = ?", developer.UserID).Pluck("full_name", &names).Error; err != nil {
+		return err
+	}
+	name := ""
+	if len(names) > 0 {
+		name = names[0]
+	}
+
+	s, err := uniqueSlug(db, &models.DeveloperProfile{}, developer.ID, name, "developer")
+	if err != nil {
+		return err
+	}
+	developer.Slug = s
+	return nil
+}
+
+// BackfillSlugs gives slugs to companies, jobs and developers created before slugs existed
+func BackfillSlugs(db *gorm.DB) error {
+	var companies []models.CompanyProfile
+	if err := db.Unscoped().Where("slug IS NULL OR slug = ''").Order("id").Find(&companies).Error; err != nil {
+		return err
+	}
+	for i := range companies {
+		company := &companies[i]
+		if err := retrySlugConflicts(db, func(tx *gorm.DB) error {
+			if err := assignCompanySlug(tx, company); err != nil {
+				return err
+			}
+			return tx.Unscoped().Model(company).UpdateColumn("slug", company.Slug).Error
+		}); err != nil {
+			return err
+		}
+	}
+
+	var jobs []models.JobPost
+	if err := db.Unscoped().Where("slug IS NULL OR slug = ''").Order("id").Find(&jobs).Error; err != nil {
+		return err
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		if err := retrySlugConflicts(db, func(tx *gorm.DB) error {
+			if err := assignJobSlug(tx, job); err != nil {
+				return err
+			}
+			return tx.Unscoped().Model(job).UpdateColumn("slug", job.Slug).Error
+		}); err != nil {
+			return err
+		}
+	}
+
+	var developers []models.DeveloperProfile
+	if err := db.Unscoped().Where("slug IS NULL OR slug = ''").Order("id").Find(&developers).Error; err != nil {
+		return err
+	}
+	for i := range developers {
+		developer := &developers[i]
+		if err := retrySlugConflicts(db, func(tx *gorm.DB) error {
+			if err := assignDeveloperSlug(tx, developer); err != nil {
+				return err
+			}
+			return tx.Unscoped().Model(developer).UpdateColumn("slug", developer.Slug).Error
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
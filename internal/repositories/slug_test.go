@@ -0,0 +1,26 @@
+package repositories
+
+import "testing"
+
+func TestSlugBase(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Pathao Ltd.", "pathao-ltd"},
+		{"বাংলা টেক", "bangla-tek"},
+		{"!!!", "company"},
+		{"", "company"},
+		{"2024", "company-2024"},
+		{"২০২৪", "company-2024"}, // numeric once transliterated
+		{"2024 Labs", "2024-labs"},
+		{"Me", "company-me"},
+		{"compare", "company-compare"},
+		{"Compare IT", "compare-it"},
+	}
+	for _, tt := range tests {
+		if got := slugBase(tt.text, "company"); got != tt.want {
+			t.Errorf("slugBase(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -70,3 +70,17 @@ func (r *TechRepository) ListLanguages(search string) ([]models.ProgrammingLangu
 	err := query.Order("name ASC").Find(&langs).Error
 	return langs, err
 }
+
+// CountTechnologies returns how many of ids exist
+func (r *TechRepository) CountTechnologies(ids []uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Technology{}).Where("id IN ?", ids).Count(&count).Error
+	return count, err
+}
+
+// CountLanguages returns how many of ids exist
+func (r *TechRepository) CountLanguages(ids []uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ProgrammingLanguage{}).Where("id IN ?", ids).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+)
+
+// How often certificates are checked for upcoming expiry
+const certificateReminderInterval = time.Hour
+
+type CertificateReminderService struct {
+	developerRepo *repositories.DeveloperRepository
+	locks         *repositories.LockRepository
+	mailer        Mailer
+	windows       []int // days before expiry a reminder is sent, smallest first
+}
+
+// NewCertificateReminderService parses reminderDays, a comma-separated list of days before expiry
+// such as "60,30,7". An empty list disables reminders.
+func NewCertificateReminderService(developerRepo *repositories.DeveloperRepository, locks *repositories.LockRepository, mailer Mailer, reminderDays string) (*CertificateReminderService, error) {
+	var windows []int
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(reminderDays, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		days, err := strconv.Atoi(part)
+		if err != nil || days <= 0 {
+			return nil, fmt.Errorf("invalid certificate reminder days %q", part)
+		}
+		if !seen[days] {
+			seen[days] = true
+			windows = append(windows, days)
+		}
+	}
+	sort.Ints(windows)
+
+	return &CertificateReminderService{
+		developerRepo: developerRepo,
+		locks:         locks,
+		mailer:        mailer,
+		windows:       windows,
+	}, nil
+}
+
+// SendReminders emails developers whose certificates entered a reminder window and returns how many were sent.
+// A certificate gets one reminder per window; windows it skipped, e.g. because it was added late, are not caught up on.
+func (s *CertificateReminderService) SendReminders() (int, error) {
+	if len(s.windows) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	certs, err := s.developerRepo.ListCertificatesExpiringBetween(now, now.AddDate(0, 0, s.windows[len(s.windows)-1]))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, cert := range certs {
+		if cert.Developer == nil || cert.Developer.User.Email == "" {
+			continue
+		}
+
+		daysLeft := int(math.Ceil(cert.ExpirationDate.Sub(now).Hours() / 24))
+		window := s.windowFor(daysLeft)
+		if window == 0 || reminded(&cert, window) {
+			continue
+		}
+
+		// Claim the reminder before sending so concurrent runs never mail the same window twice
+		reminder := &models.CertificateReminder{
+			CertificateID:  cert.ID,
+			ExpirationDate: *cert.ExpirationDate,
+			WindowDays:     window,
+		}
+		claimed, err := s.developerRepo.ClaimCertificateReminder(reminder)
+		if err != nil {
+			log.Printf("Failed to record reminder for certificate %d: %v", cert.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		subject := fmt.Sprintf("Your %s certificate expires in %s", cert.CertificateName, pluralDays(daysLeft))
+		body := fmt.Sprintf("Your %s certificate from %s expires on %s.\n\nOnce it expires it is shown as expired on your bdSeeker profile "+
+			"and no longer matches searches for valid certifications. If you renew it, update its expiration date on your profile.",
+			cert.CertificateName, cert.IssuingOrganization, cert.ExpirationDate.Format("January 2, 2006"))
+		if err := s.mailer.Send(cert.Developer.User.Email, subject, body); err != nil {
+			log.Printf("Failed to send reminder for certificate %d: %v", cert.ID, err)
+			if err := s.developerRepo.ReleaseCertificateReminder(reminder); err != nil {
+				log.Printf("Failed to release reminder for certificate %d: %v", cert.ID, err)
+			}
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// windowFor returns the smallest reminder window a certificate expiring in daysLeft days is in, or 0 if none
+func (s *CertificateReminderService) windowFor(daysLeft int) int {
+	for _, window := range s.windows {
+		if daysLeft <= window {
+			return window
+		}
+	}
+	return 0
+}
+
+// reminded reports whether a reminder for the certificate's current expiration date was sent in window or a smaller one
+func reminded(cert *models.DeveloperCertificate, window int) bool {
+	for _, reminder := range cert.Reminders {
+		if reminder.ExpirationDate.Equal(*cert.ExpirationDate) && reminder.WindowDays <= window {
+			return true
+		}
+	}
+	return false
+}
+
+func pluralDays(days int) string {
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+// Run sends due reminders right away and then periodically until ctx is done
+func (s *CertificateReminderService) Run(ctx context.Context) {
+	if len(s.windows) == 0 {
+		return
+	}
+	runPeriodically(ctx, s.locks, "certificate reminders", certificateReminderInterval, func() {
+		if sent, err := s.SendReminders(); err != nil {
+			log.Printf("Failed to send certificate reminders: %v", err)
+		} else if sent > 0 {
+			log.Printf("Sent %d certificate expiry reminders", sent)
+		}
+	})
+}
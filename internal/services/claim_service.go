@@ -0,0 +1,401 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"github.com/bishworup11/bdSeeker-backend/internal/storage"
+	"gorm.io/gorm"
+)
+
+const claimEmailTTL = 24 * time.Hour
+
+// Document types accepted as proof, keyed by sniffed content type
+var claimDocumentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+var (
+	ErrClaimNotFound       = errors.New("claim not found")
+	ErrUnsupportedDocument = errors.New("unsupported document type, use PDF, JPEG or PNG")
+	ErrOwnerChanged        = errors.New("the company's owner changed in the meantime, reload it and try again")
+)
+
+type ClaimService struct {
+	claimRepo       *repositories.ClaimRepository
+	companyRepo     *repositories.CompanyRepository
+	userRepo        *repositories.UserRepository
+	mailer          Mailer
+	storage         storage.Storage
+	maxDocumentSize int64
+	now             func() time.Time
+}
+
+func NewClaimService(claimRepo *repositories.ClaimRepository, companyRepo *repositories.CompanyRepository, userRepo *repositories.UserRepository, mailer Mailer, store storage.Storage, maxDocumentSize int64) *ClaimService {
+	return &ClaimService{
+		claimRepo:       claimRepo,
+		companyRepo:     companyRepo,
+		userRepo:        userRepo,
+		mailer:          mailer,
+		storage:         store,
+		maxDocumentSize: maxDocumentSize,
+		now:             time.Now,
+	}
+}
+
+type SubmitClaimRequest struct {
+	JobTitle  string `json:"job_title" validate:"required,max=255"`
+	Message   string `json:"message" validate:"max=2000"`
+	WorkEmail string `json:"work_email" validate:"omitempty,email,max=255"`
+}
+
+// MaxDocumentSize returns the largest accepted proof document in bytes
+func (s *ClaimService) MaxDocumentSize() int64 {
+	return s.maxDocumentSize
+}
+
+// Submit opens a claim on an unowned company. A work email at the company's domain is sent a confirmation code.
+func (s *ClaimService) Submit(userID, companyID uint, req *SubmitClaimRequest) (*models.CompanyClaim, error) {
+	company, err := s.companyRepo.FindByID(companyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("company not found")
+		}
+		return nil, err
+	}
+
+	if company.UserID != nil {
+		return nil, errors.New("company already has an owner")
+	}
+
+	owner, err := s.ownsCompany(userID)
+	if err != nil {
+		return nil, err
+	}
+	if owner {
+		return nil, errors.New("you already own a company profile")
+	}
+
+	pending, err := s.claimRepo.HasPending(userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, errors.New("you already have an open claim for this company")
+	}
+
+	claim := &models.CompanyClaim{
+		CompanyID: companyID,
+		UserID:    userID,
+		JobTitle:  req.JobTitle,
+		Message:   req.Message,
+		Status:    models.ClaimPending,
+	}
+
+	if req.WorkEmail != "" {
+		domain, err := DomainFromWebsite(company.Website)
+		if err != nil {
+			return nil, errors.New("this company has no website domain, upload a document as proof instead")
+		}
+		email := strings.ToLower(strings.TrimSpace(req.WorkEmail))
+		if !emailBelongsToDomain(email, domain) {
+			return nil, fmt.Errorf("work email must be an address at %s", domain)
+		}
+
+		code, err := generateVerificationToken()
+		if err != nil {
+			return nil, err
+		}
+		expires := s.now().Add(claimEmailTTL)
+		claim.WorkEmail = email
+		claim.EmailCode = code
+		claim.EmailCodeExpiresAt = &expires
+	}
+
+	if err := s.claimRepo.Create(claim); err != nil {
+		return nil, err
+	}
+
+	if claim.WorkEmail != "" {
+		body := fmt.Sprintf("Use this code to confirm your claim of %s on bdSeeker: %s\n\nThe code expires in 24 hours.", company.CompanyName, claim.EmailCode)
+		if err := s.mailer.Send(claim.WorkEmail, "Confirm your company claim on bdSeeker", body); err != nil {
+			return nil, err
+		}
+	}
+
+	return claim, nil
+}
+
+// VerifyEmail confirms the work email of a claim with the code that was sent to it
+func (s *ClaimService) VerifyEmail(userID, claimID uint, code string) (*models.CompanyClaim, error) {
+	claim, err := s.ownPendingClaim(userID, claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.WorkEmail == "" || claim.EmailCode == "" {
+		return nil, errors.New("claim has no work email to verify")
+	}
+	if claim.EmailVerifiedAt != nil {
+		return claim, nil
+	}
+	if claim.EmailCodeExpiresAt == nil || s.now().After(*claim.EmailCodeExpiresAt) {
+		return nil, errors.New("verification code has expired, submit a new claim")
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(code)), []byte(claim.EmailCode)) != 1 {
+		return nil, errors.New("verification code does not match")
+	}
+
+	now := s.now()
+	claim.EmailVerifiedAt = &now
+	claim.EmailCode = ""
+	claim.EmailCodeExpiresAt = nil
+	if err := s.claimRepo.Update(claim); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// AttachDocument stores a proof document for a pending claim, replacing any previous one
+func (s *ClaimService) AttachDocument(ctx context.Context, userID, claimID uint, filename string, data []byte) (*models.CompanyClaim, error) {
+	if int64(len(data)) > s.maxDocumentSize {
+		return nil, ErrFileTooLarge
+	}
+
+	claim, err := s.ownPendingClaim(userID, claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := claimDocumentTypes[contentType]
+	if !ok {
+		return nil, ErrUnsupportedDocument
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	key := path.Join("claims", fmt.Sprint(claim.ID), name+ext)
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, fmt.Errorf("failed to store document: %w", err)
+	}
+
+	previous := claim.DocumentKey
+	claim.DocumentKey = key
+	claim.DocumentName = filepath.Base(filename)
+	claim.DocumentType = contentType
+	if err := s.claimRepo.Update(claim); err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return nil, err
+	}
+
+	if previous != "" {
+		_ = s.storage.Delete(ctx, previous)
+	}
+	return claim, nil
+}
+
+// OpenDocument returns the proof document of a claim for review
+func (s *ClaimService) OpenDocument(ctx context.Context, claimID uint) (io.ReadCloser, *models.CompanyClaim, error) {
+	claim, err := s.findClaim(claimID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if claim.DocumentKey == "" {
+		return nil, nil, errors.New("claim has no document")
+	}
+
+	reader, err := s.storage.Get(ctx, claim.DocumentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reader, claim, nil
+}
+
+func (s *ClaimService) ListMine(userID uint) ([]models.CompanyClaim, error) {
+	return s.claimRepo.ListByUser(userID)
+}
+
+func (s *ClaimService) List(page, limit int, status string) ([]models.CompanyClaim, int64, error) {
+	return s.claimRepo.ListByStatus(page, limit, status)
+}
+
+// Approve hands the company to the claimant and rejects other open claims for it
+func (s *ClaimService) Approve(adminID, claimID uint, note string) (*models.CompanyClaim, error) {
+	claim, err := s.findClaim(claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != models.ClaimPending {
+		return nil, errors.New("claim has already been reviewed")
+	}
+	if claim.EmailVerifiedAt == nil && claim.DocumentKey == "" {
+		return nil, errors.New("claim has no verified work email or document")
+	}
+
+	company, err := s.companyRepo.FindByID(claim.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if company.UserID != nil {
+		return nil, errors.New("company already has an owner")
+	}
+	owner, err := s.ownsCompany(claim.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if owner {
+		return nil, errors.New("claimant already owns another company profile")
+	}
+
+	now := s.now()
+	claim.Status = models.ClaimApproved
+	claim.ReviewedBy = &adminID
+	claim.ReviewedAt = &now
+	claim.ReviewNote = note
+
+	newOwner := claim.UserID
+	transfer := &models.CompanyOwnershipTransfer{
+		CompanyID: claim.CompanyID,
+		ToUserID:  &newOwner,
+		ClaimID:   &claim.ID,
+		ActorID:   adminID,
+		Reason:    models.TransferClaimApproved,
+		Note:      note,
+	}
+
+	if err := s.claimRepo.TransferOwnership(transfer, claim); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOwnerChanged
+		}
+		return nil, err
+	}
+	return claim, nil
+}
+
+// Reject closes a claim without changing ownership
+func (s *ClaimService) Reject(adminID, claimID uint, note string) (*models.CompanyClaim, error) {
+	claim, err := s.findClaim(claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != models.ClaimPending {
+		return nil, errors.New("claim has already been reviewed")
+	}
+
+	now := s.now()
+	claim.Status = models.ClaimRejected
+	claim.ReviewedBy = &adminID
+	claim.ReviewedAt = &now
+	claim.ReviewNote = note
+	if err := s.claimRepo.Update(claim); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// TransferOwnership lets an admin move a company to another user, or release it when toUserID is nil
+func (s *ClaimService) TransferOwnership(adminID, companyID uint, toUserID *uint, note string) (*models.CompanyOwnershipTransfer, error) {
+	company, err := s.companyRepo.FindByID(companyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("company not found")
+		}
+		return nil, err
+	}
+
+	reason := models.TransferReleased
+	if toUserID != nil {
+		if company.UserID != nil && *company.UserID == *toUserID {
+			return nil, errors.New("user already owns this company")
+		}
+		if _, err := s.userRepo.FindByID(*toUserID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("user not found")
+			}
+			return nil, err
+		}
+		owner, err := s.ownsCompany(*toUserID)
+		if err != nil {
+			return nil, err
+		}
+		if owner {
+			return nil, errors.New("user already owns another company profile")
+		}
+		reason = models.TransferAdmin
+	} else if company.UserID == nil {
+		return nil, errors.New("company has no owner")
+	}
+
+	transfer := &models.CompanyOwnershipTransfer{
+		CompanyID:  companyID,
+		FromUserID: company.UserID,
+		ToUserID:   toUserID,
+		ActorID:    adminID,
+		Reason:     reason,
+		Note:       note,
+	}
+	if err := s.claimRepo.TransferOwnership(transfer, nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOwnerChanged
+		}
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (s *ClaimService) Transfers(companyID uint) ([]models.CompanyOwnershipTransfer, error) {
+	return s.claimRepo.ListTransfers(companyID)
+}
+
+func (s *ClaimService) findClaim(claimID uint) (*models.CompanyClaim, error) {
+	claim, err := s.claimRepo.FindByID(claimID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClaimNotFound
+		}
+		return nil, err
+	}
+	return claim, nil
+}
+
+func (s *ClaimService) ownPendingClaim(userID, claimID uint) (*models.CompanyClaim, error) {
+	claim, err := s.findClaim(claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.UserID != userID {
+		return nil, ErrClaimNotFound
+	}
+	if claim.Status != models.ClaimPending {
+		return nil, errors.New("claim has already been reviewed")
+	}
+	return claim, nil
+}
+
+// ownsCompany reports whether the user already owns a company; a user can own only one
+func (s *ClaimService) ownsCompany(userID uint) (bool, error) {
+	_, err := s.companyRepo.FindByUserID(userID)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return false, err
+}
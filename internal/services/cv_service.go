@@ -0,0 +1,546 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/dto"
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/pkg/pdf"
+)
+
+// CV templates
+const (
+	CVTemplateClassic = "classic"
+	CVTemplateModern  = "modern"
+)
+
+var ErrUnknownCVTemplate = errors.New("unknown CV template, supported: classic, modern")
+
+// cvStyle is the look of a CV template
+type cvStyle struct {
+	accent pdf.Color
+	text   pdf.Color
+	muted  pdf.Color
+	rule   pdf.Color
+	banner bool // name on a colored band and skills as tags instead of a centered header and skill lists
+}
+
+var cvTemplates = map[string]cvStyle{
+	CVTemplateClassic: {
+		accent: pdf.Color{R: 33, G: 37, B: 41},
+		text:   pdf.Color{R: 33, G: 37, B: 41},
+		muted:  pdf.Color{R: 108, G: 117, B: 125},
+		rule:   pdf.Color{R: 173, G: 181, B: 189},
+	},
+	CVTemplateModern: {
+		accent: pdf.Color{R: 0, G: 106, B: 78},
+		text:   pdf.Color{R: 33, G: 37, B: 41},
+		muted:  pdf.Color{R: 108, G: 117, B: 125},
+		rule:   pdf.Color{R: 222, G: 226, B: 230},
+		banner: true,
+	},
+}
+
+const (
+	cvMargin     = 50.0
+	cvBottom     = 60.0 // space kept free for the page footer
+	cvLineHeight = 1.35
+	cvBannerSize = 110.0
+)
+
+var (
+	cvWhite     = pdf.Color{R: 255, G: 255, B: 255}
+	cvTagColor  = pdf.Color{R: 230, G: 244, B: 239}
+	cvBannerSub = pdf.Color{R: 214, G: 236, B: 228}
+)
+
+// CVService renders developer profiles as PDF CVs
+type CVService struct {
+	regular *pdf.TrueType // fallback fonts for text Helvetica cannot draw, such as Bangla; nil when not configured
+	bold    *pdf.TrueType
+}
+
+// NewCVService loads the fonts used for Bangla text. The bold font defaults to the regular one;
+// without fonts only text Helvetica can draw is rendered.
+func NewCVService(fontPath, boldFontPath string) (*CVService, error) {
+	s := &CVService{}
+	if fontPath == "" {
+		return s, nil
+	}
+
+	var err error
+	if s.regular, err = loadCVFont(fontPath); err != nil {
+		return nil, err
+	}
+	s.bold = s.regular
+	if boldFontPath != "" {
+		if s.bold, err = loadCVFont(boldFontPath); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func loadCVFont(path string) (*pdf.TrueType, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CV font: %w", err)
+	}
+	font, err := pdf.ParseTrueType(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CV font %s: %w", path, err)
+	}
+	if !font.Has('ক') {
+		return nil, fmt.Errorf("CV font %s has no Bangla glyphs", path)
+	}
+	return font, nil
+}
+
+// Render draws a developer profile as a PDF. The profile must already be filtered for the viewer,
+// so hidden fields such as the email or the current employer never reach the document.
+func (s *CVService) Render(developer dto.Developer, template string) ([]byte, error) {
+	style, ok := cvTemplates[template]
+	if !ok {
+		return nil, ErrUnknownCVTemplate
+	}
+
+	name := strings.TrimSpace(developer.FullName)
+	if name == "" {
+		name = developer.Slug
+	}
+
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	doc.SetInfo(name+" - CV", name)
+	regular, bold, err := s.faces(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &cvWriter{style: style, doc: doc, regular: regular, bold: bold}
+	w.newPage()
+	w.header(name, cvHeadline(developer), cvContacts(developer))
+	w.summary(developer.Bio)
+	w.experiences(developer.Experiences)
+	w.projects(developer.Projects)
+	w.educations(developer.Educations)
+	w.certificates(developer.Certificates)
+	w.skills(developer)
+	w.footer(name)
+
+	return doc.Bytes()
+}
+
+// faces registers Helvetica with the configured fonts as fallbacks
+func (s *CVService) faces(doc *pdf.Document) (regular, bold pdf.Face, err error) {
+	helvetica, err := doc.AddStandardFont(pdf.Helvetica)
+	if err != nil {
+		return nil, nil, err
+	}
+	helveticaBold, err := doc.AddStandardFont(pdf.HelveticaBold)
+	if err != nil {
+		return nil, nil, err
+	}
+	regular, bold = pdf.Face{helvetica}, pdf.Face{helveticaBold}
+
+	if s.regular != nil {
+		fallback := doc.AddTrueType(s.regular)
+		regular = append(regular, fallback)
+		if s.bold != s.regular {
+			fallback = doc.AddTrueType(s.bold)
+		}
+		bold = append(bold, fallback)
+	}
+	return regular, bold, nil
+}
+
+// cvHeadline is the title of the current position, or of the latest one
+func cvHeadline(developer dto.Developer) string {
+	var latest *dto.Experience
+	for i := range developer.Experiences {
+		exp := &developer.Experiences[i]
+		if exp.IsCurrent {
+			return exp.Title
+		}
+		if latest == nil || exp.StartDate.After(latest.StartDate) {
+			latest = exp
+		}
+	}
+	if latest != nil {
+		return latest.Title
+	}
+	return ""
+}
+
+func cvContacts(developer dto.Developer) []string {
+	var contacts []string
+	for _, value := range []string{developer.Location, developer.Email} {
+		if value = strings.TrimSpace(value); value != "" {
+			contacts = append(contacts, value)
+		}
+	}
+	return contacts
+}
+
+func cvMonth(t time.Time) string {
+	return t.Format("Jan 2006")
+}
+
+func cvPeriod(start time.Time, end *time.Time) string {
+	if end == nil {
+		return cvMonth(start) + " – Present"
+	}
+	return cvMonth(start) + " – " + cvMonth(*end)
+}
+
+// cvSkill describes a skill as "Go (Expert, 5 yrs)"
+func cvSkill(skill dto.Skill) string {
+	var details []string
+	if skill.Proficiency != "" {
+		details = append(details, strings.ToUpper(skill.Proficiency[:1])+skill.Proficiency[1:])
+	}
+	switch {
+	case skill.YearsOfExperience == 1:
+		details = append(details, "1 yr")
+	case skill.YearsOfExperience > 1:
+		details = append(details, fmt.Sprintf("%d yrs", skill.YearsOfExperience))
+	}
+	if len(details) == 0 {
+		return skill.Name
+	}
+	return fmt.Sprintf("%s (%s)", skill.Name, strings.Join(details, ", "))
+}
+
+// cvSkills lists skills, or the plain tags of profiles without proficiency details, primary skills first
+func cvSkills(skills []dto.Skill, tags []dto.Tag) []string {
+	var primary, other []string
+	for _, skill := range skills {
+		if skill.Name == "" {
+			continue
+		}
+		if skill.IsPrimary {
+			primary = append(primary, cvSkill(skill))
+		} else {
+			other = append(other, cvSkill(skill))
+		}
+	}
+	if len(skills) == 0 {
+		for _, tag := range tags {
+			other = append(other, tag.Name)
+		}
+	}
+	return append(primary, other...)
+}
+
+// cvWriter lays out a CV top to bottom, starting a new page when the current one is full
+type cvWriter struct {
+	style   cvStyle
+	doc     *pdf.Document
+	page    *pdf.Page
+	regular pdf.Face
+	bold    pdf.Face
+	y       float64 // top of the next line
+}
+
+func (w *cvWriter) left() float64 {
+	return cvMargin
+}
+
+func (w *cvWriter) right() float64 {
+	return w.doc.Width() - cvMargin
+}
+
+func (w *cvWriter) newPage() {
+	w.page = w.doc.AddPage()
+	w.y = cvMargin
+}
+
+// ensure starts a new page unless height more points fit on the current one
+func (w *cvWriter) ensure(height float64) {
+	if w.y+height > w.doc.Height()-cvBottom {
+		w.newPage()
+	}
+}
+
+// paragraph draws wrapped text starting at x
+func (w *cvWriter) paragraph(face pdf.Face, size float64, color pdf.Color, x float64, text string) {
+	lineHeight := size * cvLineHeight
+	for _, line := range face.Wrap(text, size, w.right()-x) {
+		w.ensure(lineHeight)
+		if line != "" {
+			w.page.Text(x, w.y+size, face, size, color, line)
+		}
+		w.y += lineHeight
+	}
+}
+
+func (w *cvWriter) centered(face pdf.Face, size float64, color pdf.Color, text string) {
+	for _, line := range face.Wrap(text, size, w.right()-w.left()) {
+		width := face.Width(line, size)
+		w.page.Text((w.doc.Width()-width)/2, w.y+size, face, size, color, line)
+		w.y += size * cvLineHeight
+	}
+}
+
+func (w *cvWriter) header(name, headline string, contacts []string) {
+	contact := strings.Join(contacts, "  |  ")
+
+	if !w.style.banner {
+		w.centered(w.bold, 24, w.style.text, name)
+		if headline != "" {
+			w.centered(w.regular, 12, w.style.muted, headline)
+		}
+		if contact != "" {
+			w.y += 2
+			w.centered(w.regular, 10, w.style.text, contact)
+		}
+		w.y += 6
+		w.page.Line(w.left(), w.y, w.right(), w.y, 1, w.style.text)
+		w.y += 10
+		return
+	}
+
+	// The band has room for one line each; longer text is cut at the last word that fits
+	w.page.Rect(0, 0, w.doc.Width(), cvBannerSize, w.style.accent)
+	w.bannerLine(52, w.bold, 26, cvWhite, name)
+	w.bannerLine(74, w.regular, 13, cvBannerSub, headline)
+	w.bannerLine(94, w.regular, 10, cvWhite, contact)
+	w.y = cvBannerSize + 20
+}
+
+func (w *cvWriter) bannerLine(y float64, face pdf.Face, size float64, color pdf.Color, text string) {
+	if lines := face.Wrap(text, size, w.right()-w.left()); len(lines) > 0 {
+		w.page.Text(w.left(), y, face, size, color, lines[0])
+	}
+}
+
+// section starts a section, keeping its title on the same page as the first lines below it
+func (w *cvWriter) section(title string) {
+	w.ensure(60)
+	w.y += 8
+	if w.style.banner {
+		w.page.Rect(w.left(), w.y+1, 4, 14, w.style.accent)
+		w.page.Text(w.left()+12, w.y+13, w.bold, 13, w.style.accent, title)
+		w.y += 22
+		return
+	}
+	w.page.Text(w.left(), w.y+12, w.bold, 12, w.style.accent, strings.ToUpper(title))
+	w.y += 17
+	w.page.Line(w.left(), w.y, w.right(), w.y, 0.5, w.style.rule)
+	w.y += 6
+}
+
+// entry draws a bold title with the period right aligned and an optional muted subtitle
+func (w *cvWriter) entry(title, subtitle, period string) {
+	w.ensure(36)
+	periodWidth := w.regular.Width(period, 10)
+	lines := w.bold.Wrap(title, 11, w.right()-w.left()-periodWidth-12)
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+	w.page.Text(w.right()-periodWidth, w.y+11, w.regular, 10, w.style.muted, period)
+	for _, line := range lines {
+		w.ensure(11 * cvLineHeight)
+		w.page.Text(w.left(), w.y+11, w.bold, 11, w.style.text, line)
+		w.y += 11 * cvLineHeight
+	}
+	if subtitle != "" {
+		w.paragraph(w.regular, 10, w.style.muted, w.left(), subtitle)
+	}
+}
+
+func (w *cvWriter) summary(bio string) {
+	if strings.TrimSpace(bio) == "" {
+		return
+	}
+	w.section("Summary")
+	w.paragraph(w.regular, 10, w.style.text, w.left(), bio)
+}
+
+func (w *cvWriter) experiences(experiences []dto.Experience) {
+	if len(experiences) == 0 {
+		return
+	}
+	w.section("Experience")
+	for _, exp := range experiences {
+		w.entry(exp.Title, exp.CompanyName, cvPeriod(exp.StartDate, exp.EndDate))
+		if exp.Description != "" {
+			w.y += 2
+			w.paragraph(w.regular, 10, w.style.text, w.left(), exp.Description)
+		}
+		w.y += 8
+	}
+}
+
+func (w *cvWriter) projects(projects []dto.Project) {
+	if len(projects) == 0 {
+		return
+	}
+	w.section("Projects")
+	for _, project := range projects {
+		var details []string
+		if project.Role != "" {
+			details = append(details, project.Role)
+		}
+		if len(project.Technologies) > 0 {
+			names := make([]string, len(project.Technologies))
+			for i, tech := range project.Technologies {
+				names[i] = tech.Name
+			}
+			details = append(details, strings.Join(names, ", "))
+		}
+		period := ""
+		if project.StartDate != nil {
+			period = cvPeriod(*project.StartDate, project.EndDate)
+		}
+		w.entry(project.Title, strings.Join(details, "  |  "), period)
+		if project.Description != "" {
+			w.y += 2
+			w.paragraph(w.regular, 10, w.style.text, w.left(), project.Description)
+		}
+		for _, uri := range []string{project.RepoURL, project.DemoURL} {
+			if uri != "" {
+				w.link(uri)
+			}
+		}
+		w.y += 8
+	}
+}
+
+func (w *cvWriter) educations(educations []dto.Education) {
+	if len(educations) == 0 {
+		return
+	}
+	w.section("Education")
+	for _, edu := range educations {
+		title, subtitle := edu.Degree, edu.Institution
+		if edu.FieldOfStudy != "" {
+			title = strings.TrimPrefix(title+" in "+edu.FieldOfStudy, " in ")
+		}
+		if title == "" {
+			title, subtitle = edu.Institution, ""
+		}
+		if edu.Grade != "" {
+			subtitle = strings.TrimPrefix(subtitle+"  |  Grade: "+edu.Grade, "  |  ")
+		}
+		w.entry(title, subtitle, cvPeriod(edu.StartDate, edu.EndDate))
+		if edu.Description != "" {
+			w.y += 2
+			w.paragraph(w.regular, 10, w.style.text, w.left(), edu.Description)
+		}
+		w.y += 8
+	}
+}
+
+func (w *cvWriter) certificates(certificates []dto.Certificate) {
+	if len(certificates) == 0 {
+		return
+	}
+	w.section("Certificates")
+	for _, cert := range certificates {
+		details := []string{}
+		if cert.IssuingOrganization != "" {
+			details = append(details, cert.IssuingOrganization)
+		}
+		if cert.Status == models.CertificateExpired {
+			details = append(details, "Expired "+cvMonth(*cert.ExpirationDate))
+		} else if cert.ExpirationDate != nil {
+			details = append(details, "Expires "+cvMonth(*cert.ExpirationDate))
+		}
+		if cert.CredentialID != "" {
+			details = append(details, "Credential ID "+cert.CredentialID)
+		}
+		w.entry(cert.CertificateName, strings.Join(details, "  |  "), cvMonth(cert.IssueDate))
+		if cert.CertificateLink != "" {
+			w.link(cert.CertificateLink)
+		}
+		w.y += 8
+	}
+}
+
+// link draws a clickable URL on one line, shortened when it is too long
+func (w *cvWriter) link(uri string) {
+	const size = 9
+	text := uri
+	for runes := []rune(uri); len(runes) > 1 && w.regular.Width(text, size) > w.right()-w.left(); {
+		runes = runes[:len(runes)-1]
+		text = string(runes) + "…"
+	}
+	w.ensure(size * cvLineHeight)
+	width := w.page.Text(w.left(), w.y+size, w.regular, size, w.style.accent, text)
+	w.page.Link(w.left(), w.y, width, size*cvLineHeight, uri)
+	w.y += size * cvLineHeight
+}
+
+func (w *cvWriter) skills(developer dto.Developer) {
+	technologies := cvSkills(developer.Skills, developer.Technologies)
+	languages := cvSkills(developer.Languages, developer.ProgrammingLanguages)
+	if len(technologies) == 0 && len(languages) == 0 {
+		return
+	}
+
+	w.section("Skills")
+	for _, group := range []struct {
+		label string
+		items []string
+	}{
+		{"Technologies", technologies},
+		{"Programming languages", languages},
+	} {
+		if len(group.items) == 0 {
+			continue
+		}
+		if w.style.banner {
+			w.ensure(30)
+			w.page.Text(w.left(), w.y+10, w.bold, 10, w.style.text, group.label)
+			w.y += 16
+			w.tags(group.items)
+			w.y += 6
+			continue
+		}
+		label := group.label + ": "
+		w.ensure(10 * cvLineHeight)
+		indent := w.page.Text(w.left(), w.y+10, w.bold, 10, w.style.text, label)
+		w.paragraph(w.regular, 10, w.style.text, w.left()+indent, strings.Join(group.items, ", "))
+		w.y += 4
+	}
+}
+
+// tags draws items as filled labels flowing across lines
+func (w *cvWriter) tags(items []string) {
+	const (
+		size    = 9
+		padding = 6
+		gap     = 6
+		height  = size + 2*padding - 2
+	)
+	x := w.left()
+	w.ensure(height)
+	for _, item := range items {
+		width := w.regular.Width(item, size) + 2*padding
+		if x > w.left() && x+width > w.right() {
+			x = w.left()
+			w.y += height + gap
+			w.ensure(height)
+		}
+		w.page.Rect(x, w.y, width, height, cvTagColor)
+		w.page.Text(x+padding, w.y+padding+size-3, w.regular, size, w.style.accent, item)
+		x += width + gap
+	}
+	w.y += height + gap
+}
+
+// footer numbers the pages once the content is laid out
+func (w *cvWriter) footer(name string) {
+	pages := w.doc.Pages()
+	y := w.doc.Height() - 30
+	for i, page := range pages {
+		page.Line(w.left(), y-12, w.right(), y-12, 0.5, w.style.rule)
+		page.Text(w.left(), y, w.regular, 8, w.style.muted, name)
+		number := fmt.Sprintf("%d / %d", i+1, len(pages))
+		page.Text(w.right()-w.regular.Width(number, 8), y, w.regular, 8, w.style.muted, number)
+	}
+}
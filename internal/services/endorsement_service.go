@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	// A user may give this many endorsements per endorsementLimitPeriod
+	endorsementLimit       = 20
+	endorsementLimitPeriod = 24 * time.Hour
+	// Endorsements closing a ring of up to this many users, each endorsing the next, are held for moderation
+	endorsementRingMaxUsers = 3
+)
+
+var (
+	ErrSelfEndorsement     = errors.New("you cannot endorse your own skills")
+	ErrSkillNotListed      = errors.New("the developer does not list this skill")
+	ErrAlreadyEndorsed     = errors.New("you have already endorsed this skill")
+	ErrEndorsementLimit    = fmt.Errorf("you can give at most %d endorsements a day", endorsementLimit)
+	ErrEndorsementNotFound = errors.New("endorsement not found")
+)
+
+type EndorsementService struct {
+	endorsementRepo *repositories.EndorsementRepository
+}
+
+func NewEndorsementService(endorsementRepo *repositories.EndorsementRepository) *EndorsementService {
+	return &EndorsementService{endorsementRepo: endorsementRepo}
+}
+
+type EndorseRequest struct {
+	Context string `json:"context" validate:"max=255"` // e.g. "worked together at X"
+}
+
+// Endorse records a user's endorsement of one of a developer's skills. Endorsements closing a ring of users
+// endorsing each other are flagged and held for moderation instead of being counted.
+func (s *EndorsementService) Endorse(endorserID uint, developer *models.DeveloperProfile, technologyID uint, req *EndorseRequest) (*models.SkillEndorsement, error) {
+	if developer.UserID == endorserID {
+		return nil, ErrSelfEndorsement
+	}
+
+	listed := false
+	for _, skill := range developer.Skills {
+		if skill.TechnologyID == technologyID {
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		return nil, ErrSkillNotListed
+	}
+
+	previous, err := s.endorsementRepo.FindWithWithdrawn(developer.ID, technologyID, endorserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	found := err == nil
+	if found && !previous.DeletedAt.Valid {
+		return nil, ErrAlreadyEndorsed
+	}
+
+	endorsement := &models.SkillEndorsement{
+		DeveloperID:  developer.ID,
+		TechnologyID: technologyID,
+		EndorserID:   endorserID,
+		Context:      strings.TrimSpace(req.Context),
+		Moderation:   models.Moderation{Status: models.ModerationApproved},
+	}
+
+	ring, err := s.endorsementRepo.RingLength(endorserID, developer.UserID, endorsementRingMaxUsers)
+	if err != nil {
+		return nil, err
+	}
+	if ring > 0 {
+		endorsement.IsFlagged = true
+		endorsement.Moderation.Status = models.ModerationPending
+		if ring == 2 {
+			endorsement.FlagReason = "reciprocal endorsement: the developer has endorsed the endorser"
+		} else {
+			endorsement.FlagReason = fmt.Sprintf("endorsement ring of %d users endorsing each other", ring)
+		}
+	}
+
+	// A withdrawn endorsement of the same skill is reused, as the unique index still covers it
+	if found {
+		endorsement.ID = previous.ID
+		endorsement.CreatedAt = time.Now()
+	}
+	given, err := s.endorsementRepo.Give(endorsement, found, time.Now().Add(-endorsementLimitPeriod), endorsementLimit)
+	if err != nil {
+		// A concurrent request endorsed the skill first
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAlreadyEndorsed
+		}
+		return nil, err
+	}
+	if !given {
+		return nil, ErrEndorsementLimit
+	}
+	return endorsement, nil
+}
+
+// Withdraw removes a user's endorsement of a developer's skill
+func (s *EndorsementService) Withdraw(endorserID, developerID, technologyID uint) error {
+	endorsement, err := s.endorsementRepo.Find(developerID, technologyID, endorserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEndorsementNotFound
+		}
+		return err
+	}
+	return s.endorsementRepo.Delete(endorsement.ID)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+)
+
+const (
+	// Used when OPEN_TO_WORK_EXPIRY is not a positive duration
+	defaultOpenToWorkExpiry = 90 * 24 * time.Hour
+	// How often expired open-to-work statuses are reset
+	openToWorkSweepInterval = time.Hour
+)
+
+var ErrNotOpenToWork = errors.New("your open-to-work status is not_looking; set a status before refreshing it")
+
+type JobSearchService struct {
+	developerRepo *repositories.DeveloperRepository
+	locks         *repositories.LockRepository
+	expiry        time.Duration
+}
+
+func NewJobSearchService(developerRepo *repositories.DeveloperRepository, locks *repositories.LockRepository, expiry time.Duration) *JobSearchService {
+	if expiry <= 0 {
+		expiry = defaultOpenToWorkExpiry
+	}
+	return &JobSearchService{
+		developerRepo: developerRepo,
+		locks:         locks,
+		expiry:        expiry,
+	}
+}
+
+type UpdateJobSearchRequest struct {
+	Status             string     `json:"status" validate:"required,oneof=actively_looking open_to_offers not_looking"`
+	DesiredRoles       []string   `json:"desired_roles" validate:"max=10,dive,required,max=100"`
+	PreferredWorkModes []string   `json:"preferred_work_modes" validate:"max=4,dive,oneof=office hybrid remote onsite"`
+	PreferredLocations []string   `json:"preferred_locations" validate:"max=10,dive,required,max=100"`
+	ExpectedSalaryMin  float64    `json:"expected_salary_min" validate:"min=0"`
+	ExpectedSalaryMax  float64    `json:"expected_salary_max" validate:"omitempty,gtefield=ExpectedSalaryMin"`
+	NoticePeriodDays   *int       `json:"notice_period_days" validate:"omitempty,min=0,max=365"`
+	AvailableFrom      *time.Time `json:"available_from"`
+}
+
+// Update replaces a developer's job search preferences. Setting a status other than not_looking
+// restarts its expiry, so saving the preferences also refreshes them.
+func (s *JobSearchService) Update(developer *models.DeveloperProfile, req *UpdateJobSearchRequest) error {
+	developer.DesiredRoles = uniqueTrimmed(req.DesiredRoles)
+	developer.PreferredWorkModes = uniqueTrimmed(req.PreferredWorkModes)
+	developer.PreferredLocations = uniqueTrimmed(req.PreferredLocations)
+	developer.ExpectedSalaryMin = req.ExpectedSalaryMin
+	developer.ExpectedSalaryMax = req.ExpectedSalaryMax
+	developer.NoticePeriodDays = req.NoticePeriodDays
+	developer.AvailableFrom = req.AvailableFrom
+	s.setStatus(developer, req.Status)
+
+	return s.developerRepo.Update(developer)
+}
+
+// Refresh restarts the expiry of a developer's open-to-work status
+func (s *JobSearchService) Refresh(developer *models.DeveloperProfile) error {
+	if developer.OpenToWorkStatus == models.OpenToWorkNotLooking {
+		return ErrNotOpenToWork
+	}
+	s.setStatus(developer, developer.OpenToWorkStatus)
+
+	return s.developerRepo.UpdateFields(developer.ID, map[string]interface{}{
+		"open_to_work_expires_at": developer.OpenToWorkExpiresAt,
+	})
+}
+
+func (s *JobSearchService) setStatus(developer *models.DeveloperProfile, status string) {
+	developer.OpenToWorkStatus = status
+	developer.OpenToWorkExpiresAt = nil
+	if status != models.OpenToWorkNotLooking {
+		expiresAt := time.Now().Add(s.expiry)
+		developer.OpenToWorkExpiresAt = &expiresAt
+	}
+}
+
+// ExpireStale resets expired open-to-work statuses and returns how many were reset.
+// Statuses set before expiry existed are given one first.
+func (s *JobSearchService) ExpireStale() (int64, error) {
+	now := time.Now()
+	if _, err := s.developerRepo.ScheduleOpenToWorkExpiry(now.Add(s.expiry)); err != nil {
+		return 0, err
+	}
+	return s.developerRepo.ExpireOpenToWork(now)
+}
+
+// Run expires stale statuses right away and then periodically until ctx is done
+func (s *JobSearchService) Run(ctx context.Context) {
+	runPeriodically(ctx, s.locks, "open-to-work expiry", openToWorkSweepInterval, func() {
+		if expired, err := s.ExpireStale(); err != nil {
+			log.Printf("Failed to expire open-to-work statuses: %v", err)
+		} else if expired > 0 {
+			log.Printf("Expired %d open-to-work statuses", expired)
+		}
+	})
+}
+
+// uniqueTrimmed trims every value and drops empty and case-insensitively repeated ones
+func uniqueTrimmed(values []string) models.StringList {
+	result := models.StringList{}
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, value)
+	}
+	return result
+}
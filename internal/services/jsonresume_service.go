@@ -0,0 +1,529 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"github.com/bishworup11/bdSeeker-backend/pkg/jsonresume"
+)
+
+// Import actions reported for each entry of an imported document
+const (
+	ImportCreate    = "create"
+	ImportUpdate    = "update"
+	ImportUnchanged = "unchanged"
+	ImportSkip      = "skip"
+)
+
+// FieldChange is the old and new value of an updated field
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ImportChange describes what an import does with one entry
+type ImportChange struct {
+	Section string                 `json:"section"` // profile, work, education, certificates, skills, languages
+	Action  string                 `json:"action"`  // create, update, unchanged, skip
+	Entry   string                 `json:"entry"`
+	ID      uint                   `json:"id,omitempty"` // the existing entry being updated
+	Fields  map[string]FieldChange `json:"fields,omitempty"`
+	Reason  string                 `json:"reason,omitempty"` // why the entry is skipped
+}
+
+// ImportResult is the diff of an import, applied unless it is a dry run
+type ImportResult struct {
+	DryRun  bool           `json:"dry_run"`
+	Summary map[string]int `json:"summary"`
+	Changes []ImportChange `json:"changes"`
+}
+
+func (r *ImportResult) add(change ImportChange) {
+	if change.Action == ImportUpdate && len(change.Fields) == 0 {
+		change.Action = ImportUnchanged
+	}
+	r.Changes = append(r.Changes, change)
+	r.Summary[change.Action]++
+}
+
+// JSONResumeService converts developer profiles to and from JSON Resume documents
+type JSONResumeService struct {
+	developerRepo *repositories.DeveloperRepository
+	techRepo      *repositories.TechRepository
+}
+
+func NewJSONResumeService(developerRepo *repositories.DeveloperRepository, techRepo *repositories.TechRepository) *JSONResumeService {
+	return &JSONResumeService{
+		developerRepo: developerRepo,
+		techRepo:      techRepo,
+	}
+}
+
+// Export serializes a developer's whole profile. It is meant for the developer, so nothing is hidden.
+func (s *JSONResumeService) Export(developerID uint) (*jsonresume.Resume, error) {
+	developer, err := s.developerRepo.FindByID(developerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resume := &jsonresume.Resume{
+		Schema: jsonresume.SchemaURL,
+		Basics: jsonresume.Basics{
+			Name:    developer.User.FullName,
+			Email:   developer.User.Email,
+			Summary: developer.Bio,
+		},
+		Work:         make([]jsonresume.Work, 0, len(developer.Experiences)),
+		Education:    make([]jsonresume.Education, 0, len(developer.Educations)),
+		Certificates: make([]jsonresume.Certificate, 0, len(developer.Certificates)),
+		Skills:       make([]jsonresume.Skill, 0, len(developer.Skills)+len(developer.Languages)),
+		Meta: &jsonresume.Meta{
+			Version:      "v1.0.0",
+			LastModified: developer.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	if developer.Location != "" {
+		resume.Basics.Location = &jsonresume.Location{City: developer.Location}
+	}
+
+	for _, exp := range developer.Experiences {
+		work := jsonresume.Work{
+			Name:      exp.CompanyName,
+			Position:  exp.Title,
+			StartDate: jsonresume.FormatDate(exp.StartDate),
+			Summary:   exp.Description,
+		}
+		if exp.EndDate != nil {
+			work.EndDate = jsonresume.FormatDate(*exp.EndDate)
+		} else if resume.Basics.Label == "" {
+			resume.Basics.Label = exp.Title
+		}
+		resume.Work = append(resume.Work, work)
+	}
+
+	for _, edu := range developer.Educations {
+		education := jsonresume.Education{
+			Institution: edu.Institution,
+			Area:        edu.FieldOfStudy,
+			StudyType:   edu.Degree,
+			StartDate:   jsonresume.FormatDate(edu.StartDate),
+			Score:       edu.Grade,
+		}
+		if edu.EndDate != nil {
+			education.EndDate = jsonresume.FormatDate(*edu.EndDate)
+		}
+		resume.Education = append(resume.Education, education)
+	}
+
+	for _, cert := range developer.Certificates {
+		resume.Certificates = append(resume.Certificates, jsonresume.Certificate{
+			Name:   cert.CertificateName,
+			Date:   jsonresume.FormatDate(cert.IssueDate),
+			Issuer: cert.IssuingOrganization,
+			URL:    cert.CertificateLink,
+		})
+	}
+
+	for _, skill := range developer.Skills {
+		if skill.Technology != nil {
+			resume.Skills = append(resume.Skills, jsonresume.Skill{Name: skill.Technology.Name, Level: skill.Proficiency})
+		}
+	}
+	for _, lang := range developer.Languages {
+		if lang.ProgrammingLanguage != nil {
+			resume.Skills = append(resume.Skills, jsonresume.Skill{Name: lang.ProgrammingLanguage.Name, Level: lang.Proficiency})
+		}
+	}
+	return resume, nil
+}
+
+// Import upserts a JSON Resume document into a developer's profile. Entries are matched to existing ones
+// by their natural keys and nothing is deleted. Invalid entries are skipped with a reason, and with dryRun
+// the diff is only reported.
+func (s *JSONResumeService) Import(developerID uint, doc *jsonresume.Resume, dryRun bool) (*ImportResult, error) {
+	developer, err := s.developerRepo.FindByID(developerID)
+	if err != nil {
+		return nil, err
+	}
+	technologies, err := s.techRepo.ListTechnologies("")
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.techRepo.ListLanguages("")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		DryRun:  dryRun,
+		Summary: map[string]int{ImportCreate: 0, ImportUpdate: 0, ImportUnchanged: 0, ImportSkip: 0},
+		Changes: []ImportChange{},
+	}
+	changes := &repositories.ProfileChanges{}
+
+	importBasics(developer, &doc.Basics, changes, result)
+	importWork(developer, doc.Work, changes, result)
+	importEducation(developer, doc.Education, changes, result)
+	importCertificates(developer, doc.Certificates, changes, result)
+	importSkills(developer, doc.Skills, technologies, languages, changes, result)
+
+	if !dryRun && !changes.Empty() {
+		if err := s.developerRepo.ApplyProfileChanges(changes); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func importBasics(developer *models.DeveloperProfile, basics *jsonresume.Basics, changes *repositories.ProfileChanges, result *ImportResult) {
+	profile := &models.DeveloperProfile{ID: developer.ID, Bio: developer.Bio, Location: developer.Location}
+	change := ImportChange{Section: "profile", Action: ImportUpdate, Entry: developer.User.FullName, ID: developer.ID, Fields: map[string]FieldChange{}}
+
+	setString(change.Fields, "bio", &profile.Bio, truncate(strings.TrimSpace(basics.Summary), 5000))
+	setString(change.Fields, "location", &profile.Location, locationText(basics.Location))
+
+	if len(change.Fields) > 0 {
+		changes.Profile = profile
+	}
+	result.add(change)
+}
+
+func locationText(location *jsonresume.Location) string {
+	if location == nil {
+		return ""
+	}
+	var parts []string
+	for _, part := range []string{location.City, location.Region} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return truncate(strings.TrimSpace(location.Address), 255)
+	}
+	return truncate(strings.Join(parts, ", "), 255)
+}
+
+func importWork(developer *models.DeveloperProfile, entries []jsonresume.Work, changes *repositories.ProfileChanges, result *ImportResult) {
+	matched := map[uint]bool{}
+	for i := range entries {
+		work := &entries[i]
+		company, title := strings.TrimSpace(work.CompanyName()), strings.TrimSpace(work.Position)
+		change := ImportChange{Section: "work", Entry: strings.TrimSpace(title + " at " + company)}
+
+		start, end, reason := importDates(work.StartDate, work.EndDate)
+		if title == "" || company == "" {
+			reason = "position and name are required"
+		} else if len(title) > 255 || len(company) > 255 {
+			reason = "position and name must be at most 255 characters"
+		}
+		if reason != "" {
+			change.Action, change.Reason = ImportSkip, reason
+			result.add(change)
+			continue
+		}
+
+		description := strings.TrimSpace(work.Summary)
+		for _, highlight := range work.Highlights {
+			if highlight = strings.TrimSpace(highlight); highlight != "" {
+				description = strings.TrimSpace(description + "\n- " + highlight)
+			}
+		}
+		description = truncate(description, 5000)
+
+		var existing *models.DeveloperExperience
+		for j := range developer.Experiences {
+			exp := &developer.Experiences[j]
+			if !matched[exp.ID] && strings.EqualFold(exp.CompanyName, company) && strings.EqualFold(exp.Title, title) && sameMonth(exp.StartDate, start) {
+				existing = exp
+				break
+			}
+		}
+		if existing == nil {
+			changes.Experiences = append(changes.Experiences, models.DeveloperExperience{
+				DeveloperID: developer.ID,
+				Title:       title,
+				CompanyName: company,
+				StartDate:   start,
+				EndDate:     end,
+				Description: description,
+			})
+			change.Action = ImportCreate
+			result.add(change)
+			continue
+		}
+
+		matched[existing.ID] = true
+		updated := *existing
+		updated.Developer = nil
+		change.Action, change.ID, change.Fields = ImportUpdate, existing.ID, map[string]FieldChange{}
+		setDate(change.Fields, "start_date", &updated.StartDate, start)
+		setOptionalDate(change.Fields, "end_date", &updated.EndDate, end)
+		setString(change.Fields, "description", &updated.Description, description)
+		if len(change.Fields) > 0 {
+			changes.Experiences = append(changes.Experiences, updated)
+		}
+		result.add(change)
+	}
+}
+
+func importEducation(developer *models.DeveloperProfile, entries []jsonresume.Education, changes *repositories.ProfileChanges, result *ImportResult) {
+	matched := map[uint]bool{}
+	for i := range entries {
+		entry := &entries[i]
+		institution, degree := strings.TrimSpace(entry.Institution), strings.TrimSpace(entry.StudyType)
+		change := ImportChange{Section: "education", Entry: strings.TrimSpace(degree + ", " + institution)}
+
+		start, end, reason := importDates(entry.StartDate, entry.EndDate)
+		if institution == "" || degree == "" {
+			reason = "institution and studyType are required"
+		} else if len(institution) > 255 || len(degree) > 255 {
+			reason = "institution and studyType must be at most 255 characters"
+		}
+		if reason != "" {
+			change.Action, change.Reason = ImportSkip, reason
+			result.add(change)
+			continue
+		}
+		field := truncate(strings.TrimSpace(entry.Area), 255)
+		grade := truncate(strings.TrimSpace(entry.Grade()), 50)
+
+		var existing *models.DeveloperEducation
+		for j := range developer.Educations {
+			edu := &developer.Educations[j]
+			if !matched[edu.ID] && strings.EqualFold(edu.Institution, institution) && strings.EqualFold(edu.Degree, degree) {
+				existing = edu
+				break
+			}
+		}
+		if existing == nil {
+			changes.Educations = append(changes.Educations, models.DeveloperEducation{
+				DeveloperID:  developer.ID,
+				Institution:  institution,
+				Degree:       degree,
+				FieldOfStudy: field,
+				StartDate:    start,
+				EndDate:      end,
+				Grade:        grade,
+			})
+			change.Action = ImportCreate
+			result.add(change)
+			continue
+		}
+
+		matched[existing.ID] = true
+		updated := *existing
+		updated.Developer = nil
+		change.Action, change.ID, change.Fields = ImportUpdate, existing.ID, map[string]FieldChange{}
+		setString(change.Fields, "field_of_study", &updated.FieldOfStudy, field)
+		setDate(change.Fields, "start_date", &updated.StartDate, start)
+		setOptionalDate(change.Fields, "end_date", &updated.EndDate, end)
+		setString(change.Fields, "grade", &updated.Grade, grade)
+		if len(change.Fields) > 0 {
+			changes.Educations = append(changes.Educations, updated)
+		}
+		result.add(change)
+	}
+}
+
+func importCertificates(developer *models.DeveloperProfile, entries []jsonresume.Certificate, changes *repositories.ProfileChanges, result *ImportResult) {
+	matched := map[uint]bool{}
+	for i := range entries {
+		entry := &entries[i]
+		name, issuer, link := strings.TrimSpace(entry.Name), strings.TrimSpace(entry.Issuer), strings.TrimSpace(entry.URL)
+		change := ImportChange{Section: "certificates", Entry: strings.TrimSpace(name + ", " + issuer)}
+
+		issued, _, reason := importDates(entry.Date, "")
+		switch {
+		case name == "" || issuer == "":
+			reason = "name and issuer are required"
+		case len(name) > 255 || len(issuer) > 255:
+			reason = "name and issuer must be at most 255 characters"
+		case entry.Date == "":
+			reason = "date is required"
+		case link != "" && !isHTTPURL(link):
+			reason = "url must be an http(s) URL of at most 500 characters"
+		}
+		if reason != "" {
+			change.Action, change.Reason = ImportSkip, reason
+			result.add(change)
+			continue
+		}
+
+		var existing *models.DeveloperCertificate
+		for j := range developer.Certificates {
+			cert := &developer.Certificates[j]
+			if !matched[cert.ID] && strings.EqualFold(cert.CertificateName, name) && strings.EqualFold(cert.IssuingOrganization, issuer) {
+				existing = cert
+				break
+			}
+		}
+		if existing == nil {
+			changes.Certificates = append(changes.Certificates, models.DeveloperCertificate{
+				DeveloperID:         developer.ID,
+				CertificateName:     name,
+				IssuingOrganization: issuer,
+				IssueDate:           issued,
+				CertificateLink:     link,
+			})
+			change.Action = ImportCreate
+			result.add(change)
+			continue
+		}
+
+		matched[existing.ID] = true
+		updated := *existing
+		updated.Developer = nil
+		change.Action, change.ID, change.Fields = ImportUpdate, existing.ID, map[string]FieldChange{}
+		setDate(change.Fields, "issue_date", &updated.IssueDate, issued)
+		setString(change.Fields, "certificate_link", &updated.CertificateLink, link)
+		if len(change.Fields) > 0 {
+			changes.Certificates = append(changes.Certificates, updated)
+		}
+		result.add(change)
+	}
+}
+
+// importSkills matches skill names and keywords against known technologies, then programming languages.
+// Names that match neither are skipped since tags are not created by imports.
+func importSkills(developer *models.DeveloperProfile, entries []jsonresume.Skill, technologies []models.Technology, languages []models.ProgrammingLanguage, changes *repositories.ProfileChanges, result *ImportResult) {
+	techByName := make(map[string]models.Technology, len(technologies))
+	for _, tech := range technologies {
+		techByName[strings.ToLower(tech.Name)] = tech
+	}
+	langByName := make(map[string]models.ProgrammingLanguage, len(languages))
+	for _, lang := range languages {
+		langByName[strings.ToLower(lang.Name)] = lang
+	}
+	currentTech := make(map[uint]string, len(developer.Skills))
+	for _, skill := range developer.Skills {
+		currentTech[skill.TechnologyID] = skill.Proficiency
+	}
+	currentLang := make(map[uint]string, len(developer.Languages))
+	for _, lang := range developer.Languages {
+		currentLang[lang.ProgrammingLanguageID] = lang.Proficiency
+	}
+
+	seenTech, seenLang := map[uint]bool{}, map[uint]bool{}
+	for _, entry := range entries {
+		found := false
+		for _, name := range append([]string{entry.Name}, entry.Keywords...) {
+			key := strings.ToLower(strings.TrimSpace(name))
+			if tech, ok := techByName[key]; ok {
+				found = true
+				if !seenTech[tech.ID] {
+					seenTech[tech.ID] = true
+					proficiency, change := importProficiency("skills", tech.Name, entry.Level, currentTech, tech.ID)
+					if change.Action != ImportUnchanged {
+						changes.Skills = append(changes.Skills, models.DeveloperTechnology{DeveloperProfileID: developer.ID, TechnologyID: tech.ID, Proficiency: proficiency})
+					}
+					result.add(change)
+				}
+				continue
+			}
+			if lang, ok := langByName[key]; ok {
+				found = true
+				if !seenLang[lang.ID] {
+					seenLang[lang.ID] = true
+					proficiency, change := importProficiency("languages", lang.Name, entry.Level, currentLang, lang.ID)
+					if change.Action != ImportUnchanged {
+						changes.Languages = append(changes.Languages, models.DeveloperLanguage{DeveloperProfileID: developer.ID, ProgrammingLanguageID: lang.ID, Proficiency: proficiency})
+					}
+					result.add(change)
+				}
+			}
+		}
+		if !found {
+			result.add(ImportChange{Section: "skills", Action: ImportSkip, Entry: entry.Name, Reason: "not a known technology or programming language"})
+		}
+	}
+}
+
+// importProficiency decides the proficiency of an imported skill. An empty level keeps the current one.
+func importProficiency(section, name, level string, current map[uint]string, id uint) (string, ImportChange) {
+	change := ImportChange{Section: section, Entry: name}
+	proficiency := proficiencyFromLevel(level)
+	existing, ok := current[id]
+	switch {
+	case !ok:
+		change.Action = ImportCreate
+	case level == "" || existing == proficiency:
+		change.Action = ImportUnchanged
+	default:
+		change.Action = ImportUpdate
+		change.Fields = map[string]FieldChange{"proficiency": {From: existing, To: proficiency}}
+	}
+	return proficiency, change
+}
+
+// proficiencyFromLevel maps free-text JSON Resume levels such as "Master" or "Basic" to proficiency levels
+func proficiencyFromLevel(level string) string {
+	level = strings.ToLower(level)
+	switch {
+	case strings.Contains(level, "expert"), strings.Contains(level, "master"):
+		return models.ProficiencyExpert
+	case strings.Contains(level, "advanced"), strings.Contains(level, "senior"):
+		return models.ProficiencyAdvanced
+	case strings.Contains(level, "beginner"), strings.Contains(level, "novice"), strings.Contains(level, "basic"), strings.Contains(level, "junior"):
+		return models.ProficiencyBeginner
+	}
+	return models.ProficiencyIntermediate
+}
+
+// importDates parses a required start and optional end date, returning why they are invalid
+func importDates(from, to string) (time.Time, *time.Time, string) {
+	if strings.TrimSpace(from) == "" {
+		return time.Time{}, nil, "start date is required"
+	}
+	start, err := jsonresume.ParseDate(from)
+	if err != nil {
+		return time.Time{}, nil, err.Error()
+	}
+	if strings.TrimSpace(to) == "" {
+		return start, nil, ""
+	}
+	end, err := jsonresume.ParseDate(to)
+	if err != nil {
+		return time.Time{}, nil, err.Error()
+	}
+	if !end.After(start) {
+		return time.Time{}, nil, "end date must be after the start date"
+	}
+	return start, &end, ""
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && len(value) <= 500
+}
+
+func sameMonth(a, b time.Time) bool {
+	a, b = a.UTC(), b.UTC()
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
+
+// setString updates an optional text field. Empty imported values never clear existing ones.
+func setString(fields map[string]FieldChange, name string, current *string, value string) {
+	if value != "" && *current != value {
+		fields[name] = FieldChange{From: *current, To: value}
+		*current = value
+	}
+}
+
+func setDate(fields map[string]FieldChange, name string, current *time.Time, value time.Time) {
+	if !current.Equal(value) {
+		fields[name] = FieldChange{From: *current, To: value}
+		*current = value
+	}
+}
+
+func setOptionalDate(fields map[string]FieldChange, name string, current **time.Time, value *time.Time) {
+	if *current == nil && value == nil || *current != nil && value != nil && (*current).Equal(*value) {
+		return
+	}
+	fields[name] = FieldChange{From: *current, To: value}
+	*current = value
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"path"
+	"strings"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/storage"
+	"github.com/bishworup11/bdSeeker-backend/pkg/imaging"
+)
+
+// ImageVariant is one rendition of an uploaded image.
+// Crop variants are center-cropped to exactly Width x Height; others are scaled to fit within it.
+type ImageVariant struct {
+	Name   string
+	Width  int
+	Height int
+	Crop   bool
+}
+
+// Standard renditions for company media, developer avatars and project galleries. The first variant is the main image.
+var (
+	LogoVariants = []ImageVariant{
+		{Name: "full", Width: 512, Height: 512},
+		{Name: "thumb", Width: 128, Height: 128, Crop: true},
+	}
+	CoverVariants = []ImageVariant{
+		{Name: "full", Width: 1600, Height: 400, Crop: true},
+		{Name: "thumb", Width: 640, Height: 160, Crop: true},
+	}
+	AvatarVariants = []ImageVariant{
+		{Name: "full", Width: 400, Height: 400, Crop: true},
+		{Name: "thumb", Width: 96, Height: 96, Crop: true},
+	}
+	ProjectImageVariants = []ImageVariant{
+		{Name: "full", Width: 1600, Height: 1200},
+		{Name: "thumb", Width: 400, Height: 300, Crop: true},
+	}
+)
+
+var ErrFileTooLarge = errors.New("file is too large")
+
+// StoredImage describes the renditions written for an uploaded image
+type StoredImage struct {
+	Key  string            // key of the main rendition, used to derive and delete the others
+	URLs map[string]string // public URL per variant name
+}
+
+type MediaService struct {
+	storage      storage.Storage
+	maxImageSize int64
+}
+
+func NewMediaService(store storage.Storage, maxImageSize int64) *MediaService {
+	return &MediaService{storage: store, maxImageSize: maxImageSize}
+}
+
+// MaxImageSize returns the largest accepted image upload in bytes
+func (s *MediaService) MaxImageSize() int64 {
+	return s.maxImageSize
+}
+
+// StoreImage validates an uploaded image, strips its metadata and stores every variant under prefix
+func (s *MediaService) StoreImage(ctx context.Context, prefix string, data []byte, variants []ImageVariant) (*StoredImage, error) {
+	if int64(len(data)) > s.maxImageSize {
+		return nil, ErrFileTooLarge
+	}
+
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+
+	ext := ".jpg"
+	if format == imaging.FormatPNG {
+		ext = ".png"
+	}
+
+	stored := &StoredImage{
+		Key:  path.Join(prefix, id+ext),
+		URLs: make(map[string]string, len(variants)),
+	}
+
+	written := make([]string, 0, len(variants))
+	for i, variant := range variants {
+		var rendition image.Image
+		if variant.Crop {
+			rendition = imaging.Fill(img, variant.Width, variant.Height)
+		} else {
+			rendition = imaging.Fit(img, variant.Width, variant.Height)
+		}
+
+		encoded, contentType, err := imaging.Encode(rendition, format)
+		if err != nil {
+			s.deleteKeys(ctx, written)
+			return nil, err
+		}
+
+		key := variantKey(stored.Key, variants, i)
+		if err := s.storage.Put(ctx, key, bytes.NewReader(encoded), int64(len(encoded)), contentType); err != nil {
+			s.deleteKeys(ctx, written)
+			return nil, fmt.Errorf("failed to store image: %w", err)
+		}
+		written = append(written, key)
+		stored.URLs[variant.Name] = s.storage.URL(key)
+	}
+
+	return stored, nil
+}
+
+// DeleteImage removes every variant of an image previously written by StoreImage
+func (s *MediaService) DeleteImage(ctx context.Context, key string, variants []ImageVariant) {
+	if key == "" {
+		return
+	}
+	keys := make([]string, 0, len(variants))
+	for i := range variants {
+		keys = append(keys, variantKey(key, variants, i))
+	}
+	s.deleteKeys(ctx, keys)
+}
+
+func (s *MediaService) deleteKeys(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		// Orphaned files are harmless, so failures are not reported to the caller
+		_ = s.storage.Delete(ctx, key)
+	}
+}
+
+// variantKey derives the key of a variant from the main key, e.g. logo/abc.png -> logo/abc_thumb.png
+func variantKey(key string, variants []ImageVariant, i int) string {
+	if i == 0 {
+		return key
+	}
+	ext := path.Ext(key)
+	return strings.TrimSuffix(key, ext) + "_" + variants[i].Name + ext
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,567 @@
+package services
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+// ResumeDraft holds the profile entries proposed from a resume's text.
+// Nothing is saved until the developer reviews and confirms it.
+type ResumeDraft struct {
+	Experiences []DraftExperience `json:"experiences"`
+	Educations  []DraftEducation  `json:"educations"`
+	Skills      []DraftSkill      `json:"skills"`
+	Languages   []DraftSkill      `json:"languages"`
+}
+
+// DraftExperience uses the same fields as a work experience so it can be confirmed as is.
+// Fields that could not be recognised are left empty for the developer to fill in.
+type DraftExperience struct {
+	Title       string     `json:"title"`
+	CompanyName string     `json:"company_name"`
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	Description string     `json:"description"`
+}
+
+type DraftEducation struct {
+	Institution  string     `json:"institution"`
+	Degree       string     `json:"degree"`
+	FieldOfStudy string     `json:"field_of_study"`
+	StartDate    *time.Time `json:"start_date"`
+	EndDate      *time.Time `json:"end_date"`
+	Grade        string     `json:"grade"`
+}
+
+// DraftSkill is a known technology or programming language mentioned in the resume
+type DraftSkill struct {
+	ID                uint   `json:"id"`
+	Name              string `json:"name"`
+	Proficiency       string `json:"proficiency"`
+	YearsOfExperience int    `json:"years_of_experience"`
+	IsPrimary         bool   `json:"is_primary"`
+}
+
+// Resume sections
+const (
+	sectionNone = iota
+	sectionExperience
+	sectionEducation
+	sectionSkills
+	sectionOther
+)
+
+var resumeHeadings = map[string]int{
+	"experience":                  sectionExperience,
+	"experiences":                 sectionExperience,
+	"work experience":             sectionExperience,
+	"professional experience":     sectionExperience,
+	"relevant experience":         sectionExperience,
+	"employment":                  sectionExperience,
+	"employment history":          sectionExperience,
+	"work history":                sectionExperience,
+	"career history":              sectionExperience,
+	"education":                   sectionEducation,
+	"educations":                  sectionEducation,
+	"academic background":         sectionEducation,
+	"academic qualification":      sectionEducation,
+	"academic qualifications":     sectionEducation,
+	"educational qualification":   sectionEducation,
+	"educational qualifications":  sectionEducation,
+	"education and training":      sectionEducation,
+	"skills":                      sectionSkills,
+	"technical skills":            sectionSkills,
+	"core skills":                 sectionSkills,
+	"key skills":                  sectionSkills,
+	"technologies":                sectionSkills,
+	"tech stack":                  sectionSkills,
+	"skills and technologies":     sectionSkills,
+	"skills & technologies":       sectionSkills,
+	"summary":                     sectionOther,
+	"professional summary":        sectionOther,
+	"profile":                     sectionOther,
+	"about me":                    sectionOther,
+	"objective":                   sectionOther,
+	"career objective":            sectionOther,
+	"projects":                    sectionOther,
+	"personal projects":           sectionOther,
+	"certifications":              sectionOther,
+	"certificates":                sectionOther,
+	"awards":                      sectionOther,
+	"achievements":                sectionOther,
+	"publications":                sectionOther,
+	"languages":                   sectionOther,
+	"interests":                   sectionOther,
+	"hobbies":                     sectionOther,
+	"references":                  sectionOther,
+	"contact":                     sectionOther,
+	"personal information":        sectionOther,
+	"personal details":            sectionOther,
+	"extracurricular activities":  sectionOther,
+	"training":                    sectionOther,
+	"volunteer experience":        sectionOther,
+	"leadership and volunteering": sectionOther,
+}
+
+const monthPattern = `jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?`
+
+const datePattern = `(?:(?:` + monthPattern + `)\.?,?\s*'?(?:19|20)\d{2}|\d{1,2}/(?:19|20)\d{2}|(?:19|20)\d{2})`
+
+var (
+	dateRangeRe   = regexp.MustCompile(`(?i)\b(` + datePattern + `)\s*(?:-|–|—|to|until|till)\s*(` + datePattern + `|present|current|now|ongoing|running|till date|to date)\b`)
+	monthRe       = regexp.MustCompile(`(?i)\b(` + monthPattern + `)`)
+	yearRe        = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+	numericMonth  = regexp.MustCompile(`^(\d{1,2})/`)
+	titleRe       = regexp.MustCompile(`(?i)\b(engineer|developer|programmer|intern|manager|lead|architect|designer|analyst|consultant|scientist|officer|head|cto|ceo|founder|co-founder|specialist|administrator|tester|qa|sqa|devops|sre|director|associate|trainee|executive|researcher|instructor|teacher|lecturer)\b`)
+	degreeRe      = regexp.MustCompile(`(?i)\b(b\.?\s?sc|m\.?\s?sc|b\.s\.?|m\.s\.?|b\.a\.?|m\.a\.?|bba|mba|b\.?\s?tech|m\.?\s?tech|b\.e\.?|m\.e\.?|ph\.?\s?d|bachelor(?:'s)?|master(?:'s)?|doctor(?:ate)?|diploma|hsc|ssc|a[- ]levels?|o[- ]levels?|higher secondary|secondary school|dakhil|alim)(?:\W|$)`)
+	institutionRe = regexp.MustCompile(`(?i)\b(university|college|institute|school|academy|polytechnic|madrasa|madrasah|buet|kuet|cuet|ruet|sust|iut|aiub|nsu|bracu|ewu|diu|uiu|aust)\b`)
+	gradeRe       = regexp.MustCompile(`(?i)\b(?:c?gpa|grade|result)\s*[:\-]?\s*(?:[0-9]+(?:\.[0-9]+)?(?:\s*(?:/|out of)\s*[0-9]+(?:\.[0-9]+)?)?|[a-f][+-]?(?:\W|$))`)
+	segmentRe     = regexp.MustCompile(`\s+[|–—-]\s+|,\s+|\t|\s{2,}`)
+)
+
+// headerSeparators split a single header line into title and company, in order of preference
+var headerSeparators = []string{" at ", " @ ", " | ", " — ", " – ", " - ", ", "}
+
+const bulletChars = "•●◦▪■□➢►*-–·"
+
+// ParseResume proposes profile entries from a resume's text. Technologies and languages are only
+// suggested when they match a known name, so the draft can be confirmed without creating new tags.
+func ParseResume(text string, technologies []models.Technology, languages []models.ProgrammingLanguage) *ResumeDraft {
+	lines := strings.Split(text, "\n")
+	sections := splitSections(lines)
+
+	experienceLines := sections[sectionExperience]
+	if len(experienceLines) == 0 {
+		experienceLines = sections[sectionNone]
+	}
+	educationLines := sections[sectionEducation]
+	if len(educationLines) == 0 {
+		educationLines = sections[sectionNone]
+	}
+
+	draft := &ResumeDraft{
+		Experiences: parseExperiences(experienceLines),
+		Educations:  parseEducations(educationLines),
+		Skills:      []DraftSkill{},
+		Languages:   []DraftSkill{},
+	}
+
+	names := make([]namedID, 0, len(technologies))
+	for _, tech := range technologies {
+		names = append(names, namedID{ID: tech.ID, Name: tech.Name})
+	}
+	for _, match := range findNames(text, names) {
+		draft.Skills = append(draft.Skills, DraftSkill{ID: match.ID, Name: match.Name, Proficiency: models.ProficiencyIntermediate})
+	}
+
+	names = names[:0]
+	for _, lang := range languages {
+		names = append(names, namedID{ID: lang.ID, Name: lang.Name})
+	}
+	for _, match := range findNames(text, names) {
+		draft.Languages = append(draft.Languages, DraftSkill{ID: match.ID, Name: match.Name, Proficiency: models.ProficiencyIntermediate})
+	}
+	return draft
+}
+
+// splitSections groups lines under the heading they follow. Lines before the first heading are sectionNone.
+func splitSections(lines []string) map[int][]string {
+	sections := map[int][]string{}
+	current := sectionNone
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if section, ok := headingSection(line); ok {
+			current = section
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+	return sections
+}
+
+func headingSection(line string) (int, bool) {
+	if len(line) > 40 {
+		return 0, false
+	}
+	key := strings.ToLower(strings.Trim(line, " :-–—•*#|_="))
+	key = strings.Join(strings.Fields(key), " ")
+	section, ok := resumeHeadings[key]
+	return section, ok
+}
+
+// parseExperiences finds entries anchored on a date range. The title and company are taken from the
+// rest of that line and the short lines right above it; the lines below, up to the next entry, are its description.
+func parseExperiences(lines []string) []DraftExperience {
+	var anchors []int
+	for i, line := range lines {
+		if dateRangeRe.MatchString(line) {
+			anchors = append(anchors, i)
+		}
+	}
+
+	type entry struct {
+		exp         DraftExperience
+		headerStart int
+		bodyStart   int
+	}
+	entries := make([]entry, 0, len(anchors))
+	for k, i := range anchors {
+		line := lines[i]
+		loc := dateRangeRe.FindStringSubmatchIndex(line)
+		start, end, ongoing := parseDateRange(line[loc[2]:loc[3]], line[loc[4]:loc[5]])
+
+		var header []string
+		if rest := cleanHeader(line[:loc[0]] + "  " + line[loc[1]:]); rest != "" {
+			header = append(header, rest)
+		}
+
+		floor := 0
+		if k > 0 {
+			floor = anchors[k-1] + 1
+		}
+		// A header line that already holds both title and company needs nothing from above
+		want := 2 - len(header)
+		if len(header) == 1 && splitsHeader(header[0]) {
+			want = 0
+		}
+		headerStart := i
+		for j := i - 1; j >= floor && want > 0 && isHeaderLine(lines[j]); j-- {
+			header = append([]string{lines[j]}, header...)
+			headerStart = j
+			want--
+		}
+
+		// Some layouts put the dates above the title
+		bodyStart := i + 1
+		if len(header) == 0 && bodyStart < len(lines) && isHeaderLine(lines[bodyStart]) && (k+1 >= len(anchors) || anchors[k+1] != bodyStart) {
+			header = append(header, lines[bodyStart])
+			bodyStart++
+		}
+
+		// Entries with a degree are educations listed without their own heading
+		if degreeRe.MatchString(strings.Join(header, " ")) {
+			continue
+		}
+
+		exp := DraftExperience{StartDate: start, EndDate: end}
+		if ongoing {
+			exp.EndDate = nil
+		}
+		exp.Title, exp.CompanyName = splitTitleCompany(header)
+		entries = append(entries, entry{exp: exp, headerStart: headerStart, bodyStart: bodyStart})
+	}
+
+	result := make([]DraftExperience, 0, len(entries))
+	for k := range entries {
+		bodyEnd := len(lines)
+		if k+1 < len(entries) {
+			bodyEnd = entries[k+1].headerStart
+		}
+		var description []string
+		for j := entries[k].bodyStart; j < bodyEnd && j < len(lines); j++ {
+			if _, ok := headingSection(lines[j]); ok {
+				break
+			}
+			description = append(description, stripBullet(lines[j]))
+		}
+		exp := entries[k].exp
+		exp.Description = truncate(strings.Join(description, "\n"), 5000)
+		result = append(result, exp)
+	}
+	return result
+}
+
+func isHeaderLine(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || len(line) > 80 || strings.HasSuffix(line, ".") || len(strings.Fields(line)) > 10 {
+		return false
+	}
+	if strings.ContainsAny(string([]rune(line)[0]), bulletChars) {
+		return false
+	}
+	_, heading := headingSection(line)
+	return !heading && !dateRangeRe.MatchString(line)
+}
+
+// cleanHeader trims separators and brackets left unbalanced by the dates removed from a line
+func cleanHeader(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.NewReplacer("( )", "", "()", "").Replace(s)
+	for {
+		trimmed := strings.Trim(s, " \t|,-–—·•:")
+		switch {
+		case strings.HasSuffix(trimmed, "("), strings.HasPrefix(trimmed, ")"):
+			trimmed = strings.Trim(trimmed, "()")
+		case strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")"):
+			trimmed = strings.TrimSuffix(trimmed, ")")
+		case strings.HasPrefix(trimmed, "(") && strings.Count(trimmed, "(") > strings.Count(trimmed, ")"):
+			trimmed = strings.TrimPrefix(trimmed, "(")
+		}
+		if trimmed == s {
+			return s
+		}
+		s = trimmed
+	}
+}
+
+func splitsHeader(header string) bool {
+	for _, sep := range headerSeparators {
+		if strings.Contains(strings.ToLower(header), sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitTitleCompany tells the job title from the company name, preferring the part that names a role as the title
+func splitTitleCompany(header []string) (string, string) {
+	var first, second string
+	switch len(header) {
+	case 0:
+		return "", ""
+	case 1:
+		first = header[0]
+		lower := strings.ToLower(first)
+		for _, sep := range headerSeparators {
+			if idx := strings.Index(lower, sep); idx > 0 {
+				first, second = header[0][:idx], header[0][idx+len(sep):]
+				break
+			}
+		}
+	default:
+		first, second = header[0], header[1]
+	}
+
+	first, second = cleanHeader(first), cleanHeader(second)
+	if titleRe.MatchString(second) && !titleRe.MatchString(first) {
+		first, second = second, first
+	}
+	return truncate(first, 255), truncate(second, 255)
+}
+
+// parseDateRange returns the start and end of a range. ongoing is true when it runs to the present.
+func parseDateRange(from, to string) (*time.Time, *time.Time, bool) {
+	start := parseResumeDate(from, false)
+	switch strings.ToLower(strings.TrimSpace(to)) {
+	case "present", "current", "now", "ongoing", "running", "till date", "to date":
+		return start, nil, true
+	}
+	end := parseResumeDate(to, true)
+	if start != nil && end != nil && !end.After(*start) {
+		end = nil
+	}
+	return start, end, false
+}
+
+// parseResumeDate reads "Jan 2020", "01/2020" or "2020". A bare year ends in December when it closes a range.
+func parseResumeDate(s string, end bool) *time.Time {
+	year, err := strconv.Atoi(yearRe.FindString(s))
+	if err != nil {
+		return nil
+	}
+
+	month := time.January
+	if end {
+		month = time.December
+	}
+	if m := monthRe.FindString(s); m != "" {
+		month = monthFromName(m)
+	} else if m := numericMonth.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+		if n, _ := strconv.Atoi(m[1]); n >= 1 && n <= 12 {
+			month = time.Month(n)
+		}
+	}
+
+	date := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+func monthFromName(name string) time.Month {
+	prefix := strings.ToLower(name)
+	if len(prefix) > 3 {
+		prefix = prefix[:3]
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), prefix) {
+			return m
+		}
+	}
+	return time.January
+}
+
+// parseEducations reads entries line by line. A new entry starts when a line carries a field the current one already has.
+func parseEducations(lines []string) []DraftEducation {
+	result := []DraftEducation{}
+	var current DraftEducation
+	flush := func() {
+		if current.Degree != "" || current.Institution != "" {
+			result = append(result, current)
+		}
+		current = DraftEducation{}
+	}
+
+	for _, line := range lines {
+		line = stripBullet(line)
+
+		var start, end *time.Time
+		if loc := dateRangeRe.FindStringSubmatchIndex(line); loc != nil {
+			var ongoing bool
+			start, end, ongoing = parseDateRange(line[loc[2]:loc[3]], line[loc[4]:loc[5]])
+			if ongoing {
+				end = nil
+			}
+			line = line[:loc[0]] + "  " + line[loc[1]:]
+		} else if years := yearRe.FindAllString(line, -1); len(years) == 1 && (degreeRe.MatchString(line) || institutionRe.MatchString(line) || len(strings.Fields(line)) <= 3) {
+			// A single year is the passing year
+			end = parseResumeDate(years[0], true)
+			line = strings.Replace(line, years[0], "  ", 1)
+		}
+
+		grade := ""
+		if loc := gradeRe.FindStringIndex(line); loc != nil {
+			grade = strings.TrimRight(strings.TrimSpace(line[loc[0]:loc[1]]), ",;)")
+			line = line[:loc[0]] + "  " + line[loc[1]:]
+		}
+
+		var degree, field, institution string
+		for _, segment := range segmentRe.Split(line, -1) {
+			segment = cleanHeader(segment)
+			switch {
+			case segment == "":
+			case degree == "" && degreeRe.MatchString(segment):
+				degree, field = splitDegree(segment)
+			case institution == "" && institutionRe.MatchString(segment):
+				institution = segment
+			}
+		}
+
+		if degree == "" && institution == "" && current.Degree == "" && current.Institution == "" {
+			continue
+		}
+		if (degree != "" && current.Degree != "") || (institution != "" && current.Institution != "") ||
+			(end != nil && current.EndDate != nil) || (grade != "" && current.Grade != "") {
+			flush()
+		}
+
+		if degree != "" {
+			current.Degree, current.FieldOfStudy = truncate(degree, 255), truncate(field, 255)
+		}
+		if institution != "" {
+			current.Institution = truncate(institution, 255)
+		}
+		if start != nil {
+			current.StartDate = start
+		}
+		if end != nil {
+			current.EndDate = end
+		}
+		if grade != "" {
+			current.Grade = truncate(grade, 50)
+		}
+	}
+	flush()
+	return result
+}
+
+// splitDegree separates "BSc in Computer Science" or "HSC (Science)" into degree and field of study
+func splitDegree(segment string) (string, string) {
+	lower := strings.ToLower(segment)
+	if idx := strings.Index(lower, " in "); idx > 0 {
+		return cleanHeader(segment[:idx]), cleanHeader(segment[idx+4:])
+	}
+	if open := strings.Index(segment, "("); open > 0 {
+		if close := strings.Index(segment[open:], ")"); close > 0 {
+			return cleanHeader(segment[:open]), cleanHeader(segment[open+1 : open+close])
+		}
+	}
+	return segment, ""
+}
+
+func stripBullet(line string) string {
+	return strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), bulletChars))
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:max]))
+}
+
+type namedID struct {
+	ID   uint
+	Name string
+}
+
+// findNames returns the names mentioned in text, in order of first mention. Names of one or two
+// characters such as "C", "R" or "Go" must match case exactly to avoid picking up ordinary words.
+func findNames(text string, names []namedID) []namedID {
+	lower := strings.ToLower(text)
+	type found struct {
+		namedID
+		pos int
+	}
+	var matches []found
+	for _, name := range names {
+		needle := strings.TrimSpace(name.Name)
+		if needle == "" {
+			continue
+		}
+		haystack := text
+		if len([]rune(needle)) > 2 {
+			haystack, needle = lower, strings.ToLower(needle)
+		}
+		if pos := indexWord(haystack, needle); pos >= 0 {
+			matches = append(matches, found{namedID: name, pos: pos})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].pos < matches[j].pos })
+	result := make([]namedID, 0, len(matches))
+	for _, match := range matches {
+		result = append(result, match.namedID)
+	}
+	return result
+}
+
+// indexWord finds needle where it is not part of a longer word. "+" and "#" count as word
+// characters so "C" does not match inside "C++" or "C#".
+func indexWord(haystack, needle string) int {
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#'
+	}
+	for offset := 0; offset < len(haystack); {
+		idx := strings.Index(haystack[offset:], needle)
+		if idx < 0 {
+			return -1
+		}
+		pos := offset + idx
+		end := pos + len(needle)
+
+		previous, _ := utf8.DecodeLastRuneInString(haystack[:pos])
+		next, _ := utf8.DecodeRuneInString(haystack[end:])
+		before := pos == 0 || !isWord(previous)
+		after := end == len(haystack) || !isWord(next)
+		// A trailing full stop ends a sentence, but ".NET" continues the name. "React.js" still mentions React.
+		if after && end < len(haystack) && haystack[end] == '.' && end+1 < len(haystack) && isWord(rune(haystack[end+1])) {
+			rest := strings.ToLower(haystack[end:])
+			after = strings.HasPrefix(rest, ".js") && (len(rest) == 3 || !isWord(rune(rest[3])))
+		}
+		if before && after {
+			return pos
+		}
+		offset = pos + 1
+	}
+	return -1
+}
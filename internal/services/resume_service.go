@@ -0,0 +1,168 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"github.com/bishworup11/bdSeeker-backend/internal/storage"
+	"github.com/bishworup11/bdSeeker-backend/pkg/document"
+	"gorm.io/gorm"
+)
+
+var ErrResumeNotFound = errors.New("resume not found")
+
+type ResumeService struct {
+	developerRepo *repositories.DeveloperRepository
+	techRepo      *repositories.TechRepository
+	storage       storage.Storage
+	maxSize       int64
+}
+
+func NewResumeService(developerRepo *repositories.DeveloperRepository, techRepo *repositories.TechRepository, store storage.Storage, maxSize int64) *ResumeService {
+	return &ResumeService{
+		developerRepo: developerRepo,
+		techRepo:      techRepo,
+		storage:       store,
+		maxSize:       maxSize,
+	}
+}
+
+// ResumeUpload is a stored resume with the profile entries proposed from its text
+type ResumeUpload struct {
+	Resume  *models.DeveloperResume `json:"resume"`
+	Draft   *ResumeDraft            `json:"draft"`
+	Warning string                  `json:"warning,omitempty"` // why no draft could be proposed
+}
+
+// MaxResumeSize returns the largest accepted resume in bytes
+func (s *ResumeService) MaxResumeSize() int64 {
+	return s.maxSize
+}
+
+// Upload stores a PDF or DOCX resume, replacing the developer's previous one, and proposes profile entries
+// from its text. A file whose text cannot be read, such as a scanned PDF, is still kept for job applications.
+func (s *ResumeService) Upload(ctx context.Context, developerID uint, filename string, data []byte) (*ResumeUpload, error) {
+	if int64(len(data)) > s.maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	format := document.Detect(data)
+	if format == "" {
+		return nil, document.ErrUnsupportedFormat
+	}
+
+	upload := &ResumeUpload{}
+	_, text, err := document.ExtractText(data)
+	if err != nil {
+		upload.Warning = fmt.Sprintf("The resume was saved but no profile entries could be proposed: %v", err)
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	key := path.Join("resumes", fmt.Sprint(developerID), name+"."+format)
+	contentType := document.ContentTypes[format]
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, fmt.Errorf("failed to store resume: %w", err)
+	}
+
+	resume, err := s.developerRepo.FindResume(developerID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		_ = s.storage.Delete(ctx, key)
+		return nil, err
+	}
+
+	previous := resume.FileKey
+	resume.DeveloperID = developerID
+	resume.FileKey = key
+	resume.FileName = filepath.Base(filename)
+	resume.ContentType = contentType
+	resume.Size = int64(len(data))
+	resume.ExtractedText = text
+	if err := s.developerRepo.SaveResume(resume); err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return nil, err
+	}
+	if previous != "" {
+		_ = s.storage.Delete(ctx, previous)
+	}
+
+	upload.Resume = resume
+	if upload.Draft, err = s.draft(text); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// Current returns the developer's resume with a draft rebuilt from its text, so new technologies are picked up
+func (s *ResumeService) Current(developerID uint) (*ResumeUpload, error) {
+	resume, err := s.find(developerID)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := s.draft(resume.ExtractedText)
+	if err != nil {
+		return nil, err
+	}
+	return &ResumeUpload{Resume: resume, Draft: draft}, nil
+}
+
+// Open returns the original resume file of a developer
+func (s *ResumeService) Open(ctx context.Context, developerID uint) (io.ReadCloser, *models.DeveloperResume, error) {
+	resume, err := s.find(developerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader, err := s.storage.Get(ctx, resume.FileKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reader, resume, nil
+}
+
+// Delete removes the developer's resume and its file
+func (s *ResumeService) Delete(ctx context.Context, developerID uint) error {
+	resume, err := s.find(developerID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.developerRepo.DeleteResume(resume.ID); err != nil {
+		return err
+	}
+	_ = s.storage.Delete(ctx, resume.FileKey)
+	return nil
+}
+
+func (s *ResumeService) find(developerID uint) (*models.DeveloperResume, error) {
+	resume, err := s.developerRepo.FindResume(developerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+	return resume, nil
+}
+
+func (s *ResumeService) draft(text string) (*ResumeDraft, error) {
+	technologies, err := s.techRepo.ListTechnologies("")
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.techRepo.ListLanguages("")
+	if err != nil {
+		return nil, err
+	}
+	return ParseResume(text, technologies, languages), nil
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+)
+
+const (
+	// Plausible monthly base salary range in BDT; anything outside is flagged for moderation
+	salaryPlausibleMin = 3_000
+	salaryPlausibleMax = 3_000_000
+	// Reports further than this many interquartile ranges outside p25/p75 of their peers are flagged
+	salaryOutlierIQRFactor = 3.0
+	// A user may report one salary per company and title in this period
+	salaryResubmitPeriod = 365 * 24 * time.Hour
+)
+
+type SalaryService struct {
+	salaryRepo    *repositories.SalaryRepository
+	companyRepo   *repositories.CompanyRepository
+	minSampleSize int
+}
+
+func NewSalaryService(salaryRepo *repositories.SalaryRepository, companyRepo *repositories.CompanyRepository, minSampleSize int) *SalaryService {
+	if minSampleSize < 1 {
+		minSampleSize = 1
+	}
+	return &SalaryService{
+		salaryRepo:    salaryRepo,
+		companyRepo:   companyRepo,
+		minSampleSize: minSampleSize,
+	}
+}
+
+type SubmitSalaryRequest struct {
+	JobTitle          string  `json:"job_title" validate:"required,max=255"`
+	Level             string  `json:"level" validate:"required,oneof=intern junior mid senior lead principal manager"`
+	YearsOfExperience int     `json:"years_of_experience" validate:"min=0,max=50"`
+	BaseSalary        float64 `json:"base_salary" validate:"required,gt=0"`
+	AnnualBonus       float64 `json:"annual_bonus" validate:"min=0"`
+	Location          string  `json:"location" validate:"max=255"`
+}
+
+// CompanySalarySummary is the public, aggregated view of a company's salaries.
+// Groups below the minimum sample size are omitted to protect reporters' anonymity.
+type CompanySalarySummary struct {
+	CompanyID     uint                       `json:"company_id"`
+	MinSampleSize int                        `json:"min_sample_size"`
+	Overall       *repositories.SalaryStats  `json:"overall"`
+	ByTitle       []repositories.SalaryStats `json:"by_title"`
+}
+
+// Submit stores a salary report. Reports that look implausible are held for moderation instead of being counted.
+func (s *SalaryService) Submit(userID, companyID uint, req *SubmitSalaryRequest) (*models.SalaryReport, error) {
+	exists, err := s.companyRepo.Exists(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("company not found")
+	}
+
+	title := NormalizeJobTitle(req.JobTitle)
+	if title == "" {
+		return nil, errors.New("job title is required")
+	}
+
+	duplicate, err := s.salaryRepo.HasRecentReport(userID, companyID, title, time.Now().Add(-salaryResubmitPeriod))
+	if err != nil {
+		return nil, err
+	}
+	if duplicate {
+		return nil, errors.New("you have already reported a salary for this title at this company in the last year")
+	}
+
+	report := &models.SalaryReport{
+		CompanyID:         companyID,
+		UserID:            userID,
+		JobTitle:          strings.TrimSpace(req.JobTitle),
+		NormalizedTitle:   title,
+		Level:             req.Level,
+		YearsOfExperience: req.YearsOfExperience,
+		BaseSalary:        req.BaseSalary,
+		AnnualBonus:       req.AnnualBonus,
+		Location:          strings.TrimSpace(req.Location),
+		Moderation:        models.Moderation{Status: models.ModerationApproved},
+	}
+
+	peers, err := s.salaryRepo.CompanyStats(companyID, title)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason := s.outlierReason(report, peers); reason != "" {
+		report.IsOutlier = true
+		report.OutlierReason = reason
+		report.Moderation.Status = models.ModerationPending
+	}
+
+	if err := s.salaryRepo.Create(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Summary returns aggregated percentiles for a company, overall and per title
+func (s *SalaryService) Summary(companyID uint) (*CompanySalarySummary, error) {
+	summary := &CompanySalarySummary{
+		CompanyID:     companyID,
+		MinSampleSize: s.minSampleSize,
+		ByTitle:       []repositories.SalaryStats{},
+	}
+
+	overall, err := s.salaryRepo.CompanyStats(companyID, "")
+	if err != nil {
+		return nil, err
+	}
+	if overall.SampleSize >= int64(s.minSampleSize) {
+		summary.Overall = overall
+	}
+
+	byTitle, err := s.salaryRepo.TitleStats(companyID, s.minSampleSize)
+	if err != nil {
+		return nil, err
+	}
+	if byTitle != nil {
+		summary.ByTitle = byTitle
+	}
+
+	return summary, nil
+}
+
+// outlierReason explains why a report looks implausible, or returns "" when it looks fine
+func (s *SalaryService) outlierReason(report *models.SalaryReport, peers *repositories.SalaryStats) string {
+	if report.BaseSalary < salaryPlausibleMin || report.BaseSalary > salaryPlausibleMax {
+		return fmt.Sprintf("base salary outside plausible range %d-%d BDT", salaryPlausibleMin, salaryPlausibleMax)
+	}
+	if report.AnnualBonus > report.BaseSalary*24 {
+		return "annual bonus exceeds 24 months of base salary"
+	}
+
+	if peers == nil || peers.SampleSize < int64(s.minSampleSize) {
+		return ""
+	}
+
+	spread := peers.P75 - peers.P25
+	if spread <= 0 {
+		spread = peers.P50 * 0.5
+	}
+	low := peers.P25 - salaryOutlierIQRFactor*spread
+	high := peers.P75 + salaryOutlierIQRFactor*spread
+	if report.BaseSalary < low || report.BaseSalary > high {
+		return fmt.Sprintf("base salary far from the %d existing reports for this title", peers.SampleSize)
+	}
+	return ""
+}
+
+// NormalizeJobTitle lowercases a title and collapses punctuation and whitespace so similar titles group together.
+// Combining marks, such as Bangla vowel signs, stay part of their word.
+func NormalizeJobTitle(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+	return strings.Join(fields, " ")
+}
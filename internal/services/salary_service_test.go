@@ -0,0 +1,93 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+)
+
+func TestOutlierReason(t *testing.T) {
+	// Peers earning 50k-70k a month: IQR 20k, so reports below -10k or above 130k are outliers
+	peers := &repositories.SalaryStats{SampleSize: 5, P25: 50_000, P50: 60_000, P75: 70_000}
+	tests := []struct {
+		name       string
+		salary     float64
+		bonus      float64
+		peers      *repositories.SalaryStats
+		wantReason string // "" when the report is not an outlier
+	}{
+		{"typical salary", 65_000, 100_000, peers, ""},
+		{"at the upper fence", 130_000, 0, peers, ""},
+		{"above the upper fence", 130_001, 0, peers, "far from the 5 existing reports"},
+		{"below the plausible range", 2_000, 0, peers, "outside plausible range"},
+		{"above the plausible range", 3_000_001, 0, nil, "outside plausible range"},
+		{"bonus over 24 months", 60_000, 1_440_001, peers, "annual bonus"},
+		{"no peers", 500_000, 0, nil, ""},
+		{"too few peers to compare", 500_000, 0, &repositories.SalaryStats{SampleSize: 4, P25: 50_000, P50: 60_000, P75: 70_000}, ""},
+		{"identical peers fall back to half the median", 150_001, 0, &repositories.SalaryStats{SampleSize: 5, P25: 60_000, P50: 60_000, P75: 60_000}, "far from"},
+		{"identical peers within the fallback spread", 150_000, 0, &repositories.SalaryStats{SampleSize: 5, P25: 60_000, P50: 60_000, P75: 60_000}, ""},
+	}
+
+	s := NewSalaryService(nil, nil, 5)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &models.SalaryReport{BaseSalary: tt.salary, AnnualBonus: tt.bonus}
+			reason := s.outlierReason(report, tt.peers)
+			if tt.wantReason == "" && reason != "" {
+				t.Errorf("outlierReason = %q, want no reason", reason)
+			}
+			if tt.wantReason != "" && !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("outlierReason = %q, want it to mention %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNewSalaryServiceMinSampleSize(t *testing.T) {
+	tests := []struct {
+		configured, want int
+	}{
+		{5, 5},
+		{1, 1},
+		{0, 1},
+		{-3, 1},
+	}
+	for _, tt := range tests {
+		if got := NewSalaryService(nil, nil, tt.configured).minSampleSize; got != tt.want {
+			t.Errorf("NewSalaryService(%d).minSampleSize = %d, want %d", tt.configured, got, tt.want)
+		}
+	}
+
+	// With a minimum of one, a single peer is enough to flag a report
+	s := NewSalaryService(nil, nil, 0)
+	peer := &repositories.SalaryStats{SampleSize: 1, P25: 60_000, P50: 60_000, P75: 60_000}
+	if reason := s.outlierReason(&models.SalaryReport{BaseSalary: 500_000}, peer); reason == "" {
+		t.Error("outlierReason with one peer and a minimum of one = no reason, want an outlier")
+	}
+}
+
+func TestNormalizeJobTitle(t *testing.T) {
+	tests := []struct {
+		title, want string
+	}{
+		{"Software Engineer", "software engineer"},
+		{"  Software   Engineer ", "software engineer"},
+		{"Sr. Software Engineer", "sr software engineer"},
+		{"Software-Engineer", "software engineer"},
+		{"Software Engineer (Backend)", "software engineer backend"},
+		{"C++ Developer", "c++ developer"},
+		{"C# / .NET Developer", "c# net developer"},
+		{"Engineer II", "engineer ii"},
+		{"Engineer 2", "engineer 2"},
+		{"সফটওয়্যার  ইঞ্জিনিয়ার।", "সফটওয়্যার ইঞ্জিনিয়ার"},
+		{"---", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeJobTitle(tt.title); got != tt.want {
+			t.Errorf("NormalizeJobTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
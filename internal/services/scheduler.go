@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+)
+
+// runPeriodically runs job right away and then every interval until ctx is done.
+// The named lock keeps other instances from running the same job at the same time; they skip that round.
+func runPeriodically(ctx context.Context, locks *repositories.LockRepository, name string, interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := locks.RunExclusive(ctx, name, job); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to run %s: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	verificationTXTPrefix   = "_bdseeker-verification"
+	verificationValuePrefix = "bdseeker-verification="
+	verificationWellKnown   = "/.well-known/bdseeker-verification.txt"
+	verificationTTL         = 7 * 24 * time.Hour
+	verificationEmailTTL    = 24 * time.Hour
+	verificationMaxAttempts = 10
+	verificationFetchLimit  = 1024
+)
+
+// TXTResolver looks up DNS TXT records. *net.Resolver satisfies this interface.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// HTTPFetcher performs HTTP requests. *http.Client satisfies this interface.
+type HTTPFetcher interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type VerificationService struct {
+	companyRepo  *repositories.CompanyRepository
+	activityRepo *repositories.ActivityRepository
+	resolver     TXTResolver
+	fetcher      HTTPFetcher
+	mailer       Mailer
+	now          func() time.Time
+}
+
+func NewVerificationService(companyRepo *repositories.CompanyRepository, activityRepo *repositories.ActivityRepository, resolver TXTResolver, fetcher HTTPFetcher, mailer Mailer) *VerificationService {
+	return &VerificationService{
+		companyRepo:  companyRepo,
+		activityRepo: activityRepo,
+		resolver:     resolver,
+		fetcher:      fetcher,
+		mailer:       mailer,
+		now:          time.Now,
+	}
+}
+
+// NewVerificationHTTPClient returns an HTTP client suitable for fetching well-known verification files.
+// It only connects to public addresses, so a company cannot point its domain at internal services,
+// and redirects are only followed within the same host.
+func NewVerificationHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: rejectNonPublicAddress}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		// No proxy: the dialer must see the address of the verified host itself
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 3 || req.URL.Hostname() != via[0].URL.Hostname() {
+				return http.ErrUseLastResponse
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			if ip := net.ParseIP(req.URL.Hostname()); ip != nil && !isPublicIP(ip) {
+				return fmt.Errorf("redirect to non-public address %s", ip)
+			}
+			return nil
+		},
+	}
+}
+
+// rejectNonPublicAddress is a net.Dialer Control hook refusing connections to loopback, private, link-local
+// and other non-public addresses. It runs after DNS resolution, so it also covers names resolving to them.
+func rejectNonPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("connection to non-public address %s refused", host)
+	}
+	return nil
+}
+
+// Shared address space used by carrier-grade NAT, which net.IP does not classify as private
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+type StartVerificationRequest struct {
+	Method string `json:"method" validate:"required,oneof=dns http email"`
+	Email  string `json:"email" validate:"omitempty,email"`
+}
+
+// VerificationChallenge tells the company how to prove domain ownership
+type VerificationChallenge struct {
+	Verification *models.CompanyVerification `json:"verification"`
+	Instructions string                      `json:"instructions"`
+	RecordName   string                      `json:"record_name,omitempty"`
+	RecordValue  string                      `json:"record_value,omitempty"`
+	FileURL      string                      `json:"file_url,omitempty"`
+	FileContent  string                      `json:"file_content,omitempty"`
+}
+
+// Start creates a new verification challenge for the company owned by userID
+func (s *VerificationService) Start(userID uint, req *StartVerificationRequest) (*VerificationChallenge, error) {
+	company, err := s.companyRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user does not have a company profile")
+		}
+		return nil, err
+	}
+
+	if company.IsVerified {
+		return nil, errors.New("company is already verified")
+	}
+
+	domain, err := DomainFromWebsite(company.Website)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateVerificationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &models.CompanyVerification{
+		CompanyID: company.ID,
+		Domain:    domain,
+		Method:    req.Method,
+		Token:     token,
+		Status:    models.VerificationPending,
+		ExpiresAt: s.now().Add(verificationTTL),
+	}
+
+	if req.Method == models.VerificationMethodEmail {
+		email := strings.ToLower(strings.TrimSpace(req.Email))
+		if !emailBelongsToDomain(email, domain) {
+			return nil, fmt.Errorf("email must be an address at %s", domain)
+		}
+		verification.Email = email
+		verification.ExpiresAt = s.now().Add(verificationEmailTTL)
+	}
+
+	if err := s.companyRepo.CreateVerification(verification); err != nil {
+		return nil, err
+	}
+
+	challenge := &VerificationChallenge{Verification: verification}
+
+	switch req.Method {
+	case models.VerificationMethodDNS:
+		challenge.RecordName = verificationTXTPrefix + "." + domain
+		challenge.RecordValue = verificationValuePrefix + token
+		challenge.Instructions = "Add a TXT record named " + challenge.RecordName + " with the value " + challenge.RecordValue + ", then run the check."
+	case models.VerificationMethodHTTP:
+		challenge.FileURL = "https://" + domain + verificationWellKnown
+		challenge.FileContent = verificationValuePrefix + token
+		challenge.Instructions = "Serve a text file at " + challenge.FileURL + " containing " + challenge.FileContent + ", then run the check."
+	case models.VerificationMethodEmail:
+		body := fmt.Sprintf("Use this code to verify %s on bdSeeker: %s\n\nThe code expires in 24 hours.", company.CompanyName, token)
+		if err := s.mailer.Send(verification.Email, "Verify your company on bdSeeker", body); err != nil {
+			return nil, err
+		}
+		challenge.Instructions = "We sent a verification code to " + verification.Email + ". Submit it to complete verification."
+	}
+
+	return challenge, nil
+}
+
+// Check evaluates the latest pending challenge of the company owned by userID.
+// code is only used by the email method.
+func (s *VerificationService) Check(userID uint, code string) (*models.CompanyVerification, error) {
+	company, err := s.companyRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user does not have a company profile")
+		}
+		return nil, err
+	}
+
+	verification, err := s.companyRepo.FindLatestVerification(company.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no verification has been started")
+		}
+		return nil, err
+	}
+
+	if verification.Status != models.VerificationPending {
+		return verification, nil
+	}
+
+	now := s.now()
+	if now.After(verification.ExpiresAt) {
+		verification.Status = models.VerificationExpired
+		if err := s.companyRepo.UpdateVerification(verification); err != nil {
+			return nil, err
+		}
+		return verification, nil
+	}
+
+	verification.Attempts++
+	verification.LastCheckedAt = &now
+
+	if checkErr := s.evaluate(verification, code); checkErr != nil {
+		verification.LastError = checkErr.Error()
+		if verification.Attempts >= verificationMaxAttempts {
+			verification.Status = models.VerificationFailed
+		}
+		if err := s.companyRepo.UpdateVerification(verification); err != nil {
+			return nil, err
+		}
+		return verification, nil
+	}
+
+	verification.Status = models.VerificationVerified
+	verification.LastError = ""
+	verification.VerifiedAt = &now
+	if err := s.companyRepo.MarkVerified(verification); err != nil {
+		return nil, err
+	}
+
+	activity := &models.CompanyActivity{
+		CompanyID: company.ID,
+		Type:      models.ActivityCompanyVerified,
+		Summary:   company.CompanyName + " verified " + verification.Domain,
+	}
+	if err := s.activityRepo.Create(activity); err != nil {
+		log.Printf("Failed to record verification activity for company %d: %v", company.ID, err)
+	}
+
+	return verification, nil
+}
+
+// Status returns the latest verification challenge of the company owned by userID
+func (s *VerificationService) Status(userID uint) (*models.CompanyVerification, error) {
+	company, err := s.companyRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user does not have a company profile")
+		}
+		return nil, err
+	}
+	return s.companyRepo.FindLatestVerification(company.ID)
+}
+
+// evaluate checks whether the proof for a pending challenge is in place, returning why not if it is missing
+func (s *VerificationService) evaluate(verification *models.CompanyVerification, code string) error {
+	switch verification.Method {
+	case models.VerificationMethodDNS:
+		return s.checkDNS(verification)
+	case models.VerificationMethodHTTP:
+		return s.checkHTTP(verification)
+	case models.VerificationMethodEmail:
+		if strings.TrimSpace(code) != verification.Token {
+			return errors.New("verification code does not match")
+		}
+		return nil
+	default:
+		return errors.New("unknown verification method")
+	}
+}
+
+func (s *VerificationService) checkDNS(verification *models.CompanyVerification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	records, err := s.resolver.LookupTXT(ctx, verificationTXTPrefix+"."+verification.Domain)
+	if err != nil {
+		return fmt.Errorf("TXT lookup failed: %w", err)
+	}
+
+	expected := verificationValuePrefix + verification.Token
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return nil
+		}
+	}
+	return errors.New("verification TXT record not found")
+}
+
+func (s *VerificationService) checkHTTP(verification *models.CompanyVerification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	expected := verificationValuePrefix + verification.Token
+	var lastErr error
+	for _, scheme := range []string{"https", "http"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+verification.Domain+verificationWellKnown, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.fetcher.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to fetch verification file: %w", err)
+			continue
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, verificationFetchLimit))
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read verification file: %w", err)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("verification file returned status %d", resp.StatusCode)
+			continue
+		}
+
+		if strings.TrimSpace(string(body)) == expected {
+			return nil
+		}
+		lastErr = errors.New("verification file content does not match")
+	}
+	return lastErr
+}
+
+// DomainFromWebsite extracts the registrable host from a company website, without a leading "www."
+func DomainFromWebsite(website string) (string, error) {
+	website = strings.TrimSpace(website)
+	if website == "" {
+		return "", errors.New("company website is required for verification")
+	}
+	if !strings.Contains(website, "://") {
+		website = "https://" + website
+	}
+
+	parsed, err := url.Parse(website)
+	if err != nil || parsed.Hostname() == "" {
+		return "", errors.New("company website is not a valid URL")
+	}
+
+	host := strings.ToLower(strings.TrimPrefix(parsed.Hostname(), "www."))
+	if net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return "", errors.New("company website must use a domain name")
+	}
+	return host, nil
+}
+
+func emailBelongsToDomain(email, domain string) bool {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return false
+	}
+	host := email[at+1:]
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+func generateVerificationToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
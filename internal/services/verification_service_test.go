@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/models"
+)
+
+const testToken = "0123456789abcdef0123456789abcdef"
+
+type fakeResolver struct {
+	records map[string][]string
+	err     error
+}
+
+func (r fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.records[name], nil
+}
+
+// fakeFetcher answers requests by URL; URLs without an answer fail like an unreachable host
+type fakeFetcher struct {
+	responses map[string]*http.Response
+	requested []string
+}
+
+func (f *fakeFetcher) Do(req *http.Request) (*http.Response, error) {
+	f.requested = append(f.requested, req.URL.String())
+	if resp, ok := f.responses[req.URL.String()]; ok {
+		return resp, nil
+	}
+	return nil, errors.New("connection refused")
+}
+
+func textResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func pendingVerification(method string) *models.CompanyVerification {
+	return &models.CompanyVerification{Domain: "acme.test", Method: method, Token: testToken, Status: models.VerificationPending}
+}
+
+func TestEvaluateDNS(t *testing.T) {
+	name := "_bdseeker-verification.acme.test"
+	tests := []struct {
+		name     string
+		resolver fakeResolver
+		valid    bool
+	}{
+		{"matching record", fakeResolver{records: map[string][]string{name: {"v=spf1 -all", " bdseeker-verification=" + testToken + " "}}}, true},
+		{"record of another token", fakeResolver{records: map[string][]string{name: {"bdseeker-verification=other"}}}, false},
+		{"record on the apex", fakeResolver{records: map[string][]string{"acme.test": {"bdseeker-verification=" + testToken}}}, false},
+		{"lookup failure", fakeResolver{err: errors.New("no such host")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &VerificationService{resolver: tt.resolver}
+			if err := s.evaluate(pendingVerification(models.VerificationMethodDNS), ""); (err == nil) != tt.valid {
+				t.Errorf("evaluate() = %v, want valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestEvaluateHTTP(t *testing.T) {
+	httpsURL := "https://acme.test/.well-known/bdseeker-verification.txt"
+	httpURL := "http://acme.test/.well-known/bdseeker-verification.txt"
+	tests := []struct {
+		name      string
+		responses map[string]*http.Response
+		valid     bool
+	}{
+		{"served over https", map[string]*http.Response{httpsURL: textResponse(http.StatusOK, "bdseeker-verification="+testToken+"\n")}, true},
+		{"served over http only", map[string]*http.Response{httpURL: textResponse(http.StatusOK, "bdseeker-verification="+testToken)}, true},
+		{"missing file", map[string]*http.Response{httpsURL: textResponse(http.StatusNotFound, "bdseeker-verification="+testToken)}, false},
+		{"wrong content", map[string]*http.Response{httpsURL: textResponse(http.StatusOK, "bdseeker-verification=other")}, false},
+		{"unreachable", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &fakeFetcher{responses: tt.responses}
+			s := &VerificationService{fetcher: fetcher}
+			if err := s.evaluate(pendingVerification(models.VerificationMethodHTTP), ""); (err == nil) != tt.valid {
+				t.Errorf("evaluate() = %v, want valid = %v", err, tt.valid)
+			}
+			if len(fetcher.requested) == 0 || fetcher.requested[0] != httpsURL {
+				t.Errorf("requested %v, want https first", fetcher.requested)
+			}
+		})
+	}
+}
+
+func TestEvaluateEmail(t *testing.T) {
+	s := &VerificationService{}
+	if err := s.evaluate(pendingVerification(models.VerificationMethodEmail), " "+testToken+"\n"); err != nil {
+		t.Errorf("evaluate() with the sent code = %v, want nil", err)
+	}
+	for _, code := range []string{"", "00000000000000000000000000000000"} {
+		if err := s.evaluate(pendingVerification(models.VerificationMethodEmail), code); err == nil {
+			t.Errorf("evaluate() with code %q succeeded", code)
+		}
+	}
+}
+
+func TestEmailBelongsToDomain(t *testing.T) {
+	tests := map[string]bool{
+		"hr@acme.test":          true,
+		"hr@mail.acme.test":     true,
+		"hr@notacme.test":       false,
+		"hr@acme.test.evil.com": false,
+		"@acme.test":            false,
+		"acme.test":             false,
+	}
+	for email, want := range tests {
+		if got := emailBelongsToDomain(email, "acme.test"); got != want {
+			t.Errorf("emailBelongsToDomain(%q) = %v, want %v", email, got, want)
+		}
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+	}
+	for address, want := range tests {
+		if got := isPublicIP(net.ParseIP(address)); got != want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestVerificationHTTPClientRefusesLoopback(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	client := NewVerificationHTTPClient()
+	for _, target := range []string{server.URL, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)} {
+		if resp, err := client.Get(target); err == nil {
+			resp.Body.Close()
+			t.Errorf("GET %s succeeded, want the connection refused", target)
+		}
+	}
+	if hit {
+		t.Error("the loopback server received a request")
+	}
+}
+
+func TestVerificationHTTPClientRedirects(t *testing.T) {
+	client := NewVerificationHTTPClient()
+	origin, _ := http.NewRequest(http.MethodGet, "https://acme.test/.well-known/bdseeker-verification.txt", nil)
+
+	tests := []struct {
+		target  string
+		allowed bool
+	}{
+		{"https://acme.test/verification.txt", true},
+		{"http://acme.test/.well-known/bdseeker-verification.txt", true},
+		{"https://internal.acme.test/verification.txt", false},
+		{"http://169.254.169.254/latest/meta-data/", false},
+		{"ftp://acme.test/verification.txt", false},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, tt.target, nil)
+		if err := client.CheckRedirect(req, []*http.Request{origin}); (err == nil) != tt.allowed {
+			t.Errorf("redirect to %s: CheckRedirect() = %v, want allowed = %v", tt.target, err, tt.allowed)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/bishworup11/bdSeeker-backend/internal/config"
+)
+
+// Storage stores uploaded files under slash-separated keys
+type Storage interface {
+	// Put writes the content under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of the object stored under key
+	URL(key string) string
+}
+
+// New returns the storage driver selected by STORAGE_DRIVER
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.StorageDriver {
+	case "", "local":
+		return NewLocalStorage(cfg.StorageLocalPath, cfg.StoragePublicURL)
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint:     cfg.S3Endpoint,
+			Region:       cfg.S3Region,
+			Bucket:       cfg.S3Bucket,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			UsePathStyle: cfg.S3UsePathStyle,
+			PublicURL:    cfg.StoragePublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
+
+// NewPrivate returns the storage for documents that must never be publicly reachable, such as claim documents
+// and resumes. They are kept in STORAGE_PRIVATE_PATH or S3_PRIVATE_BUCKET, apart from the public uploads.
+func NewPrivate(cfg *config.Config) (Storage, error) {
+	switch cfg.StorageDriver {
+	case "", "local":
+		if within(cfg.StoragePrivatePath, cfg.StorageLocalPath) {
+			return nil, errors.New("STORAGE_PRIVATE_PATH must be outside the publicly served STORAGE_LOCAL_PATH")
+		}
+		return NewLocalStorage(cfg.StoragePrivatePath, "")
+	case "s3":
+		if cfg.S3PrivateBucket == "" || cfg.S3PrivateBucket == cfg.S3Bucket {
+			return nil, errors.New("S3_PRIVATE_BUCKET must be set to a bucket without public access, other than S3_BUCKET")
+		}
+		return NewS3Storage(S3Options{
+			Endpoint:     cfg.S3Endpoint,
+			Region:       cfg.S3Region,
+			Bucket:       cfg.S3PrivateBucket,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			UsePathStyle: cfg.S3UsePathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
+
+// within reports whether dir is root or a directory below it
+func within(dir, root string) bool {
+	dir, dirErr := filepath.Abs(dir)
+	root, rootErr := filepath.Abs(root)
+	if dirErr != nil || rootErr != nil {
+		return true
+	}
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/bishworup11/bdSeeker-backend/internal/config"
 	"github.com/bishworup11/bdSeeker-backend/internal/database"
@@ -10,6 +17,7 @@ import (
 	"github.com/bishworup11/bdSeeker-backend/internal/middleware"
 	"github.com/bishworup11/bdSeeker-backend/internal/repositories"
 	"github.com/bishworup11/bdSeeker-backend/internal/services"
+	"github.com/bishworup11/bdSeeker-backend/internal/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -30,17 +38,52 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	// Give existing companies, jobs and developers their slugs
+	if err := repositories.BackfillSlugs(database.GetDB()); err != nil {
+		log.Fatalf("Failed to backfill slugs: %v", err)
+	}
+
 	// Seed default admin user
 	if err := database.SeedAdminUser(); err != nil {
 		log.Fatalf("Failed to seed admin user: %v", err)
 	}
 
+	// Initialize file storage
+	fileStorage, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+	privateStorage, err := storage.NewPrivate(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize private storage: %v", err)
+	}
+
 	// Initialize repositories
 	db := database.GetDB()
 	userRepo := repositories.NewUserRepository(db)
+	companyRepo := repositories.NewCompanyRepository(db)
+	activityRepo := repositories.NewActivityRepository(db)
+	lockRepo := repositories.NewLockRepository(db)
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo)
+	mailer := services.NewMailer(cfg)
+	verificationService := services.NewVerificationService(companyRepo, activityRepo, net.DefaultResolver, services.NewVerificationHTTPClient(), mailer)
+	mediaService := services.NewMediaService(fileStorage, cfg.UploadMaxImageBytes)
+	salaryService := services.NewSalaryService(repositories.NewSalaryRepository(db), companyRepo, cfg.SalaryMinSampleSize)
+	claimService := services.NewClaimService(repositories.NewClaimRepository(db), companyRepo, userRepo, mailer, privateStorage, cfg.UploadMaxDocumentBytes)
+	resumeService := services.NewResumeService(repositories.NewDeveloperRepository(db), repositories.NewTechRepository(db), privateStorage, cfg.UploadMaxDocumentBytes)
+	jsonResumeService := services.NewJSONResumeService(repositories.NewDeveloperRepository(db), repositories.NewTechRepository(db))
+	endorsementService := services.NewEndorsementService(repositories.NewEndorsementRepository(db))
+	jobSearchService := services.NewJobSearchService(repositories.NewDeveloperRepository(db), lockRepo, cfg.OpenToWorkExpiry)
+	cvService, err := services.NewCVService(cfg.CVFontPath, cfg.CVBoldFontPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize CV fonts: %v", err)
+	}
+	certificateReminderService, err := services.NewCertificateReminderService(repositories.NewDeveloperRepository(db), lockRepo, mailer, cfg.CertificateReminderDays)
+	if err != nil {
+		log.Fatalf("Failed to initialize certificate reminders: %v", err)
+	}
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
@@ -49,6 +92,27 @@ func main() {
 	jobHandler := handlers.NewJobHandler()
 	techHandler := handlers.NewTechHandler()
 	adminHandler := handlers.NewAdminHandler()
+	verificationHandler := handlers.NewVerificationHandler(verificationService)
+	mediaHandler := handlers.NewMediaHandler(mediaService)
+	feedHandler := handlers.NewFeedHandler()
+	salaryHandler := handlers.NewSalaryHandler(salaryService)
+	interviewHandler := handlers.NewInterviewHandler()
+	claimHandler := handlers.NewClaimHandler(claimService)
+	contactHandler := handlers.NewContactHandler()
+	resumeHandler := handlers.NewResumeHandler(resumeService)
+	jsonResumeHandler := handlers.NewJSONResumeHandler(jsonResumeService)
+	cvHandler := handlers.NewCVHandler(cvService)
+	projectHandler := handlers.NewDeveloperProjectHandler(mediaService)
+	jobSearchHandler := handlers.NewJobSearchHandler(jobSearchService)
+	endorsementHandler := handlers.NewEndorsementHandler(endorsementService)
+
+	// Background jobs and the server stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Start background jobs
+	go jobSearchService.Run(ctx)
+	go certificateReminderService.Run(ctx)
 
 	// Setup Gin router
 	// Use gin.New() for custom middleware control
@@ -63,6 +127,11 @@ func main() {
 	// API routes
 	api := router.Group("/api/v1")
 
+	// Serve uploaded images when they are stored on the local filesystem; private documents are never served here
+	if local, ok := fileStorage.(*storage.LocalStorage); ok {
+		router.Static(storage.LocalURLPrefix, local.Root())
+	}
+
 	// Health check
 	api.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -86,38 +155,116 @@ func main() {
 
 	// Company routes (public)
 	api.GET("/companies", companyHandler.ListCompanies)
+	api.GET("/companies/compare", companyHandler.CompareCompanies)
 	api.GET("/companies/:id", companyHandler.GetCompany)
 	api.GET("/companies/:id/reviews", companyHandler.ListReviews)
+	api.GET("/companies/:id/salaries", salaryHandler.GetCompanySalaries)
+	api.GET("/companies/:id/interviews", interviewHandler.ListInterviews)
+	api.GET("/companies/:id/offices", companyHandler.ListOffices)
 
 	// Protected company routes
 	companyRoutes := api.Group("/companies")
 	companyRoutes.Use(middleware.AuthMiddleware())
 	{
 		companyRoutes.POST("", companyHandler.CreateCompany)
+		companyRoutes.PUT("/me", companyHandler.UpdateMyCompany)
+		companyRoutes.GET("/me/verification", verificationHandler.GetVerification)
+		companyRoutes.POST("/me/verification", verificationHandler.StartVerification)
+		companyRoutes.POST("/me/verification/check", verificationHandler.CheckVerification)
+		companyRoutes.POST("/me/logo", mediaHandler.UploadCompanyLogo)
+		companyRoutes.DELETE("/me/logo", mediaHandler.DeleteCompanyLogo)
+		companyRoutes.POST("/me/cover", mediaHandler.UploadCompanyCover)
+		companyRoutes.DELETE("/me/cover", mediaHandler.DeleteCompanyCover)
+		companyRoutes.PUT("/me/reviews/:id/response", companyHandler.RespondToReview)
+		companyRoutes.GET("/me/contact-requests", contactHandler.ListCompanyContactRequests)
+		companyRoutes.GET("/me/jobs", jobHandler.ListMyJobs)
+		companyRoutes.POST("/me/offices", companyHandler.CreateOffice)
+		companyRoutes.PUT("/me/offices/:id", companyHandler.UpdateOffice)
+		companyRoutes.DELETE("/me/offices/:id", companyHandler.DeleteOffice)
+		companyRoutes.POST("/:id/follow", feedHandler.FollowCompany)
+		companyRoutes.DELETE("/:id/follow", feedHandler.UnfollowCompany)
 		companyRoutes.POST("/:id/ratings", companyHandler.RateCompany)
 		companyRoutes.POST("/:id/reviews", companyHandler.CreateReview)
+		companyRoutes.POST("/:id/salaries", salaryHandler.SubmitSalary)
+		companyRoutes.POST("/:id/interviews", interviewHandler.CreateInterview)
+		companyRoutes.POST("/:id/claims", claimHandler.SubmitClaim)
+	}
+
+	// Current user routes (protected)
+	meRoutes := api.Group("/me")
+	meRoutes.Use(middleware.AuthMiddleware())
+	{
+		meRoutes.GET("/reviews", companyHandler.ListMyReviews)
+		meRoutes.PUT("/reviews/:id", companyHandler.UpdateMyReview)
+		meRoutes.GET("/interviews", interviewHandler.ListMyInterviews)
+		meRoutes.PUT("/interviews/:id", interviewHandler.UpdateMyInterview)
+		meRoutes.GET("/claims", claimHandler.ListMyClaims)
+		meRoutes.POST("/claims/:id/verify-email", claimHandler.VerifyClaimEmail)
+		meRoutes.POST("/claims/:id/document", claimHandler.UploadClaimDocument)
+		meRoutes.GET("/following", feedHandler.ListFollowing)
+		meRoutes.GET("/feed", feedHandler.GetFeed)
 	}
 
-	// Developer routes (public)
-	api.GET("/developers", developerHandler.ListDevelopers)
-	api.GET("/developers/:id", developerHandler.GetDeveloper)
+	// Developer routes (public, the caller is identified when signed in for privacy settings)
+	api.GET("/developers", middleware.OptionalAuthMiddleware(), developerHandler.ListDevelopers)
+	api.GET("/developers/:id", middleware.OptionalAuthMiddleware(), developerHandler.GetDeveloper)
+	api.GET("/developers/:id/cv.pdf", middleware.OptionalAuthMiddleware(), cvHandler.GetDeveloperCV)
 
 	// Protected developer routes
 	devRoutes := api.Group("/developers")
 	devRoutes.Use(middleware.AuthMiddleware())
 	{
 		devRoutes.POST("", developerHandler.CreateDeveloper)
+		devRoutes.GET("/me", developerHandler.GetMyDeveloper)
+		devRoutes.PATCH("/me", developerHandler.UpdateMyDeveloper)
+		devRoutes.PUT("/me/job-search", jobSearchHandler.UpdateMyJobSearch)
+		devRoutes.POST("/me/job-search/refresh", jobSearchHandler.RefreshMyJobSearch)
+		devRoutes.POST("/me/avatar", mediaHandler.UploadDeveloperAvatar)
+		devRoutes.DELETE("/me/avatar", mediaHandler.DeleteDeveloperAvatar)
+		devRoutes.PUT("/me/skills", developerHandler.ReplaceMySkills)
+		devRoutes.PUT("/me/languages", developerHandler.ReplaceMyLanguages)
+		devRoutes.POST("/me/experiences", developerHandler.CreateExperience)
+		devRoutes.PUT("/me/experiences/:id", developerHandler.UpdateExperience)
+		devRoutes.DELETE("/me/experiences/:id", developerHandler.DeleteExperience)
+		devRoutes.POST("/me/educations", developerHandler.CreateEducation)
+		devRoutes.PUT("/me/educations/:id", developerHandler.UpdateEducation)
+		devRoutes.DELETE("/me/educations/:id", developerHandler.DeleteEducation)
+		devRoutes.POST("/me/certificates", developerHandler.CreateCertificate)
+		devRoutes.PUT("/me/certificates/:id", developerHandler.UpdateCertificate)
+		devRoutes.DELETE("/me/certificates/:id", developerHandler.DeleteCertificate)
+		devRoutes.POST("/me/projects", projectHandler.CreateProject)
+		devRoutes.PUT("/me/projects/order", projectHandler.ReorderProjects)
+		devRoutes.PUT("/me/projects/:id", projectHandler.UpdateProject)
+		devRoutes.DELETE("/me/projects/:id", projectHandler.DeleteProject)
+		devRoutes.POST("/me/projects/:id/images", projectHandler.UploadProjectImage)
+		devRoutes.DELETE("/me/projects/:id/images/:image_id", projectHandler.DeleteProjectImage)
+		devRoutes.POST("/me/resume", resumeHandler.UploadMyResume)
+		devRoutes.GET("/me/resume", resumeHandler.GetMyResume)
+		devRoutes.GET("/me/resume/file", resumeHandler.DownloadMyResume)
+		devRoutes.DELETE("/me/resume", resumeHandler.DeleteMyResume)
+		devRoutes.POST("/me/resume/confirm", resumeHandler.ConfirmResumeDraft)
+		devRoutes.GET("/:id/resume", resumeHandler.DownloadDeveloperResume)
+		devRoutes.GET("/me/export", jsonResumeHandler.ExportMyProfile)
+		devRoutes.POST("/me/import", jsonResumeHandler.ImportMyProfile)
+		devRoutes.GET("/me/contact-requests", contactHandler.ListMyContactRequests)
+		devRoutes.POST("/me/contact-requests/:id/accept", contactHandler.AcceptContactRequest)
+		devRoutes.POST("/me/contact-requests/:id/decline", contactHandler.DeclineContactRequest)
+		devRoutes.POST("/:id/contact-requests", middleware.RoleMiddleware("company"), contactHandler.RequestContact)
+		devRoutes.POST("/:id/skills/:technology_id/endorsements", endorsementHandler.EndorseSkill)
+		devRoutes.DELETE("/:id/skills/:technology_id/endorsements", endorsementHandler.WithdrawEndorsement)
 	}
 
 	// Job routes (public)
 	api.GET("/jobs", jobHandler.ListJobs)
-	api.GET("/jobs/:id", jobHandler.GetJob)
+	api.GET("/jobs/:id", middleware.OptionalAuthMiddleware(), jobHandler.GetJob)
 
 	// Protected job routes
 	jobRoutes := api.Group("/jobs")
 	jobRoutes.Use(middleware.AuthMiddleware())
 	{
 		jobRoutes.POST("", jobHandler.CreateJob)
+		jobRoutes.PATCH("/:id", jobHandler.UpdateJob)
+		jobRoutes.DELETE("/:id", jobHandler.DeleteJob)
 		jobRoutes.POST("/:id/reactions", jobHandler.ReactToJob)
 		jobRoutes.POST("/:id/comments", jobHandler.CommentOnJob)
 	}
@@ -134,10 +281,39 @@ func main() {
 		adminRoutes.GET("/users", adminHandler.ListUsers)
 		adminRoutes.DELETE("/users/:id", adminHandler.DeleteUser)
 
+		// Admin - Company Pages and Claims
+		adminRoutes.POST("/companies", adminHandler.CreateCompany)
+		adminRoutes.PUT("/companies/:id/owner", claimHandler.TransferCompanyOwnership)
+		adminRoutes.GET("/companies/:id/ownership", claimHandler.ListOwnershipTransfers)
+		adminRoutes.GET("/claims", claimHandler.ListClaims)
+		adminRoutes.GET("/claims/:id/document", claimHandler.GetClaimDocument)
+		adminRoutes.PUT("/claims/:id/approve", claimHandler.ApproveClaim)
+		adminRoutes.PUT("/claims/:id/reject", claimHandler.RejectClaim)
+
 		// Admin - Review Management
 		adminRoutes.GET("/reviews/pending", adminHandler.ListPendingReviews)
 		adminRoutes.PUT("/reviews/:id/approve", adminHandler.ApproveReview)
-		adminRoutes.DELETE("/reviews/:id/reject", adminHandler.RejectReview)
+		adminRoutes.PUT("/reviews/:id/reject", adminHandler.RejectReview)
+		adminRoutes.DELETE("/reviews/:id/reject", adminHandler.RejectReviewLegacy)
+		adminRoutes.PUT("/reviews/:id/request-changes", adminHandler.RequestReviewChanges)
+		adminRoutes.GET("/reviews/:id/moderation", adminHandler.ListReviewModeration)
+
+		// Admin - Salary Moderation
+		adminRoutes.GET("/salaries/flagged", adminHandler.ListFlaggedSalaries)
+		adminRoutes.PUT("/salaries/:id/approve", adminHandler.ApproveSalary)
+		adminRoutes.PUT("/salaries/:id/reject", adminHandler.RejectSalary)
+
+		// Admin - Interview Moderation
+		adminRoutes.GET("/interviews/pending", adminHandler.ListPendingInterviews)
+		adminRoutes.PUT("/interviews/:id/approve", adminHandler.ApproveInterview)
+		adminRoutes.PUT("/interviews/:id/reject", adminHandler.RejectInterview)
+		adminRoutes.PUT("/interviews/:id/request-changes", adminHandler.RequestInterviewChanges)
+		adminRoutes.GET("/interviews/:id/moderation", adminHandler.ListInterviewModeration)
+
+		// Admin - Endorsement Moderation
+		adminRoutes.GET("/endorsements/flagged", adminHandler.ListFlaggedEndorsements)
+		adminRoutes.PUT("/endorsements/:id/approve", adminHandler.ApproveEndorsement)
+		adminRoutes.PUT("/endorsements/:id/reject", adminHandler.RejectEndorsement)
 
 		// Admin - Comment Management
 		adminRoutes.PUT("/comments/:id/approve", adminHandler.ApproveComment)
@@ -153,7 +329,18 @@ func main() {
 	log.Printf("📚 API Documentation: http://%s/api/v1/health", addr)
 	log.Printf("🔧 Environment: %s", cfg.Environment)
 	
-	if err := router.Run(addr); err != nil {
+	server := &http.Server{Addr: addr, Handler: router}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown failed: %v", err)
+		}
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("Server failed to start: %v", err)
 	}
+	log.Println("Server stopped")
 }
@@ -0,0 +1,99 @@
+// Package document extracts plain text from uploaded PDF and DOCX files using only the standard library.
+// Extraction is best effort: layout is reduced to lines of text, which is enough for keyword and
+// section detection but not for faithful rendering.
+package document
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// Supported document formats
+const (
+	FormatPDF  = "pdf"
+	FormatDOCX = "docx"
+)
+
+// Content types of the supported formats
+var ContentTypes = map[string]string{
+	FormatPDF:  "application/pdf",
+	FormatDOCX: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+}
+
+// maxDecodedSize bounds the decompressed data read from one document to protect against zip bombs
+const maxDecodedSize = 50 << 20
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported document type, use PDF or DOCX")
+	ErrEncrypted         = errors.New("document is encrypted")
+	ErrNoText            = errors.New("no text could be extracted from the document")
+	ErrMalformed         = errors.New("document is damaged or malformed")
+
+	errTooLarge = errors.New("document is too large to read")
+)
+
+// Detect returns the format of a document from its content, or "" when it is not supported
+func Detect(data []byte) string {
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	switch {
+	case bytes.Contains(head, []byte("%PDF-")):
+		return FormatPDF
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) && isDOCX(data):
+		return FormatDOCX
+	}
+	return ""
+}
+
+// ExtractText detects the document format and returns it with the document's text
+func ExtractText(data []byte) (string, string, error) {
+	format := Detect(data)
+
+	var text string
+	var err error
+	switch format {
+	case FormatPDF:
+		text, err = ExtractPDF(data)
+	case FormatDOCX:
+		text, err = ExtractDOCX(data)
+	default:
+		return "", "", ErrUnsupportedFormat
+	}
+	if err != nil {
+		return format, "", err
+	}
+
+	text = normalizeText(text)
+	if strings.TrimSpace(text) == "" {
+		return format, "", ErrNoText
+	}
+	return format, text, nil
+}
+
+// normalizeText trims every line, collapses runs of spaces and drops repeated blank lines
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var out strings.Builder
+	blank := true
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.FieldsFunc(line, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ' '
+		}), " ")
+		if line == "" {
+			if !blank {
+				out.WriteByte('\n')
+			}
+			blank = true
+			continue
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+		blank = false
+	}
+	return strings.TrimSpace(out.String())
+}
@@ -0,0 +1,92 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const docxBody = "word/document.xml"
+
+func isDOCX(data []byte) bool {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, file := range reader.File {
+		if file.Name == docxBody {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractDOCX returns the text of a Word document, one paragraph per line.
+// Table cells are separated by tabs.
+func ExtractDOCX(data []byte) (string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", ErrUnsupportedFormat
+	}
+
+	var body *zip.File
+	for _, file := range reader.File {
+		if file.Name == docxBody {
+			body = file
+			break
+		}
+	}
+	if body == nil {
+		return "", ErrUnsupportedFormat
+	}
+	if body.UncompressedSize64 > maxDecodedSize {
+		return "", errTooLarge
+	}
+
+	rc, err := body.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(rc, maxDecodedSize))
+	var text strings.Builder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteByte('\t')
+			case "br", "cr":
+				text.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteByte('\n')
+			case "tc":
+				text.WriteByte('\t')
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(t)
+			}
+		}
+	}
+	return text.String(), nil
+}
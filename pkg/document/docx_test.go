@@ -0,0 +1,115 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+const wordNamespace = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`
+
+// buildZip writes files into a zip archive in the order given
+func buildZip(t *testing.T, files ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i+1 < len(files); i += 2 {
+		w, err := zw.Create(files[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(files[i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildDOCX(t *testing.T, body string) []byte {
+	t.Helper()
+	return buildZip(t,
+		"[Content_Types].xml", `<?xml version="1.0"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"/>`,
+		docxBody, `<?xml version="1.0" encoding="UTF-8"?><w:document `+wordNamespace+`><w:body>`+body+`</w:body></w:document>`,
+	)
+}
+
+func TestExtractDOCX(t *testing.T) {
+	data := buildDOCX(t, `<w:p><w:r><w:t>Jane</w:t></w:r><w:r><w:t xml:space="preserve"> Doe</w:t></w:r></w:p>`+
+		`<w:p><w:r><w:t>Go</w:t><w:tab/><w:t>PostgreSQL</w:t><w:br/><w:t>ঢাকা, বাংলাদেশ</w:t></w:r></w:p>`+
+		`<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:instrText>PAGE</w:instrText></w:r></w:p>`+
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>2020 &amp; 2021</w:t></w:r></w:p></w:tc>`+
+		`<w:tc><w:p><w:r><w:t>Acme</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`)
+
+	if format := Detect(data); format != FormatDOCX {
+		t.Fatalf("Detect() = %q, want %q", format, FormatDOCX)
+	}
+	text, err := ExtractDOCX(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Jane Doe\nGo\tPostgreSQL\nঢাকা, বাংলাদেশ\n\n2020 & 2021\n\tAcme\n\t"; text != want {
+		t.Errorf("ExtractDOCX() = %q, want %q", text, want)
+	}
+
+	format, text, err := ExtractText(data)
+	if err != nil || format != FormatDOCX {
+		t.Fatalf("ExtractText() = %q, %v", format, err)
+	}
+	if want := "Jane Doe\nGo PostgreSQL\nঢাকা, বাংলাদেশ\n\n2020 & 2021\nAcme"; text != want {
+		t.Errorf("ExtractText() = %q, want %q", text, want)
+	}
+}
+
+func TestExtractDOCXRejects(t *testing.T) {
+	notWord := buildZip(t, "content.xml", "<office:document/>")
+	if format := Detect(notWord); format != "" {
+		t.Errorf("Detect() of a zip without a Word body = %q", format)
+	}
+	if _, err := ExtractDOCX(notWord); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("ExtractDOCX() of a zip without a Word body = %v, want %v", err, ErrUnsupportedFormat)
+	}
+
+	if _, _, err := ExtractText(buildDOCX(t, `<w:p><w:r><w:t>  </w:t></w:r></w:p>`)); !errors.Is(err, ErrNoText) {
+		t.Errorf("ExtractText() of a blank document = %v, want %v", err, ErrNoText)
+	}
+
+	broken := buildZip(t, docxBody, `<w:document `+wordNamespace+`><w:body><w:p><w:t>unclosed`)
+	if _, err := ExtractDOCX(broken); err == nil {
+		t.Error("ExtractDOCX() of malformed XML succeeded")
+	}
+}
+
+func TestExtractDOCXTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(docxBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk := make([]byte, 1<<20)
+	for written := 0; written <= maxDecodedSize; written += len(chunk) {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ExtractDOCX(buf.Bytes()); !errors.Is(err, errTooLarge) {
+		t.Errorf("ExtractDOCX() of a zip bomb = %v, want %v", err, errTooLarge)
+	}
+}
+
+func TestExtractDOCXTruncated(t *testing.T) {
+	data := buildDOCX(t, `<w:p><w:r><w:t>Jane Doe</w:t></w:r></w:p>`)
+	for n := 0; n < len(data); n++ {
+		if _, _, err := ExtractText(data[:n]); err == nil {
+			t.Fatalf("ExtractText() of the first %d bytes succeeded", n)
+		}
+	}
+}
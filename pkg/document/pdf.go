@@ -0,0 +1,430 @@
+package document
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"encoding/ascii85"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	pdfObjectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	pdfRootRef      = regexp.MustCompile(`/Root\s+(\d+)\s+\d+\s+R`)
+	pdfEncryptRef   = regexp.MustCompile(`/Encrypt\s*(\d+\s+\d+\s+R|<<)`)
+)
+
+// pdfDocument holds the objects of a PDF. Objects are found by scanning for "n g obj" headers instead of
+// trusting the cross-reference table, which is often broken in files exported by online CV builders.
+type pdfDocument struct {
+	objects map[int]interface{}
+	budget  int64
+	fonts   map[pdfRef]*pdfFont
+}
+
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// ExtractPDF returns the text of a PDF document, page by page.
+// Scanned documents without a text layer produce no text.
+func ExtractPDF(data []byte) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", ErrMalformed
+		}
+	}()
+
+	if pdfEncryptRef.Match(data) {
+		return "", ErrEncrypted
+	}
+
+	doc := parsePDF(data)
+	if len(doc.objects) == 0 {
+		return "", ErrMalformed
+	}
+
+	var out strings.Builder
+	for _, page := range doc.pages(data) {
+		doc.pageText(&out, page)
+		out.WriteString("\n\n")
+	}
+	return out.String(), nil
+}
+
+func parsePDF(data []byte) *pdfDocument {
+	doc := &pdfDocument{
+		objects: map[int]interface{}{},
+		budget:  maxDecodedSize,
+		fonts:   map[pdfRef]*pdfFont{},
+	}
+
+	// Later definitions win, which applies incremental updates in file order
+	skipUntil := 0
+	for _, m := range pdfObjectHeader.FindAllSubmatchIndex(data, -1) {
+		if m[0] < skipUntil || (m[0] > 0 && !isPDFSpace(data[m[0]-1]) && !isPDFDelim(data[m[0]-1])) {
+			continue
+		}
+		num, err := strconv.Atoi(string(data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+
+		l := &pdfLexer{data: data, pos: m[1]}
+		obj := l.value(0)
+		if dict, ok := obj.(pdfDict); ok {
+			if stream, end := readStream(data, l.pos, dict); stream != nil {
+				obj = stream
+				skipUntil = end
+			}
+		}
+		doc.objects[num] = obj
+	}
+
+	doc.expandObjectStreams()
+	return doc
+}
+
+// readStream reads the stream data following a dictionary, returning the stream and where its data ends
+func readStream(data []byte, pos int, dict pdfDict) (*pdfStream, int) {
+	l := &pdfLexer{data: data, pos: pos}
+	l.skipSpace()
+	if !bytes.HasPrefix(data[l.pos:], []byte("stream")) {
+		return nil, 0
+	}
+
+	start := l.pos + len("stream")
+	if start < len(data) && data[start] == '\r' {
+		start++
+	}
+	if start < len(data) && data[start] == '\n' {
+		start++
+	}
+
+	if length, ok := dict["Length"].(float64); ok && length >= 0 && start+int(length) <= len(data) {
+		end := start + int(length)
+		tail := &pdfLexer{data: data, pos: end}
+		tail.skipSpace()
+		if bytes.HasPrefix(data[tail.pos:], []byte("endstream")) {
+			return &pdfStream{dict: dict, raw: data[start:end]}, tail.pos
+		}
+	}
+
+	// The length is indirect or wrong: fall back to searching for the end marker
+	end := bytes.Index(data[start:], []byte("endstream"))
+	if end < 0 {
+		return &pdfStream{dict: dict, raw: data[start:]}, len(data)
+	}
+	raw := data[start : start+end]
+	raw = bytes.TrimSuffix(raw, []byte("\n"))
+	raw = bytes.TrimSuffix(raw, []byte("\r"))
+	return &pdfStream{dict: dict, raw: raw}, start + end
+}
+
+func (d *pdfDocument) sortedNumbers() []int {
+	nums := make([]int, 0, len(d.objects))
+	for num := range d.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// expandObjectStreams adds the objects stored inside compressed object streams
+func (d *pdfDocument) expandObjectStreams() {
+	for _, num := range d.sortedNumbers() {
+		stream, ok := d.objects[num].(*pdfStream)
+		if !ok || d.name(stream.dict["Type"]) != "ObjStm" {
+			continue
+		}
+		data, err := d.decode(stream)
+		if err != nil {
+			continue
+		}
+
+		count, _ := d.number(stream.dict["N"])
+		first, _ := d.number(stream.dict["First"])
+		if first < 0 || int(first) > len(data) {
+			continue
+		}
+
+		header := &pdfLexer{data: data[:int(first)]}
+		for i := 0; i < int(count); i++ {
+			objNum, ok1 := header.token().(float64)
+			offset, ok2 := header.token().(float64)
+			if !ok1 || !ok2 {
+				break
+			}
+			if _, exists := d.objects[int(objNum)]; exists {
+				continue
+			}
+			pos := int(first) + int(offset)
+			if pos < 0 || pos >= len(data) {
+				continue
+			}
+			l := &pdfLexer{data: data, pos: pos}
+			d.objects[int(objNum)] = l.value(0)
+		}
+	}
+}
+
+func (d *pdfDocument) resolve(v interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = d.objects[ref.num]
+	}
+	return nil
+}
+
+func (d *pdfDocument) dict(v interface{}) pdfDict {
+	switch t := d.resolve(v).(type) {
+	case pdfDict:
+		return t
+	case *pdfStream:
+		return t.dict
+	}
+	return nil
+}
+
+func (d *pdfDocument) array(v interface{}) []interface{} {
+	array, _ := d.resolve(v).([]interface{})
+	return array
+}
+
+func (d *pdfDocument) number(v interface{}) (float64, bool) {
+	n, ok := d.resolve(v).(float64)
+	return n, ok
+}
+
+func (d *pdfDocument) name(v interface{}) pdfName {
+	name, _ := d.resolve(v).(pdfName)
+	return name
+}
+
+// decode applies the stream's filters. Image-only filters are not supported since only text is needed.
+func (d *pdfDocument) decode(stream *pdfStream) ([]byte, error) {
+	var filters, params []interface{}
+	switch f := d.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []interface{}{f}
+		params = []interface{}{stream.dict["DecodeParms"]}
+	case []interface{}:
+		filters = f
+		params = d.array(stream.dict["DecodeParms"])
+	}
+
+	data := stream.raw
+	for i, filter := range filters {
+		var parms pdfDict
+		if i < len(params) {
+			parms = d.dict(params[i])
+		}
+
+		var err error
+		switch d.name(filter) {
+		case "FlateDecode", "Fl":
+			if data, err = d.inflate(data); err == nil {
+				data = unpredict(data, parms)
+			}
+		case "ASCIIHexDecode", "AHx":
+			data = (&pdfLexer{data: append([]byte{'<'}, data...)}).hexString()
+		case "ASCII85Decode", "A85":
+			data, err = decodeASCII85(data)
+		default:
+			return nil, fmt.Errorf("unsupported stream filter %v", filter)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func (d *pdfDocument) inflate(data []byte) ([]byte, error) {
+	var r io.Reader
+	if zr, err := zlib.NewReader(bytes.NewReader(data)); err == nil {
+		r = zr
+	} else {
+		r = flate.NewReader(bytes.NewReader(data))
+	}
+
+	out, err := io.ReadAll(io.LimitReader(r, d.budget+1))
+	if int64(len(out)) > d.budget {
+		return nil, errTooLarge
+	}
+	d.budget -= int64(len(out))
+	// Truncated or badly checksummed streams are common; keep whatever was decoded
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+func decodeASCII85(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	data = bytes.TrimPrefix(data, []byte("<~"))
+	if end := bytes.Index(data, []byte("~>")); end >= 0 {
+		data = data[:end]
+	}
+	return io.ReadAll(ascii85.NewDecoder(bytes.NewReader(data)))
+}
+
+// unpredict reverses the PNG predictors used by cross-reference and object streams
+func unpredict(data []byte, parms pdfDict) []byte {
+	predictor, _ := parms["Predictor"].(float64)
+	if predictor < 10 {
+		return data
+	}
+
+	colors, bits, columns := 1, 8, 1
+	if v, ok := parms["Colors"].(float64); ok && v > 0 {
+		colors = int(v)
+	}
+	if v, ok := parms["BitsPerComponent"].(float64); ok && v > 0 {
+		bits = int(v)
+	}
+	if v, ok := parms["Columns"].(float64); ok && v > 0 {
+		columns = int(v)
+	}
+	bpp := colors * bits / 8
+	if bpp < 1 {
+		bpp = 1
+	}
+	rowLen := (columns*colors*bits + 7) / 8
+
+	var out []byte
+	prev := make([]byte, rowLen)
+	for pos := 0; pos+1+rowLen <= len(data); pos += rowLen + 1 {
+		filter := data[pos]
+		row := append([]byte(nil), data[pos+1:pos+1+rowLen]...)
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch filter {
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// pages returns the document's pages in reading order, with inherited resources resolved
+func (d *pdfDocument) pages(data []byte) []pdfPage {
+	var root pdfDict
+	if matches := pdfRootRef.FindAllSubmatch(data, -1); len(matches) > 0 {
+		if num, err := strconv.Atoi(string(matches[len(matches)-1][1])); err == nil {
+			root = d.dict(pdfRef{num: num})
+		}
+	}
+	if root == nil {
+		for _, num := range d.sortedNumbers() {
+			if dict := d.dict(pdfRef{num: num}); d.name(dict["Type"]) == "Catalog" {
+				root = dict
+				break
+			}
+		}
+	}
+
+	var pages []pdfPage
+	if root != nil {
+		d.collectPages(root["Pages"], nil, map[int]bool{}, &pages, 0)
+	}
+	if len(pages) == 0 {
+		for _, num := range d.sortedNumbers() {
+			if dict := d.dict(pdfRef{num: num}); d.name(dict["Type"]) == "Page" {
+				pages = append(pages, pdfPage{dict: dict, resources: d.dict(dict["Resources"])})
+			}
+		}
+	}
+	return pages
+}
+
+func (d *pdfDocument) collectPages(node interface{}, inherited pdfDict, seen map[int]bool, pages *[]pdfPage, depth int) {
+	if ref, ok := node.(pdfRef); ok {
+		if seen[ref.num] {
+			return
+		}
+		seen[ref.num] = true
+	}
+	dict := d.dict(node)
+	if dict == nil || depth > maxNesting {
+		return
+	}
+
+	resources := inherited
+	if own := d.dict(dict["Resources"]); own != nil {
+		resources = own
+	}
+
+	if kids := d.array(dict["Kids"]); kids != nil {
+		for _, kid := range kids {
+			d.collectPages(kid, resources, seen, pages, depth+1)
+		}
+		return
+	}
+	*pages = append(*pages, pdfPage{dict: dict, resources: resources})
+}
+
+func (d *pdfDocument) pageText(out *strings.Builder, page pdfPage) {
+	contents := d.resolve(page.dict["Contents"])
+	parts, ok := contents.([]interface{})
+	if !ok {
+		parts = []interface{}{contents}
+	}
+
+	var content []byte
+	for _, part := range parts {
+		stream, ok := d.resolve(part).(*pdfStream)
+		if !ok {
+			continue
+		}
+		data, err := d.decode(stream)
+		if err != nil {
+			continue
+		}
+		content = append(content, data...)
+		content = append(content, '\n')
+	}
+
+	d.runContent(content, page.resources, &textWriter{out: out}, 0)
+}
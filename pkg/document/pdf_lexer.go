@@ -0,0 +1,287 @@
+package document
+
+import (
+	"math"
+	"strconv"
+)
+
+// PDF objects are represented as float64, bool, nil, []byte (strings), pdfName, pdfKeyword,
+// []interface{} (arrays), pdfDict, pdfRef and *pdfStream
+type (
+	pdfName    string
+	pdfKeyword string
+	pdfDict    map[pdfName]interface{}
+	pdfRef     struct{ num, gen int }
+)
+
+type pdfStream struct {
+	dict pdfDict
+	raw  []byte
+}
+
+// maxNesting bounds array and dictionary nesting so hostile input cannot exhaust the stack
+const maxNesting = 64
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFSpace(b byte) bool {
+	switch b {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isPDFDelim(b byte) bool {
+	switch b {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func hexValue(b byte) (byte, bool) {
+	switch {
+	case b >= '0' && b <= '9':
+		return b - '0', true
+	case b >= 'a' && b <= 'f':
+		return b - 'a' + 10, true
+	case b >= 'A' && b <= 'F':
+		return b - 'A' + 10, true
+	}
+	return 0, false
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		if b == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if !isPDFSpace(b) {
+			return
+		}
+		l.pos++
+	}
+}
+
+// token returns the next token, or nil at the end of the data
+func (l *pdfLexer) token() interface{} {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil
+	}
+
+	switch b := l.data[l.pos]; b {
+	case '(':
+		return l.literalString()
+	case '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return pdfKeyword("<<")
+		}
+		return l.hexString()
+	case '>':
+		l.pos++
+		if l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+			return pdfKeyword(">>")
+		}
+		return pdfKeyword(">")
+	case '[', ']', '{', '}', ')':
+		l.pos++
+		return pdfKeyword(string(b))
+	case '/':
+		return l.name()
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if c := word[0]; c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9') {
+		if n, err := strconv.ParseFloat(word, 64); err == nil {
+			return n
+		}
+	}
+	return pdfKeyword(word)
+}
+
+func (l *pdfLexer) literalString() []byte {
+	l.pos++
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		l.pos++
+		switch b {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				b = '\n'
+			case 'r':
+				b = '\r'
+			case 't':
+				b = '\t'
+			case 'b':
+				b = '\b'
+			case 'f':
+				b = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e < '0' || e > '7' {
+					b = e
+					break
+				}
+				v := int(e - '0')
+				for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+					v = v*8 + int(l.data[l.pos]-'0')
+					l.pos++
+				}
+				b = byte(v)
+			}
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+func (l *pdfLexer) hexString() []byte {
+	l.pos++
+	var out []byte
+	var high byte
+	half := false
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		l.pos++
+		if b == '>' {
+			break
+		}
+		v, ok := hexValue(b)
+		if !ok {
+			continue
+		}
+		if half {
+			out = append(out, high<<4|v)
+		} else {
+			high = v
+		}
+		half = !half
+	}
+	if half {
+		out = append(out, high<<4)
+	}
+	return out
+}
+
+func (l *pdfLexer) name() pdfName {
+	l.pos++
+	var out []byte
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		b := l.data[l.pos]
+		if b == '#' && l.pos+2 < len(l.data) {
+			high, ok1 := hexValue(l.data[l.pos+1])
+			low, ok2 := hexValue(l.data[l.pos+2])
+			if ok1 && ok2 {
+				out = append(out, high<<4|low)
+				l.pos += 3
+				continue
+			}
+		}
+		out = append(out, b)
+		l.pos++
+	}
+	return pdfName(out)
+}
+
+// value parses the next complete object. Keywords other than the structural ones are returned as is,
+// so content streams and CMaps can be read with the same parser.
+func (l *pdfLexer) value(depth int) interface{} {
+	switch t := l.token().(type) {
+	case pdfKeyword:
+		if depth > maxNesting {
+			return nil
+		}
+		switch t {
+		case "[":
+			array := []interface{}{}
+			for {
+				v := l.value(depth + 1)
+				if v == nil && l.pos >= len(l.data) {
+					return array
+				}
+				if kw, ok := v.(pdfKeyword); ok && kw == "]" {
+					return array
+				}
+				array = append(array, v)
+			}
+		case "<<":
+			dict := pdfDict{}
+			for {
+				k := l.value(depth + 1)
+				if k == nil && l.pos >= len(l.data) {
+					return dict
+				}
+				if kw, ok := k.(pdfKeyword); ok && kw == ">>" {
+					return dict
+				}
+				key, ok := k.(pdfName)
+				if !ok {
+					continue
+				}
+				v := l.value(depth + 1)
+				if kw, ok := v.(pdfKeyword); ok && kw == ">>" {
+					return dict
+				}
+				dict[key] = v
+			}
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return t
+	case float64:
+		if t >= 0 && t == math.Trunc(t) {
+			save := l.pos
+			if gen, ok := l.token().(float64); ok && gen >= 0 && gen == math.Trunc(gen) {
+				if kw, ok := l.token().(pdfKeyword); ok && kw == "R" {
+					return pdfRef{num: int(t), gen: int(gen)}
+				}
+			}
+			l.pos = save
+		}
+		return t
+	case nil:
+		return nil
+	default:
+		return t
+	}
+}
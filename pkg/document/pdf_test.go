@@ -0,0 +1,253 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bishworup11/bdSeeker-backend/pkg/pdf"
+)
+
+// buildPDF numbers objects from 1 and ends the file with a trailer whose root is object 1. Empty objects
+// are left out, for objects stored in object streams. No cross-reference table is written since the
+// reader does not use it.
+func buildPDF(trailer string, objects ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		if obj == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Root 1 0 R %s>>\n%%%%EOF\n", trailer)
+	return buf.Bytes()
+}
+
+func stream(dict, data string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func deflated(t *testing.T, data string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// objectStream packs objects numbered 7 and up into a compressed object stream
+func objectStream(t *testing.T, objects ...string) string {
+	t.Helper()
+	var header, body strings.Builder
+	for i, obj := range objects {
+		fmt.Fprintf(&header, "%d %d ", 7+i, body.Len())
+		body.WriteString(obj + " ")
+	}
+	dict := fmt.Sprintf("/Type /ObjStm /N %d /First %d /Filter /FlateDecode", len(objects), header.Len())
+	return stream(dict, deflated(t, header.String()+body.String()))
+}
+
+// singlePage is a document with one page drawing content with font F1
+func singlePage(font, content string) []byte {
+	return buildPDF("",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		font,
+		content,
+	)
+}
+
+const helvetica = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>"
+
+func TestExtractPDF(t *testing.T) {
+	bangla := `<< /Type /Font /Subtype /Type0 /BaseFont /NotoSansBengali /Encoding /Identity-H /ToUnicode 6 0 R >>`
+	cmap := "/CIDInit /ProcSet findresource begin\nbegincmap\n1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n" +
+		"2 beginbfchar\n<0003> <0995>\n<0007> <09BF>\nendbfchar\n" +
+		"1 beginbfrange\n<0010> <0011> <0041>\nendbfrange\nendcmap\n"
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			"lines and words",
+			singlePage(helvetica, stream("", "BT /F1 12 Tf 72 720 Td (Jane Doe) Tj 0 -14 Td (Backend \\(Go\\)) Tj ET")),
+			"Jane Doe\nBackend (Go)",
+		},
+		{
+			"kerning and word gaps",
+			singlePage(helvetica, stream("", "BT /F1 10 Tf 72 720 Td [(Go)-20(lang) -300 (developer)] TJ ET")),
+			"Golang developer",
+		},
+		{
+			"compressed content",
+			singlePage(helvetica, stream("/Filter /FlateDecode", deflated(t, "BT /F1 12 Tf 72 720 Td (Compressed) Tj T* (next) ' ET"))),
+			"Compressed\nnext",
+		},
+		{
+			"WinAnsi and Differences",
+			singlePage("<< /Type /Font /Subtype /Type1 /Encoding << /Differences [65 /bullet /uni09A2] >> >>",
+				stream("", "BT /F1 12 Tf (A B \\226 caf\\351) Tj ET")),
+			"• ঢ – café",
+		},
+		{
+			"ToUnicode map of an embedded font",
+			buildPDF("",
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+				bangla,
+				stream("", "BT /F1 12 Tf 72 720 Td <00070003> Tj 0 -14 Td <00100011> Tj ET"),
+				stream("", cmap),
+			),
+			"িক\nAB",
+		},
+		{
+			"pages in page tree order",
+			buildPDF("",
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [4 0 R 3 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>",
+				"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+				"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+				helvetica,
+				stream("", "BT /F1 12 Tf (second) Tj ET"),
+				stream("", "BT /F1 12 Tf (first) Tj ET"),
+			),
+			"first\n\nsecond",
+		},
+		{
+			"objects in an object stream",
+			buildPDF("",
+				"<< /Type /Catalog /Pages 7 0 R >>",
+				"",
+				"",
+				helvetica,
+				stream("", "BT /F1 12 Tf (packed) Tj ET"),
+				objectStream(t, "<< /Type /Pages /Kids [8 0 R] /Count 1 >>",
+					"<< /Type /Page /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>"),
+			),
+			"packed",
+		},
+		{
+			"form XObject",
+			buildPDF("",
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Resources << /Font << /F1 4 0 R >> /XObject << /X1 6 0 R >> >> /Contents 5 0 R >>",
+				helvetica,
+				stream("", "q /X1 Do Q"),
+				stream("/Type /XObject /Subtype /Form", "BT /F1 12 Tf (in a form) Tj ET"),
+			),
+			"in a form",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, text, err := ExtractText(tt.data)
+			if err != nil || format != FormatPDF {
+				t.Fatalf("ExtractText() = %q, %v", format, err)
+			}
+			if text != tt.want {
+				t.Errorf("ExtractText() = %q, want %q", text, tt.want)
+			}
+		})
+	}
+}
+
+// The CV generator's output can be read back, so generated CVs can be attached as resumes
+func TestExtractPDFGenerated(t *testing.T) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	font, err := doc.AddStandardFont(pdf.Helvetica)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := pdf.Face{font}
+	page := doc.AddPage()
+	x := 50 + page.Text(50, 60, face, 20, pdf.Color{}, "Jane Doe")
+	page.Text(x+10, 60, face, 20, pdf.Color{}, "(Dhaka)")
+	page.Text(50, 90, face, 11, pdf.Color{}, "Senior Go developer – 5 years")
+	doc.AddPage().Text(50, 60, face, 11, pdf.Color{}, "References on request")
+	data, err := doc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, text, err := ExtractText(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Jane Doe (Dhaka)\nSenior Go developer – 5 years\n\nReferences on request"; text != want {
+		t.Errorf("ExtractText() = %q, want %q", text, want)
+	}
+}
+
+func TestExtractPDFRejects(t *testing.T) {
+	encrypted := buildPDF("/Encrypt 6 0 R",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		helvetica,
+		stream("", "BT /F1 12 Tf (secret) Tj ET"),
+		"<< /Filter /Standard /V 2 /R 3 >>",
+	)
+	scanned := singlePage(helvetica, stream("", "q 100 0 0 100 0 0 cm BI /W 1 /H 1 /BPC 8 /CS /G ID \x00 EI Q"))
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"encrypted", encrypted, ErrEncrypted},
+		{"no objects", []byte("%PDF-1.4\n%%EOF\n"), ErrMalformed},
+		{"no text layer", scanned, ErrNoText},
+		{"unsupported filter", singlePage(helvetica, stream("/Filter /DCTDecode", "BT /F1 12 Tf (x) Tj ET")), ErrNoText},
+		{"not a document", []byte("GIF89a"), ErrUnsupportedFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ExtractText(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("ExtractText() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// Damaged files never panic; whatever text survives is extracted
+func TestExtractPDFDamaged(t *testing.T) {
+	data := singlePage(helvetica, stream("/Filter /FlateDecode", deflated(t, "BT /F1 12 Tf 72 720 Td (Jane Doe) Tj ET")))
+	for n := 0; n < len(data); n++ {
+		_, text, err := ExtractText(data[:n])
+		if err == nil && !strings.HasPrefix("Jane Doe", text) {
+			t.Fatalf("ExtractText() of the first %d bytes = %q", n, text)
+		}
+	}
+
+	for i := range data {
+		damaged := bytes.Clone(data)
+		damaged[i] ^= 0xFF
+		ExtractText(damaged)
+	}
+
+	nested := singlePage(helvetica, stream("", "BT /F1 12 Tf "+strings.Repeat("[", 10000)+"(deep) Tj ET"))
+	ExtractText(nested)
+
+	loop := buildPDF("",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [2 0 R 3 0 R] /Count 1 >>",
+		"<< /Type /Page /Resources 3 0 R /Contents 3 0 R >>",
+	)
+	if _, _, err := ExtractText(loop); !errors.Is(err, ErrNoText) {
+		t.Errorf("ExtractText() of a looping page tree = %v, want %v", err, ErrNoText)
+	}
+}
@@ -0,0 +1,523 @@
+package document
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// pdfFont maps the character codes of shown strings to text and glyph widths
+type pdfFont struct {
+	twoByte      bool
+	unicodeCodes bool // a composite font whose codes are already UCS-2
+	toUnicode    map[uint32]string
+	differences  map[uint32]string
+	widths       map[uint32]float64
+	defaultWidth float64
+}
+
+func (d *pdfDocument) font(resources pdfDict, name pdfName) *pdfFont {
+	ref := d.dict(resources["Font"])[name]
+	if r, ok := ref.(pdfRef); ok {
+		if font, ok := d.fonts[r]; ok {
+			return font
+		}
+	}
+
+	font := &pdfFont{defaultWidth: 500}
+	if dict := d.dict(ref); dict != nil {
+		d.loadFont(font, dict)
+	}
+	if r, ok := ref.(pdfRef); ok {
+		d.fonts[r] = font
+	}
+	return font
+}
+
+func (d *pdfDocument) loadFont(font *pdfFont, dict pdfDict) {
+	if stream, ok := d.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := d.decode(stream); err == nil {
+			font.toUnicode = parseCMap(data)
+		}
+	}
+
+	if d.name(dict["Subtype"]) == "Type0" {
+		font.twoByte = true
+		font.defaultWidth = 1000
+		encoding := string(d.name(dict["Encoding"]))
+		font.unicodeCodes = strings.HasPrefix(encoding, "Uni") &&
+			(strings.Contains(encoding, "UCS2") || strings.Contains(encoding, "UTF16"))
+		if descendants := d.array(dict["DescendantFonts"]); len(descendants) > 0 {
+			cid := d.dict(descendants[0])
+			if width, ok := d.number(cid["DW"]); ok {
+				font.defaultWidth = width
+			}
+			font.widths = d.cidWidths(d.array(cid["W"]))
+		}
+		return
+	}
+
+	first, _ := d.number(dict["FirstChar"])
+	font.widths = map[uint32]float64{}
+	for i, w := range d.array(dict["Widths"]) {
+		if width, ok := d.number(w); ok {
+			font.widths[uint32(int(first)+i)] = width
+		}
+	}
+	if descriptor := d.dict(dict["FontDescriptor"]); descriptor != nil {
+		if width, ok := d.number(descriptor["MissingWidth"]); ok && width > 0 {
+			font.defaultWidth = width
+		}
+	}
+	if encoding := d.dict(dict["Encoding"]); encoding != nil {
+		font.differences = d.differences(d.array(encoding["Differences"]))
+	}
+}
+
+// cidWidths reads a composite font's W array: "c [w1 w2 ...]" or "cFirst cLast w" entries
+func (d *pdfDocument) cidWidths(w []interface{}) map[uint32]float64 {
+	widths := map[uint32]float64{}
+	for i := 0; i+1 < len(w); {
+		first, ok := d.number(w[i])
+		if !ok {
+			break
+		}
+		if list := d.array(w[i+1]); list != nil {
+			for j, v := range list {
+				if width, ok := d.number(v); ok {
+					widths[uint32(int(first)+j)] = width
+				}
+			}
+			i += 2
+			continue
+		}
+
+		last, ok := d.number(w[i+1])
+		if !ok || i+2 >= len(w) {
+			break
+		}
+		width, _ := d.number(w[i+2])
+		for code := first; code <= last && code-first < 0xFFFF; code++ {
+			widths[uint32(code)] = width
+		}
+		i += 3
+	}
+	return widths
+}
+
+func (d *pdfDocument) differences(list []interface{}) map[uint32]string {
+	result := map[uint32]string{}
+	code := 0
+	for _, item := range list {
+		switch v := d.resolve(item).(type) {
+		case float64:
+			code = int(v)
+		case pdfName:
+			if text := glyphText(string(v)); text != "" {
+				result[uint32(code)] = text
+			}
+			code++
+		}
+	}
+	return result
+}
+
+// codes splits a shown string into character codes
+func (f *pdfFont) codes(s []byte) []uint32 {
+	if !f.twoByte {
+		codes := make([]uint32, len(s))
+		for i, b := range s {
+			codes[i] = uint32(b)
+		}
+		return codes
+	}
+
+	codes := make([]uint32, 0, (len(s)+1)/2)
+	for i := 0; i < len(s); i += 2 {
+		if i+1 == len(s) {
+			codes = append(codes, uint32(s[i]))
+			break
+		}
+		codes = append(codes, uint32(s[i])<<8|uint32(s[i+1]))
+	}
+	return codes
+}
+
+func (f *pdfFont) text(code uint32) string {
+	if text, ok := f.toUnicode[code]; ok {
+		return text
+	}
+	if f.twoByte {
+		// Glyph IDs of embedded fonts cannot be mapped without a ToUnicode CMap
+		if f.unicodeCodes {
+			return string(rune(code))
+		}
+		return ""
+	}
+	if text, ok := f.differences[code]; ok {
+		return text
+	}
+	return winAnsi(byte(code))
+}
+
+func (f *pdfFont) width(code uint32) float64 {
+	if width, ok := f.widths[code]; ok && width > 0 {
+		return width
+	}
+	return f.defaultWidth
+}
+
+// winAnsiHigh holds the characters of WinAnsiEncoding at 0x80-0x9F, where it differs from Latin-1
+var winAnsiHigh = [32]rune{
+	0x20AC, 0, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021, 0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017D, 0,
+	0, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014, 0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0, 0x017E, 0x0178,
+}
+
+func winAnsi(b byte) string {
+	switch {
+	case b < 0x20:
+		return ""
+	case b >= 0x80 && b < 0xA0:
+		if r := winAnsiHigh[b-0x80]; r != 0 {
+			return string(r)
+		}
+		return ""
+	}
+	return string(rune(b))
+}
+
+var glyphNames = map[string]string{
+	"space": " ", "bullet": "•", "endash": "–", "emdash": "—", "hyphen": "-", "minus": "-",
+	"quoteleft": "‘", "quoteright": "’", "quotedblleft": "“", "quotedblright": "”", "quotesingle": "'", "quotedbl": "\"",
+	"period": ".", "comma": ",", "colon": ":", "semicolon": ";", "slash": "/", "backslash": "\\", "bar": "|",
+	"parenleft": "(", "parenright": ")", "bracketleft": "[", "bracketright": "]", "at": "@", "ampersand": "&",
+	"numbersign": "#", "plus": "+", "equal": "=", "percent": "%", "dollar": "$", "underscore": "_",
+	"exclam": "!", "question": "?", "asterisk": "*", "fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl",
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9",
+}
+
+// glyphText maps a glyph name from an encoding's Differences array to text
+func glyphText(name string) string {
+	if text, ok := glyphNames[name]; ok {
+		return text
+	}
+	if len(name) == 1 {
+		return name
+	}
+	for _, prefix := range []string{"uni", "u"} {
+		if strings.HasPrefix(name, prefix) && len(name) >= len(prefix)+4 {
+			if code, err := strconv.ParseUint(name[len(prefix):len(prefix)+4], 16, 32); err == nil {
+				return string(rune(code))
+			}
+		}
+	}
+	return ""
+}
+
+// parseCMap reads the bfchar and bfrange mappings of a ToUnicode CMap
+func parseCMap(data []byte) map[uint32]string {
+	result := map[uint32]string{}
+	l := &pdfLexer{data: data}
+	var operands []interface{}
+	for {
+		v := l.value(0)
+		if v == nil && l.pos >= len(data) {
+			return result
+		}
+		op, ok := v.(pdfKeyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+
+		switch op {
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok := operands[i].([]byte)
+				if !ok {
+					continue
+				}
+				if text := cmapText(operands[i+1]); text != "" {
+					result[codeValue(src)] = text
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				low, ok1 := operands[i].([]byte)
+				high, ok2 := operands[i+1].([]byte)
+				if !ok1 || !ok2 {
+					continue
+				}
+				start, end := codeValue(low), codeValue(high)
+				if end < start || end-start > 0xFFFF {
+					continue
+				}
+
+				switch dst := operands[i+2].(type) {
+				case []byte:
+					units := utf16Units(dst)
+					if len(units) == 0 {
+						continue
+					}
+					for offset := uint32(0); offset <= end-start; offset++ {
+						shifted := append([]uint16(nil), units...)
+						shifted[len(shifted)-1] += uint16(offset)
+						result[start+offset] = string(utf16.Decode(shifted))
+					}
+				case []interface{}:
+					for j, item := range dst {
+						if text := cmapText(item); text != "" && uint32(j) <= end-start {
+							result[start+uint32(j)] = text
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+func cmapText(v interface{}) string {
+	switch t := v.(type) {
+	case []byte:
+		return string(utf16.Decode(utf16Units(t)))
+	case pdfName:
+		return glyphText(string(t))
+	}
+	return ""
+}
+
+func utf16Units(b []byte) []uint16 {
+	units := make([]uint16, 0, (len(b)+1)/2)
+	for i := 0; i < len(b); i += 2 {
+		if i+1 == len(b) {
+			units = append(units, uint16(b[i]))
+			break
+		}
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return units
+}
+
+func codeValue(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+// textState tracks the text position in user space, which is enough to tell line breaks and word gaps apart
+type textState struct {
+	font    *pdfFont
+	size    float64
+	leading float64
+	scaleX  float64
+	scaleY  float64
+	x, y    float64
+	lineX   float64
+	lineY   float64
+	newLine bool
+}
+
+func (st *textState) nextLine() {
+	st.lineY -= st.leading * st.scaleY
+	st.x, st.y = st.lineX, st.lineY
+	st.newLine = true
+}
+
+// textWriter joins shown strings into lines
+type textWriter struct {
+	out     *strings.Builder
+	lastX   float64
+	lastY   float64
+	started bool
+}
+
+func (w *textWriter) space() {
+	s := w.out.String()
+	if len(s) > 0 && s[len(s)-1] != ' ' && s[len(s)-1] != '\n' {
+		w.out.WriteByte(' ')
+	}
+}
+
+func (w *textWriter) show(st *textState, s []byte) {
+	codes := st.font.codes(s)
+	if len(codes) == 0 {
+		return
+	}
+
+	if w.started {
+		em := st.size * st.scaleX
+		switch {
+		case st.newLine || math.Abs(st.y-w.lastY) > st.size*st.scaleY*0.5:
+			w.out.WriteByte('\n')
+		case st.x-w.lastX > em*0.15 || st.x < w.lastX-em:
+			w.space()
+		}
+	}
+	st.newLine = false
+
+	for _, code := range codes {
+		w.out.WriteString(st.font.text(code))
+		st.x += st.font.width(code) / 1000 * st.size * st.scaleX
+	}
+	w.lastX, w.lastY, w.started = st.x, st.y, true
+}
+
+// runContent interprets the text operators of a content stream, descending into form XObjects
+func (d *pdfDocument) runContent(content []byte, resources pdfDict, w *textWriter, depth int) {
+	st := &textState{font: &pdfFont{defaultWidth: 500}, size: 10, scaleX: 1, scaleY: 1}
+	l := &pdfLexer{data: content}
+	var operands []interface{}
+	for {
+		v := l.value(0)
+		if v == nil && l.pos >= len(content) {
+			return
+		}
+		op, ok := v.(pdfKeyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+
+		switch op {
+		case "BT":
+			st.scaleX, st.scaleY = 1, 1
+			st.x, st.y, st.lineX, st.lineY = 0, 0, 0, 0
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[len(operands)-2].(pdfName); ok {
+					st.font = d.font(resources, name)
+				}
+				if size, ok := operands[len(operands)-1].(float64); ok && size != 0 {
+					st.size = math.Abs(size)
+				}
+			}
+		case "TL":
+			if n := lastNumbers(operands, 1); n != nil {
+				st.leading = n[0]
+			}
+		case "Td", "TD":
+			if n := lastNumbers(operands, 2); n != nil {
+				if op == "TD" {
+					st.leading = -n[1]
+				}
+				st.lineX += n[0] * st.scaleX
+				st.lineY += n[1] * st.scaleY
+				st.x, st.y = st.lineX, st.lineY
+			}
+		case "Tm":
+			if n := lastNumbers(operands, 6); n != nil {
+				st.scaleX, st.scaleY = math.Hypot(n[0], n[1]), math.Hypot(n[2], n[3])
+				if st.scaleX == 0 {
+					st.scaleX = 1
+				}
+				if st.scaleY == 0 {
+					st.scaleY = 1
+				}
+				st.lineX, st.lineY = n[4], n[5]
+				st.x, st.y = st.lineX, st.lineY
+			}
+		case "T*":
+			st.nextLine()
+		case "Tj":
+			if s := lastString(operands); s != nil {
+				w.show(st, s)
+			}
+		case "'", "\"":
+			st.nextLine()
+			if s := lastString(operands); s != nil {
+				w.show(st, s)
+			}
+		case "TJ":
+			if len(operands) == 0 {
+				break
+			}
+			items, _ := operands[len(operands)-1].([]interface{})
+			for _, item := range items {
+				switch t := item.(type) {
+				case []byte:
+					w.show(st, t)
+				case float64:
+					st.x -= t / 1000 * st.size * st.scaleX
+				}
+			}
+		case "Do":
+			if len(operands) > 0 && depth < 8 {
+				if name, ok := operands[len(operands)-1].(pdfName); ok {
+					d.runForm(resources, name, w, depth)
+				}
+			}
+		case "BI":
+			l.pos = skipInlineImage(content, l.pos)
+		}
+		operands = operands[:0]
+	}
+}
+
+func (d *pdfDocument) runForm(resources pdfDict, name pdfName, w *textWriter, depth int) {
+	stream, ok := d.resolve(d.dict(resources["XObject"])[name]).(*pdfStream)
+	if !ok || d.name(stream.dict["Subtype"]) != "Form" {
+		return
+	}
+	data, err := d.decode(stream)
+	if err != nil {
+		return
+	}
+
+	formResources := d.dict(stream.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	d.runContent(data, formResources, w, depth+1)
+}
+
+func lastNumbers(operands []interface{}, n int) []float64 {
+	if len(operands) < n {
+		return nil
+	}
+	result := make([]float64, n)
+	for i, v := range operands[len(operands)-n:] {
+		number, ok := v.(float64)
+		if !ok {
+			return nil
+		}
+		result[i] = number
+	}
+	return result
+}
+
+func lastString(operands []interface{}) []byte {
+	if len(operands) == 0 {
+		return nil
+	}
+	s, _ := operands[len(operands)-1].([]byte)
+	return s
+}
+
+// skipInlineImage returns the position after the binary data of an inline image
+func skipInlineImage(content []byte, pos int) int {
+	id := bytes.Index(content[pos:], []byte("ID"))
+	if id < 0 {
+		return len(content)
+	}
+	pos += id + 2
+	for pos < len(content) {
+		end := bytes.Index(content[pos:], []byte("EI"))
+		if end < 0 {
+			return len(content)
+		}
+		pos += end
+		before := isPDFSpace(content[pos-1])
+		after := pos+2 >= len(content) || isPDFSpace(content[pos+2])
+		pos += 2
+		if before && after {
+			return pos
+		}
+	}
+	return len(content)
+}
@@ -0,0 +1,164 @@
+// Package imaging decodes, resizes and re-encodes uploaded images using only the standard library.
+// Re-encoding drops all metadata such as EXIF, after applying the EXIF orientation to the pixels.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	// Register the GIF decoder so animated or legacy logos can be converted
+	_ "image/gif"
+)
+
+// Supported output formats
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+// MaxPixels bounds decoded image dimensions to protect against decompression bombs
+const MaxPixels = 40_000_000
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type, use JPEG, PNG or GIF")
+	ErrTooLarge        = errors.New("image dimensions are too large")
+)
+
+// SniffContentType returns the MIME type detected from the file content, ignoring any client-supplied type
+func SniffContentType(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// Decode validates and decodes an image, applying its EXIF orientation.
+// The returned format is the encoding that should be used when re-encoding it.
+func Decode(data []byte) (image.Image, string, error) {
+	switch SniffContentType(data) {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, "", ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedType
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedType
+	}
+
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+		return img, FormatJPEG, nil
+	}
+	return img, FormatPNG, nil
+}
+
+// Encode writes the image without any metadata
+func Encode(img image.Image, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case FormatPNG:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	default:
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+}
+
+// Fit scales the image down to fit within maxWidth x maxHeight, keeping its aspect ratio.
+// Images that already fit are returned unscaled.
+func Fit(img image.Image, maxWidth, maxHeight int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxWidth && h <= maxHeight {
+		return toRGBA(img)
+	}
+
+	scale := min(float64(maxWidth)/float64(w), float64(maxHeight)/float64(h))
+	newW := max(1, int(float64(w)*scale+0.5))
+	newH := max(1, int(float64(h)*scale+0.5))
+	return Resize(img, newW, newH)
+}
+
+// Fill center-crops the image to the aspect ratio of width x height and scales it to exactly that size
+func Fill(img image.Image, width, height int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	cropW, cropH := w, h
+	if w*height > h*width {
+		cropW = h * width / height
+	} else {
+		cropH = w * height / width
+	}
+	cropW, cropH = max(1, cropW), max(1, cropH)
+
+	x0 := b.Min.X + (w-cropW)/2
+	y0 := b.Min.Y + (h-cropH)/2
+	cropped := image.NewRGBA(image.Rect(0, 0, cropW, cropH))
+	draw.Draw(cropped, cropped.Bounds(), img, image.Pt(x0, y0), draw.Src)
+
+	return Resize(cropped, width, height)
+}
+
+// Resize scales the image to width x height using area averaging, which gives smooth downscaled thumbnails
+func Resize(img image.Image, width, height int) *image.RGBA {
+	src := toRGBA(img)
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		sy0 := y * sh / height
+		sy1 := max(sy0+1, (y+1)*sh/height)
+		for x := 0; x < width; x++ {
+			sx0 := x * sw / width
+			sx1 := max(sx0+1, (x+1)*sw/width)
+
+			var r, g, bl, a, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				i := src.PixOffset(sb.Min.X+sx0, sb.Min.Y+sy)
+				for sx := sx0; sx < sx1; sx++ {
+					r += uint32(src.Pix[i])
+					g += uint32(src.Pix[i+1])
+					bl += uint32(src.Pix[i+2])
+					a += uint32(src.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(bl / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,226 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// filled returns a w x h image painted by paint(x, y)
+func filled(w, h int, paint func(x, y int) color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, paint(x, y))
+		}
+	}
+	return img
+}
+
+func solid(c color.RGBA) func(x, y int) color.RGBA {
+	return func(int, int) color.RGBA { return c }
+}
+
+var (
+	red   = color.RGBA{255, 0, 0, 255}
+	green = color.RGBA{0, 255, 0, 255}
+	blue  = color.RGBA{0, 0, 255, 255}
+)
+
+func TestResizeAveragesAreas(t *testing.T) {
+	// 4x2 with a black and white checkerboard on the left half and solid red on the right
+	src := filled(4, 2, func(x, y int) color.RGBA {
+		if x >= 2 {
+			return red
+		}
+		if (x+y)%2 == 0 {
+			return color.RGBA{0, 0, 0, 255}
+		}
+		return color.RGBA{255, 255, 255, 255}
+	})
+
+	dst := Resize(src, 2, 1)
+	if got := dst.Bounds(); got != image.Rect(0, 0, 2, 1) {
+		t.Fatalf("Resize() bounds = %v", got)
+	}
+	if got, want := dst.RGBAAt(0, 0), (color.RGBA{127, 127, 127, 255}); got != want {
+		t.Errorf("checkerboard pixel = %v, want the average %v", got, want)
+	}
+	if got := dst.RGBAAt(1, 0); got != red {
+		t.Errorf("red pixel = %v, want %v", got, red)
+	}
+}
+
+func TestResizeUpscalesAndReadsSubImages(t *testing.T) {
+	src := filled(4, 4, func(x, y int) color.RGBA {
+		if x >= 2 && y >= 2 {
+			return blue
+		}
+		return red
+	})
+	sub := src.SubImage(image.Rect(2, 2, 4, 4)) // the blue quarter, not at the origin
+
+	dst := Resize(sub, 3, 3)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			if got := dst.RGBAAt(x, y); got != blue {
+				t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, got, blue)
+			}
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		w, h         int
+		maxW, maxH   int
+		wantW, wantH int
+	}{
+		{400, 200, 100, 100, 100, 50},
+		{200, 400, 100, 100, 50, 100},
+		{300, 300, 100, 200, 100, 100},
+		{80, 60, 100, 100, 80, 60},  // already fits
+		{1000, 1, 100, 100, 100, 1}, // never scaled below one pixel
+		{333, 100, 100, 100, 100, 30},
+	}
+	for _, tt := range tests {
+		got := Fit(filled(tt.w, tt.h, solid(red)), tt.maxW, tt.maxH).Bounds()
+		if got.Dx() != tt.wantW || got.Dy() != tt.wantH {
+			t.Errorf("Fit(%dx%d, %dx%d) = %dx%d, want %dx%d", tt.w, tt.h, tt.maxW, tt.maxH,
+				got.Dx(), got.Dy(), tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestFillCropsTheCenter(t *testing.T) {
+	// Red and blue bands on the outer quarters around a green center
+	wide := filled(400, 200, func(x, _ int) color.RGBA {
+		switch {
+		case x < 100:
+			return red
+		case x >= 300:
+			return blue
+		}
+		return green
+	})
+	tall := filled(200, 400, func(_, y int) color.RGBA {
+		switch {
+		case y < 100:
+			return red
+		case y >= 300:
+			return blue
+		}
+		return green
+	})
+
+	for name, img := range map[string]image.Image{"wide": wide, "tall": tall} {
+		dst := Fill(img, 50, 50).(*image.RGBA)
+		if got := dst.Bounds(); got != image.Rect(0, 0, 50, 50) {
+			t.Fatalf("%s: Fill() bounds = %v", name, got)
+		}
+		for _, p := range []image.Point{{0, 0}, {49, 0}, {0, 49}, {49, 49}, {25, 25}} {
+			if got := dst.RGBAAt(p.X, p.Y); got != green {
+				t.Errorf("%s: pixel %v = %v, want only the green center", name, p, got)
+			}
+		}
+	}
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	img := filled(3, 2, solid(red))
+
+	var jpg bytes.Buffer
+	if err := jpeg.Encode(&jpg, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	// An EXIF segment asking for a 90 degree turn, right after the start of image marker
+	rotated := append([]byte{0xFF, 0xD8}, exifSegment(binary.BigEndian, 6)...)
+	rotated = append(rotated, jpg.Bytes()[2:]...)
+
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantFormat string
+		wantSize   image.Point
+	}{
+		{"png", encodePNG(t, img), FormatPNG, image.Pt(3, 2)},
+		{"gif", gifData.Bytes(), FormatPNG, image.Pt(3, 2)},
+		{"jpeg", jpg.Bytes(), FormatJPEG, image.Pt(3, 2)},
+		{"rotated jpeg", rotated, FormatJPEG, image.Pt(2, 3)},
+	}
+	for _, tt := range tests {
+		got, format, err := Decode(tt.data)
+		if err != nil {
+			t.Errorf("%s: Decode() error = %v", tt.name, err)
+			continue
+		}
+		if format != tt.wantFormat || got.Bounds().Size() != tt.wantSize {
+			t.Errorf("%s: Decode() = %v %s, want %v %s", tt.name, got.Bounds().Size(), format, tt.wantSize, tt.wantFormat)
+		}
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	// A PNG whose header claims 10000x10000 pixels, with a valid checksum
+	huge := encodePNG(t, filled(1, 1, solid(red)))
+	binary.BigEndian.PutUint32(huge[16:], 10000)
+	binary.BigEndian.PutUint32(huge[20:], 10000)
+	binary.BigEndian.PutUint32(huge[29:], crc32.ChecksumIEEE(huge[12:29]))
+
+	truncated := encodePNG(t, filled(8, 8, solid(red)))
+	truncated = truncated[:len(truncated)-20]
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"text", []byte("hello world"), ErrUnsupportedType},
+		{"svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), ErrUnsupportedType},
+		{"empty", nil, ErrUnsupportedType},
+		{"truncated png", truncated, ErrUnsupportedType},
+		{"too many pixels", huge, ErrTooLarge},
+	}
+	for _, tt := range tests {
+		if _, _, err := Decode(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Decode() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestEncodeDropsMetadata(t *testing.T) {
+	img := filled(4, 4, solid(green))
+	for _, format := range []string{FormatJPEG, FormatPNG} {
+		data, contentType, err := Encode(img, format)
+		if err != nil {
+			t.Fatalf("Encode(%s) error = %v", format, err)
+		}
+		if sniffed := SniffContentType(data); sniffed != contentType {
+			t.Errorf("Encode(%s) content type = %s, but the data is %s", format, contentType, sniffed)
+		}
+		if bytes.Contains(data, []byte("Exif")) {
+			t.Errorf("Encode(%s) wrote EXIF metadata", format)
+		}
+	}
+}
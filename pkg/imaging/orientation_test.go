@@ -0,0 +1,156 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+// exifSegment builds an APP1 segment holding a TIFF header and one IFD with the orientation tag
+func exifSegment(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8) // first IFD right after the header
+	order.PutUint16(tiff[8:], 1) // one entry
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3) // SHORT
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(2+len(payload)))
+	return append(segment, payload...)
+}
+
+// jpegWith wraps segments in a start of image and a start of scan marker
+func jpegWith(segments ...[]byte) []byte {
+	data := []byte{0xFF, 0xD8}
+	for _, segment := range segments {
+		data = append(data, segment...)
+	}
+	return append(data, 0xFF, 0xDA, 0, 2)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	comment := []byte{0xFF, 0xFE, 0, 5, 'h', 'i', '!'}
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"little endian", jpegWith(exifSegment(binary.LittleEndian, 6)), 6},
+		{"big endian", jpegWith(exifSegment(binary.BigEndian, 8)), 8},
+		{"after another segment", jpegWith(comment, exifSegment(binary.BigEndian, 3)), 3},
+		{"no exif", jpegWith(comment), 1},
+		{"orientation 0", jpegWith(exifSegment(binary.LittleEndian, 0)), 1},
+		{"orientation 9", jpegWith(exifSegment(binary.LittleEndian, 9)), 1},
+		{"exif after the scan", append(jpegWith(), exifSegment(binary.LittleEndian, 6)...), 1},
+		{"not a jpeg", append([]byte{0x89, 'P', 'N', 'G'}, exifSegment(binary.LittleEndian, 6)...), 1},
+		{"empty", nil, 1},
+		{"missing marker", []byte{0xFF, 0xD8, 0x00, 0xE1, 0, 4, 0, 0}, 1},
+		{"segment size below 2", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0, 1, 0, 0}, 1},
+		{"segment past the end", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF, 'E', 'x', 'i', 'f', 0, 0}, 1},
+	}
+	for _, tt := range tests {
+		if got := jpegOrientation(tt.data); got != tt.want {
+			t.Errorf("%s: jpegOrientation() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestJPEGOrientationTruncated(t *testing.T) {
+	data := jpegWith(exifSegment(binary.BigEndian, 6))
+	segmentEnd := len(data) - 4
+	for n := 0; n < len(data); n++ {
+		want := 1
+		if n >= segmentEnd {
+			want = 6
+		}
+		if got := jpegOrientation(data[:n]); got != want {
+			t.Errorf("jpegOrientation() of the first %d bytes = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestEXIFOrientationMalformed(t *testing.T) {
+	valid := exifSegment(binary.LittleEndian, 6)[10:] // the TIFF data after "Exif\0\0"
+	corrupt := func(edit func(tiff []byte)) []byte {
+		tiff := append([]byte(nil), valid...)
+		edit(tiff)
+		return tiff
+	}
+	tests := []struct {
+		name string
+		tiff []byte
+	}{
+		{"unknown byte order", corrupt(func(tiff []byte) { copy(tiff, "XX") })},
+		{"IFD inside the header", corrupt(func(tiff []byte) { binary.LittleEndian.PutUint32(tiff[4:], 4) })},
+		{"IFD past the end", corrupt(func(tiff []byte) { binary.LittleEndian.PutUint32(tiff[4:], 0xFFFFFFFF) })},
+		{"more entries than data", corrupt(func(tiff []byte) {
+			binary.LittleEndian.PutUint16(tiff[8:], 0xFFFF)
+			binary.LittleEndian.PutUint16(tiff[10:], 0x0100) // first entry is not the orientation
+		})},
+		{"short header", valid[:7]},
+		{"entry cut off", valid[:20]},
+	}
+	for _, tt := range tests {
+		if got := exifOrientation(tt.tiff); got != 1 {
+			t.Errorf("%s: exifOrientation() = %d, want 1", tt.name, got)
+		}
+	}
+	if got := exifOrientation(valid); got != 6 {
+		t.Errorf("exifOrientation() = %d, want 6", got)
+	}
+}
+
+// letters paints each row of rows as one pixel row, storing the letter in the red channel
+func letters(rows ...string) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		for x := range row {
+			img.Set(x, y, color.RGBA{row[x], 0, 0, 255})
+		}
+	}
+	return img
+}
+
+func readLetters(img image.Image) []string {
+	b := img.Bounds()
+	rows := make([]string, 0, b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := make([]byte, 0, b.Dx())
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, _, _, _ := img.At(x, y).RGBA()
+			row = append(row, byte(r>>8))
+		}
+		rows = append(rows, string(row))
+	}
+	return rows
+}
+
+func TestApplyOrientation(t *testing.T) {
+	tests := map[int][]string{
+		1: {"abc", "def"},
+		2: {"cba", "fed"},
+		3: {"fed", "cba"},
+		4: {"def", "abc"},
+		5: {"ad", "be", "cf"},
+		6: {"da", "eb", "fc"},
+		7: {"fc", "eb", "da"},
+		8: {"cf", "be", "ad"},
+		9: {"abc", "def"},
+	}
+	for orientation, want := range tests {
+		if got := readLetters(applyOrientation(letters("abc", "def"), orientation)); !slices.Equal(got, want) {
+			t.Errorf("orientation %d: got %q, want %q", orientation, got, want)
+		}
+	}
+}
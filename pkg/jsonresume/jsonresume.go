@@ -0,0 +1,125 @@
+// Package jsonresume implements the parts of the JSON Resume schema (https://jsonresume.org/schema)
+// that map onto developer profiles. Unknown sections are ignored when decoding.
+package jsonresume
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// SchemaURL is the schema version written on export
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+type Resume struct {
+	Schema       string        `json:"$schema,omitempty"`
+	Basics       Basics        `json:"basics"`
+	Work         []Work        `json:"work"`
+	Education    []Education   `json:"education"`
+	Certificates []Certificate `json:"certificates"`
+	Skills       []Skill       `json:"skills"`
+	Meta         *Meta         `json:"meta,omitempty"`
+}
+
+type Basics struct {
+	Name     string    `json:"name"`
+	Label    string    `json:"label,omitempty"`
+	Image    string    `json:"image,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Location *Location `json:"location,omitempty"`
+	Profiles []Profile `json:"profiles,omitempty"`
+}
+
+type Location struct {
+	Address     string `json:"address,omitempty"`
+	PostalCode  string `json:"postalCode,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+type Profile struct {
+	Network  string `json:"network,omitempty"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type Work struct {
+	Name       string   `json:"name"`
+	Company    string   `json:"company,omitempty"` // name before schema v1.0.0
+	Position   string   `json:"position"`
+	URL        string   `json:"url,omitempty"`
+	StartDate  string   `json:"startDate"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+type Education struct {
+	Institution string   `json:"institution"`
+	URL         string   `json:"url,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType"`
+	StartDate   string   `json:"startDate"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	GPA         string   `json:"gpa,omitempty"` // score before schema v1.0.0
+	Courses     []string `json:"courses,omitempty"`
+}
+
+type Certificate struct {
+	Name   string `json:"name"`
+	Date   string `json:"date"`
+	Issuer string `json:"issuer"`
+	URL    string `json:"url,omitempty"`
+}
+
+type Skill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type Meta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// CompanyName returns the employer, accepting the pre-1.0 field name
+func (w *Work) CompanyName() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.Company
+}
+
+// Grade returns the score, accepting the pre-1.0 field name
+func (e *Education) Grade() string {
+	if e.Score != "" {
+		return e.Score
+	}
+	return e.GPA
+}
+
+var ErrInvalidDate = errors.New("dates must be YYYY-MM-DD, YYYY-MM or YYYY")
+
+// FormatDate formats a date the way the schema expects
+func FormatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// ParseDate reads the ISO 8601 dates the schema allows: YYYY-MM-DD, YYYY-MM or YYYY.
+// Full timestamps are accepted as well since some exporters write them.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, ErrInvalidDate
+}
@@ -0,0 +1,264 @@
+package pdf
+
+// Bangla shaping following the OpenType Indic model: text is split into syllables, the base consonant of
+// each is found, reph and pre-base vowel signs are reordered, and the font's substitutions are applied in
+// stages so conjuncts, half forms, ya-phala and ra-phala are formed. Glyph positioning (GPOS) is not
+// applied; Bangla fonts draw their marks with zero width over the preceding glyph.
+
+const (
+	bnRa      = 'র'
+	bnHasanta = '্'
+	zwnj      = '\u200C'
+	zwj       = '\u200D'
+)
+
+// Character categories
+const (
+	catOther uint8 = iota
+	catConsonant
+	catVowel
+	catMatra    // vowel sign drawn after, above or below the consonant
+	catPreMatra // vowel sign drawn before the consonant
+	catHalant
+	catNukta
+	catModifier // candrabindu, anusvara and visarga
+	catJoiner
+)
+
+// Glyph positions in a syllable
+const (
+	posPreMatra uint8 = iota
+	posPreBase
+	posBase
+	posBelow
+	posPost
+	posAfter // vowel signs and modifiers
+	posReph
+)
+
+// bnStages are applied one after another; the features of the last stage are applied together
+var bnStages = [][]string{
+	{"locl", "ccmp"}, {"nukt"}, {"akhn"}, {"rphf"}, {"rkrf"}, {"blwf"}, {"abvf"}, {"half"}, {"pstf"}, {"vatu"}, {"cjct"},
+	{"init", "pres", "abvs", "blws", "psts", "haln", "calt", "clig"},
+}
+
+var (
+	bnGlobalMask   = featureMask("locl", "ccmp", "nukt", "akhn", "rkrf", "vatu", "cjct", "pres", "abvs", "blws", "psts", "haln", "calt", "clig")
+	bnPreBaseMask  = featureMask("half", "blwf")
+	bnPostBaseMask = featureMask("blwf", "abvf", "pstf")
+)
+
+func isBengali(r rune) bool {
+	return r >= 0x0980 && r <= 0x09FF
+}
+
+func bnCategory(r rune) uint8 {
+	switch {
+	case r >= 'ক' && r <= 'হ', r >= '\u09DC' && r <= '\u09DF', r == 'ৰ', r == 'ৱ':
+		return catConsonant
+	case r >= 'অ' && r <= 'ঔ', r == 'ৠ', r == 'ৡ':
+		return catVowel
+	case r == 'ি', r == 'ে', r == 'ৈ':
+		return catPreMatra
+	case r >= 'া' && r <= 'ৌ', r == 'ৗ', r == 'ৢ', r == 'ৣ':
+		return catMatra
+	case r == bnHasanta:
+		return catHalant
+	case r == '়':
+		return catNukta
+	case r >= 'ঁ' && r <= 'ঃ':
+		return catModifier
+	case r == zwj, r == zwnj:
+		return catJoiner
+	}
+	return catOther
+}
+
+// bnSyllableEnd returns the end of the syllable starting at i: consonants joined by hasanta followed by
+// vowel signs and modifiers, or an independent vowel with its modifiers
+func bnSyllableEnd(runes []rune, i int) int {
+	n := len(runes)
+	skip := func(cats ...uint8) {
+		for i < n {
+			found := false
+			for _, cat := range cats {
+				if bnCategory(runes[i]) == cat {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return
+			}
+			i++
+		}
+	}
+
+	switch bnCategory(runes[i]) {
+	case catConsonant:
+		i++
+		skip(catNukta)
+		for i < n && bnCategory(runes[i]) == catHalant {
+			j := i + 1
+			if j < n && bnCategory(runes[j]) == catJoiner {
+				j++
+			}
+			if j >= n || bnCategory(runes[j]) != catConsonant {
+				break
+			}
+			i = j + 1
+			skip(catNukta)
+		}
+		skip(catMatra, catPreMatra, catHalant, catModifier, catNukta, catJoiner)
+	case catVowel:
+		i++
+		skip(catNukta, catMatra, catPreMatra, catModifier)
+	default:
+		i++
+	}
+	return i
+}
+
+func (tt *TrueType) glyphInfo(r rune) glyphInfo {
+	return glyphInfo{id: tt.glyphID(r), text: []rune{r}, mask: bnGlobalMask, cat: bnCategory(r), pos: posBase}
+}
+
+// consonantPosition tells whether a consonant following a hasanta takes a below-base or post-base form
+func (tt *TrueType) consonantPosition(id uint16) uint8 {
+	halant := tt.glyphID(bnHasanta)
+	switch {
+	case tt.gsub.wouldSubstitute("blwf", halant, id), tt.gsub.wouldSubstitute("blwf", id, halant):
+		return posBelow
+	case tt.gsub.wouldSubstitute("pstf", halant, id), tt.gsub.wouldSubstitute("pstf", id, halant):
+		return posPost
+	}
+	return posBase
+}
+
+// shapeBengali maps a syllable to glyphs in visual order
+func (tt *TrueType) shapeBengali(syllable []rune, wordStart bool) []glyphInfo {
+	buf := make([]glyphInfo, 0, len(syllable)+1)
+	for _, r := range syllable {
+		switch r {
+		case 'ো':
+			buf = append(buf, tt.glyphInfo('ে'), tt.glyphInfo('া'))
+		case 'ৌ':
+			buf = append(buf, tt.glyphInfo('ে'), tt.glyphInfo('ৗ'))
+		default:
+			buf = append(buf, tt.glyphInfo(r))
+		}
+	}
+
+	// A ra with hasanta before another consonant becomes a reph drawn above the base consonant
+	start := 0
+	if len(buf) >= 3 && buf[0].text[0] == bnRa && buf[1].cat == catHalant && buf[2].cat == catConsonant &&
+		tt.gsub.wouldSubstitute("rphf", buf[0].id, buf[1].id) {
+		start = 2
+	}
+
+	// The base is the last consonant without a below-base or post-base form
+	base, seenBelow := -1, false
+	for i := len(buf) - 1; i >= start; i-- {
+		if buf[i].cat != catConsonant {
+			continue
+		}
+		position := posBase
+		if i > start {
+			position = tt.consonantPosition(buf[i].id)
+		}
+		base = i
+		if position == posBase || (position == posPost && seenBelow) {
+			break
+		}
+		if position == posBelow {
+			seenBelow = true
+		}
+	}
+
+	for i := range buf {
+		switch {
+		case i < start:
+			buf[i].pos = posReph
+			buf[i].mask |= featureMask("rphf") | bnPreBaseMask
+		case buf[i].cat == catPreMatra:
+			buf[i].pos = posPreMatra
+			if wordStart {
+				buf[i].mask |= featureMask("init")
+			}
+		case buf[i].cat == catMatra, buf[i].cat == catModifier:
+			buf[i].pos = posAfter
+		case base < 0 || i == base:
+			buf[i].pos = posBase
+		case i < base:
+			buf[i].pos = posPreBase
+			buf[i].mask |= bnPreBaseMask
+		case buf[i].cat == catConsonant:
+			buf[i].pos = tt.consonantPosition(buf[i].id)
+			if buf[i].pos == posBase {
+				buf[i].pos = posPost
+			}
+			buf[i].mask |= bnPostBaseMask
+		default:
+			buf[i].pos = posPost
+			buf[i].mask |= bnPostBaseMask
+		}
+	}
+	// Hasanta, nukta and joiners after the base belong to the consonant they join
+	for i := len(buf) - 2; i > base && base >= 0; i-- {
+		if (buf[i].cat == catHalant || buf[i].cat == catJoiner) && buf[i+1].cat == catConsonant {
+			buf[i].pos = buf[i+1].pos
+		}
+	}
+	for i := 1; i < len(buf); i++ {
+		if buf[i].cat == catNukta {
+			buf[i].pos = buf[i-1].pos
+		}
+	}
+
+	// Pre-base vowel signs are drawn before the whole cluster
+	ordered := make([]glyphInfo, 0, len(buf))
+	for _, g := range buf {
+		if g.pos == posPreMatra {
+			ordered = append(ordered, g)
+		}
+	}
+	for _, g := range buf {
+		if g.pos != posPreMatra {
+			ordered = append(ordered, g)
+		}
+	}
+	buf = ordered
+
+	for _, stage := range bnStages {
+		buf = tt.gsub.apply(buf, stage...)
+	}
+
+	// The reph follows the base and below-base forms
+	if start > 0 {
+		var reph, rest []glyphInfo
+		for _, g := range buf {
+			if g.pos == posReph {
+				reph = append(reph, g)
+			} else {
+				rest = append(rest, g)
+			}
+		}
+		at := len(rest)
+		for i := len(rest) - 1; i >= 0; i-- {
+			if rest[i].pos == posBase || rest[i].pos == posBelow {
+				at = i + 1
+				break
+			}
+		}
+		buf = append(rest[:at:at], append(reph, rest[at:]...)...)
+	}
+
+	// Joiners only steer shaping
+	shaped := buf[:0]
+	for _, g := range buf {
+		if g.cat != catJoiner || g.id != 0 {
+			shaped = append(shaped, g)
+		}
+	}
+	return shaped
+}
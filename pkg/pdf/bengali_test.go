@@ -0,0 +1,116 @@
+package pdf
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBnSyllableEnd(t *testing.T) {
+	tests := []struct {
+		text string
+		want int // runes in the first syllable
+	}{
+		{"কক", 1},
+		{"কি", 2},
+		{"ক্ষি", 4},
+		{"ক্\u200Dষ", 4},
+		{"ক্", 2},
+		{"র্কো", 4},
+		{"কঁা", 3},
+		{"অংক", 2},
+		{"ক ক", 1},
+		{"১২", 1},
+	}
+	for _, tt := range tests {
+		if got := bnSyllableEnd([]rune(tt.text), 0); got != tt.want {
+			t.Errorf("bnSyllableEnd(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBnCategory(t *testing.T) {
+	tests := map[rune]uint8{
+		'ক': catConsonant, 'হ': catConsonant, '\u09DF': catConsonant,
+		'অ': catVowel, 'ঔ': catVowel,
+		'ি': catPreMatra, 'ে': catPreMatra, 'ৈ': catPreMatra,
+		'া': catMatra, 'ো': catMatra, 'ৗ': catMatra,
+		'্': catHalant, '়': catNukta, 'ঁ': catModifier, 'ঃ': catModifier,
+		zwj: catJoiner, '১': catOther, 'A': catOther,
+	}
+	for r, want := range tests {
+		if got := bnCategory(r); got != want {
+			t.Errorf("bnCategory(%q) = %d, want %d", r, got, want)
+		}
+	}
+}
+
+func TestShapeBengali(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []uint16
+	}{
+		{"consonant", "ক", []uint16{gKa}},
+		{"pre-base vowel sign at the start of a word", "কি", []uint16{gIInit, gKa}},
+		{"pre-base vowel sign inside a word", "ককি", []uint16{gKa, gI, gKa}},
+		{"o sign split around the consonant", "কো", []uint16{gE, gKaBeforeAa, gAa}},
+		{"au sign split around the consonant", "কৌ", []uint16{gE, gKa, gAuLength}},
+		{"conjunct", "ক্ষ", []uint16{gKssa}},
+		{"half form", "ক্ক", []uint16{gKaHalf, gKa}},
+		{"ya-phala", "ক্য", []uint16{gKa, gYaPhala}},
+		{"reph after the base", "র্ক", []uint16{gKa, gReph}},
+		{"reph after the base with a pre-base vowel sign", "র্কি", []uint16{gIInit, gKa, gReph}},
+		{"reph over a conjunct", "র্ক্ষ", []uint16{gKssa, gReph}},
+		{"zero width joiner dropped", "ক্\u200Dক", []uint16{gKaHalf, gKa}},
+		{"latin between syllables", "কA কি", []uint16{gKa, gA, gSpace, gIInit, gKa}},
+		{"missing character", "খ", []uint16{0}},
+	}
+	tt := testFont(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := glyphIDs(tt.layout(test.text)); !slices.Equal(got, test.want) {
+				t.Errorf("layout(%q) = %v, want %v", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+// Every character of the text is kept by exactly one glyph, in logical order per glyph, so it can be copied
+func TestShapeBengaliText(t *testing.T) {
+	tt := testFont(t)
+	tests := map[string][]string{
+		"র্কি": {"ি", "ক", "র্"},
+		"ক্ষো": {"ে", "ক্ষ", "া"},
+		"ক্য":  {"ক", "্য"},
+	}
+	for text, want := range tests {
+		glyphs := tt.layout(text)
+		got := make([]string, len(glyphs))
+		for i, g := range glyphs {
+			got[i] = string(g.text)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("layout(%q) text = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestShapeBengaliWithoutGSUB(t *testing.T) {
+	tables := testTables()
+	delete(tables, "GSUB")
+	tt, err := ParseTrueType(buildFont(tables))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without substitutions only the reordering of vowel signs applies, and ra hasanta stays in place
+	tests := map[string][]uint16{
+		"কি":  {gI, gKa},
+		"কো":  {gE, gKa, gAa},
+		"র্ক": {gRa, gHasanta, gKa},
+	}
+	for text, want := range tests {
+		if got := glyphIDs(tt.layout(text)); !slices.Equal(got, want) {
+			t.Errorf("layout(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
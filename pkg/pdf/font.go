@@ -0,0 +1,306 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var ErrUnknownFont = errors.New("unknown standard font")
+
+// Font is a font registered with a document
+type Font struct {
+	name   string // resource name used in page content
+	base   string // name of a standard font
+	widths *[224]uint16
+	tt     *TrueType
+	used   map[uint16][]rune // TrueType glyphs drawn, with the text they stand for
+	obj    int
+}
+
+// glyph is a positioned glyph: a character code for standard fonts, a glyph ID for TrueType fonts
+type glyph struct {
+	id      uint16
+	advance int // in thousandths of the font size
+	text    []rune
+}
+
+// AddStandardFont registers one of the standard fonts, such as Helvetica
+func (d *Document) AddStandardFont(name string) (*Font, error) {
+	widths, ok := standardWidths[name]
+	if !ok {
+		return nil, ErrUnknownFont
+	}
+	return d.addFont(&Font{base: name, widths: widths}), nil
+}
+
+// AddTrueType registers a TrueType font. Only glyph widths and text mappings are written per document;
+// the font file itself is compressed once and shared.
+func (d *Document) AddTrueType(tt *TrueType) *Font {
+	return d.addFont(&Font{tt: tt, used: map[uint16][]rune{}})
+}
+
+func (d *Document) addFont(font *Font) *Font {
+	d.fonts = append(d.fonts, font)
+	font.name = fmt.Sprintf("F%d", len(d.fonts))
+	return font
+}
+
+// Has reports whether the font can draw a character
+func (f *Font) Has(r rune) bool {
+	if f.tt != nil {
+		return f.tt.Has(r)
+	}
+	_, ok := winAnsi(r)
+	return ok
+}
+
+func (f *Font) layout(text string) []glyph {
+	if f.tt != nil {
+		return f.tt.layout(text)
+	}
+	glyphs := make([]glyph, 0, len(text))
+	for _, r := range text {
+		code, ok := winAnsi(r)
+		if !ok {
+			code = '?'
+		}
+		glyphs = append(glyphs, glyph{id: uint16(code), advance: int(f.widths[code-32]), text: []rune{r}})
+	}
+	return glyphs
+}
+
+// encode writes glyphs as a hex string, recording the TrueType glyphs used
+func (f *Font) encode(glyphs []glyph) string {
+	var sb strings.Builder
+	for _, g := range glyphs {
+		if f.tt == nil {
+			fmt.Fprintf(&sb, "%02X", g.id)
+			continue
+		}
+		fmt.Fprintf(&sb, "%04X", g.id)
+		if len(f.used[g.id]) == 0 {
+			f.used[g.id] = g.text
+		}
+	}
+	return sb.String()
+}
+
+func glyphWidth(glyphs []glyph, size float64) float64 {
+	total := 0
+	for _, g := range glyphs {
+		total += g.advance
+	}
+	return float64(total) * size / 1000
+}
+
+func (f *Font) write(w *writer) error {
+	if f.tt == nil {
+		w.begin(f.obj)
+		fmt.Fprintf(&w.buf, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.base)
+		w.end()
+		return nil
+	}
+
+	tt := f.tt
+	file, err := tt.compressed()
+	if err != nil {
+		return err
+	}
+	cid, descriptor, fontFile, toUnicode := w.alloc(), w.alloc(), w.alloc(), w.alloc()
+
+	w.begin(f.obj)
+	fmt.Fprintf(&w.buf, "<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		tt.name, cid, toUnicode)
+	w.end()
+
+	ids := make([]int, 0, len(f.used))
+	for id := range f.used {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+
+	var widths strings.Builder
+	for i := 0; i < len(ids); {
+		j := i
+		for j+1 < len(ids) && ids[j+1] == ids[j]+1 {
+			j++
+		}
+		fmt.Fprintf(&widths, "%d [", ids[i])
+		for k := i; k <= j; k++ {
+			if k > i {
+				widths.WriteByte(' ')
+			}
+			widths.WriteString(fmt.Sprint(tt.advance(uint16(ids[k]))))
+		}
+		widths.WriteString("] ")
+		i = j + 1
+	}
+
+	w.begin(cid)
+	fmt.Fprintf(&w.buf, "<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW 0 /W [%s] /CIDToGIDMap /Identity >>",
+		tt.name, descriptor, strings.TrimSpace(widths.String()))
+	w.end()
+
+	w.begin(descriptor)
+	fmt.Fprintf(&w.buf, "<< /Type /FontDescriptor /FontName /%s /Flags %d /FontBBox [%d %d %d %d] /ItalicAngle %s /Ascent %d /Descent %d /CapHeight %d /StemV %d /FontFile2 %d 0 R >>",
+		tt.name, tt.flags, tt.scale(int(tt.bbox[0])), tt.scale(int(tt.bbox[1])), tt.scale(int(tt.bbox[2])), tt.scale(int(tt.bbox[3])),
+		number(tt.italicAngle), tt.scale(int(tt.ascent)), tt.scale(int(tt.descent)), tt.scale(int(tt.capHeight)), tt.stemV, fontFile)
+	w.end()
+
+	w.compressed(fontFile, fmt.Sprintf(" /Length1 %d", len(tt.data)), file)
+
+	return w.stream(toUnicode, "", toUnicodeCMap(ids, f.used))
+}
+
+// toUnicodeCMap maps glyphs back to text so it can be searched and copied
+func toUnicodeCMap(ids []int, text map[uint16][]rune) []byte {
+	var sb strings.Builder
+	sb.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	var mapped []int
+	for _, id := range ids {
+		if len(text[uint16(id)]) > 0 {
+			mapped = append(mapped, id)
+		}
+	}
+	for start := 0; start < len(mapped); start += 100 {
+		end := min(start+100, len(mapped))
+		fmt.Fprintf(&sb, "%d beginbfchar\n", end-start)
+		for _, id := range mapped[start:end] {
+			fmt.Fprintf(&sb, "<%04X> <%s>\n", id, utf16Hex(text[uint16(id)]))
+		}
+		sb.WriteString("endbfchar\n")
+	}
+	sb.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return []byte(sb.String())
+}
+
+// Face is a list of fonts tried in order for each character, so text in scripts the first font lacks,
+// such as Bangla, falls back to an embedded font. Characters no font has are drawn as "?".
+type Face []*Font
+
+type run struct {
+	font *Font
+	text string
+}
+
+// runs splits text into pieces drawn with a single font. Spaces and combining marks stay with the
+// preceding font so words and syllables are not split.
+func (f Face) runs(text string) []run {
+	var runs []run
+	var current *Font
+	start := 0
+	for i, r := range text {
+		font := current
+		if font == nil || !font.Has(r) || !(unicode.IsSpace(r) || unicode.In(r, unicode.Mn, unicode.Mc) || isJoiner(r)) {
+			font = f.fontFor(r)
+		}
+		if font != current {
+			if current != nil && i > start {
+				runs = append(runs, run{font: current, text: text[start:i]})
+			}
+			current, start = font, i
+		}
+	}
+	if current != nil && start < len(text) {
+		runs = append(runs, run{font: current, text: text[start:]})
+	}
+	return runs
+}
+
+func (f Face) fontFor(r rune) *Font {
+	for _, font := range f {
+		if font.Has(r) {
+			return font
+		}
+	}
+	return f[0]
+}
+
+// Width returns the width of a line of text in points
+func (f Face) Width(text string, size float64) float64 {
+	width := 0.0
+	for _, run := range f.runs(text) {
+		width += glyphWidth(run.font.layout(run.text), size)
+	}
+	return width
+}
+
+// Wrap breaks text into lines no wider than width, keeping its line breaks.
+// Words wider than a line are split between characters.
+func (f Face) Wrap(text string, size, width float64) []string {
+	var lines []string
+	blank := false
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			if !blank && len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+
+		line := ""
+		for _, word := range words {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if f.Width(candidate, size) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			for f.Width(word, size) > width {
+				cut := f.fit(word, size, width)
+				if cut == len(word) {
+					break
+				}
+				lines = append(lines, word[:cut])
+				word = word[cut:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	if blank && len(lines) > 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// fit returns the longest prefix of a word that fits in width, cut between clusters and at least one cluster long
+func (f Face) fit(word string, size, width float64) int {
+	cut := 0
+	prev := rune(-1)
+	for i, r := range word {
+		if i > 0 && !unicode.In(r, unicode.Mn, unicode.Mc) && !isJoiner(r) && prev != bnHasanta && !isJoiner(prev) {
+			if f.Width(word[:i], size) > width {
+				if cut == 0 {
+					return i
+				}
+				return cut
+			}
+			cut = i
+		}
+		prev = r
+	}
+	if cut > 0 && f.Width(word, size) > width {
+		return cut
+	}
+	return len(word)
+}
+
+func isJoiner(r rune) bool {
+	return r == zwj || r == zwnj
+}
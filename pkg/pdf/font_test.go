@@ -0,0 +1,116 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func testFace(t *testing.T) (Face, *Document) {
+	t.Helper()
+	doc := New(A4Width, A4Height)
+	helvetica, err := doc.AddStandardFont(Helvetica)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Face{helvetica, doc.AddTrueType(testFont(t))}, doc
+}
+
+func TestFaceRuns(t *testing.T) {
+	face, _ := testFace(t)
+	tests := map[string][]string{
+		"Hello":          {"Hello"},
+		"Hello কি world": {"Hello ", "কি ", "world"},
+		"ক্ষ, A":         {"ক্ষ", ", A"},
+		"নম":             {"নম"}, // no font has it, drawn as "?" by the first font
+		"A\u200Dক":       {"A", "\u200Dক"},
+	}
+	for text, want := range tests {
+		var got []string
+		for _, r := range face.runs(text) {
+			got = append(got, r.text)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("runs(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestFaceWrap(t *testing.T) {
+	face, _ := testFace(t)
+	// Bangla glyphs of the test font are 5 points wide at 10 points
+	tests := []struct {
+		text  string
+		width float64
+		want  []string
+	}{
+		{"কি ক্ষ ক", 100, []string{"কি ক্ষ ক"}},
+		{"কি ক্ষ ক", 16, []string{"কি", "ক্ষ ক"}},
+		{"ক্ষক্ষক্ষ", 11, []string{"ক্ষক্ষ", "ক্ষ"}},
+		{"র্কির্কি", 16, []string{"র্কি", "র্কি"}},
+		{"ক্ষ", 1, []string{"ক্ষ"}},
+		{"ক\n\n\nক", 100, []string{"ক", "", "ক"}},
+	}
+	for _, tt := range tests {
+		if got := face.Wrap(tt.text, 10, tt.width); !slices.Equal(got, tt.want) {
+			t.Errorf("Wrap(%q, %v) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestDocumentEmbedsUsedFonts(t *testing.T) {
+	face, doc := testFace(t)
+	page := doc.AddPage()
+	if w := page.Text(50, 50, face, 10, Color{}, "কি"); w != 10 {
+		t.Errorf("Text() width = %v, want 10", w)
+	}
+	out, err := doc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-1.7")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Error("output is not a PDF file")
+	}
+	// Helvetica was not used, so only the TrueType font is written
+	if bytes.Contains(out, []byte("/BaseFont /Helvetica")) {
+		t.Error("unused standard font written")
+	}
+	for _, want := range []string{"/BaseFont /TestBangla-Regular", "/FontFile2", "/W [3 [500] 15 [500]]"} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("output lacks %s", want)
+		}
+	}
+
+	streams := inflateStreams(t, out)
+	if !slices.ContainsFunc(streams, func(s string) bool { return strings.Contains(s, "<000F0003> Tj") }) {
+		t.Error("page content does not draw the shaped glyphs")
+	}
+	if !slices.ContainsFunc(streams, func(s string) bool {
+		return strings.Contains(s, "<0003> <0995>") && strings.Contains(s, "<000F> <09BF>")
+	}) {
+		t.Error("ToUnicode map does not map the glyphs back to their text")
+	}
+}
+
+var streamPattern = regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`)
+
+func inflateStreams(t *testing.T, pdf []byte) []string {
+	t.Helper()
+	var streams []string
+	for _, m := range streamPattern.FindAllSubmatch(pdf, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			t.Fatalf("stream is not Flate compressed: %v", err)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("failed to inflate stream: %v", err)
+		}
+		streams = append(streams, string(data))
+	}
+	return streams
+}
@@ -0,0 +1,655 @@
+package pdf
+
+import (
+	"errors"
+	"sort"
+)
+
+// featureTags are the OpenType features applied by the shaper. Each has a mask bit so
+// a feature can be limited to some glyphs of a syllable.
+var featureTags = []string{
+	"locl", "ccmp", "nukt", "akhn", "rphf", "rkrf", "blwf", "abvf", "half", "pstf", "vatu", "cjct",
+	"init", "pres", "abvs", "blws", "psts", "haln", "calt", "clig",
+}
+
+func featureMask(tags ...string) uint32 {
+	var mask uint32
+	for _, tag := range tags {
+		for i, t := range featureTags {
+			if t == tag {
+				mask |= 1 << i
+			}
+		}
+	}
+	return mask
+}
+
+// maxNesting bounds lookups applied from contextual lookups
+const maxNesting = 8
+
+// glyphInfo is a glyph being shaped
+type glyphInfo struct {
+	id   uint16
+	text []rune // the characters the glyph stands for
+	mask uint32 // features that may apply to the glyph
+	cat  uint8  // character category
+	pos  uint8  // position in the syllable
+}
+
+// gsub holds the glyph substitutions of a font for the script being shaped
+type gsub struct {
+	features map[string][]int // feature tag to lookup indices
+	lookups  []*lookup
+	classes  map[uint16]uint16 // glyph classes from GDEF: 1 base, 2 ligature, 3 mark
+}
+
+type lookup struct {
+	flag      uint16
+	subtables []subtable
+}
+
+// Lookup flags
+const (
+	ignoreBaseGlyphs = 0x2
+	ignoreLigatures  = 0x4
+	ignoreMarks      = 0x8
+)
+
+type subtable interface {
+	// apply substitutes at glyph i, returning the index to continue from
+	apply(s *substitution, l *lookup, i int) (int, bool)
+}
+
+// parseGSUB reads the substitutions of the Bangla script, or the default script for other fonts.
+// A malformed table disables shaping instead of rejecting the font.
+func parseGSUB(table, gdef sfnt) (g *gsub) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok && errors.Is(e, ErrMalformedFont) {
+				g = nil
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	g = &gsub{features: map[string][]int{}}
+	scriptList, featureList, lookupList := int(table.u16(4)), int(table.u16(6)), int(table.u16(8))
+
+	langSys := -1
+	scripts := int(table.u16(scriptList))
+	for _, want := range []string{"bng2", "beng", "DFLT", "latn"} {
+		for i := 0; i < scripts && langSys < 0; i++ {
+			rec := scriptList + 2 + 6*i
+			if table.tag(rec) != want {
+				continue
+			}
+			script := scriptList + int(table.u16(rec+4))
+			if def := int(table.u16(script)); def != 0 {
+				langSys = script + def
+			}
+		}
+		if langSys >= 0 {
+			break
+		}
+	}
+	if langSys < 0 {
+		return g
+	}
+
+	indices := []int{}
+	if required := table.u16(langSys + 2); required != 0xFFFF {
+		indices = append(indices, int(required))
+	}
+	for i := 0; i < int(table.u16(langSys+4)); i++ {
+		indices = append(indices, int(table.u16(langSys+6+2*i)))
+	}
+	for _, index := range indices {
+		rec := featureList + 2 + 6*index
+		tag, feature := table.tag(rec), featureList+int(table.u16(rec+4))
+		for i := 0; i < int(table.u16(feature+2)); i++ {
+			g.features[tag] = append(g.features[tag], int(table.u16(feature+4+2*i)))
+		}
+	}
+
+	g.lookups = make([]*lookup, table.u16(lookupList))
+	for i := range g.lookups {
+		g.lookups[i] = parseLookup(table, lookupList+int(table.u16(lookupList+2+2*i)))
+	}
+
+	if len(gdef) >= 6 {
+		if off := int(gdef.u16(4)); off != 0 {
+			g.classes = parseClassDef(gdef, off)
+		}
+	}
+	return g
+}
+
+func parseLookup(t sfnt, off int) *lookup {
+	kind, l := t.u16(off), &lookup{flag: t.u16(off + 2)}
+	for i := 0; i < int(t.u16(off+4)); i++ {
+		sub, k := off+int(t.u16(off+6+2*i)), kind
+		if k == 7 {
+			k = t.u16(sub + 2)
+			sub += int(t.u32(sub + 4))
+		}
+		if st := parseSubtable(t, k, sub); st != nil {
+			l.subtables = append(l.subtables, st)
+		}
+	}
+	return l
+}
+
+func parseSubtable(t sfnt, kind uint16, off int) subtable {
+	format := t.u16(off)
+	switch kind {
+	case 1:
+		s := &singleSubst{cov: parseCoverage(t, off+int(t.u16(off+2)))}
+		if format == 1 {
+			s.delta = int(t.i16(off + 4))
+			return s
+		}
+		s.subs = make([]uint16, t.u16(off+4))
+		for i := range s.subs {
+			s.subs[i] = t.u16(off + 6 + 2*i)
+		}
+		return s
+	case 2:
+		s := &multipleSubst{cov: parseCoverage(t, off+int(t.u16(off+2)))}
+		s.seqs = make([][]uint16, t.u16(off+4))
+		for i := range s.seqs {
+			seq := off + int(t.u16(off+6+2*i))
+			s.seqs[i] = glyphArray(t, seq+2, int(t.u16(seq)))
+		}
+		return s
+	case 4:
+		s := &ligatureSubst{cov: parseCoverage(t, off+int(t.u16(off+2)))}
+		s.sets = make([][]ligature, t.u16(off+4))
+		for i := range s.sets {
+			set := off + int(t.u16(off+6+2*i))
+			for j := 0; j < int(t.u16(set)); j++ {
+				lig := set + int(t.u16(set+2+2*j))
+				count := int(t.u16(lig + 2))
+				if count == 0 {
+					continue
+				}
+				s.sets[i] = append(s.sets[i], ligature{glyph: t.u16(lig), components: glyphArray(t, lig+4, count-1)})
+			}
+		}
+		return s
+	case 5, 6:
+		return parseContext(t, off, format, kind == 6)
+	}
+	return nil
+}
+
+func glyphArray(t sfnt, off, count int) []uint16 {
+	glyphs := make([]uint16, count)
+	for i := range glyphs {
+		glyphs[i] = t.u16(off + 2*i)
+	}
+	return glyphs
+}
+
+type coverage map[uint16]int
+
+func parseCoverage(t sfnt, off int) coverage {
+	cov := coverage{}
+	switch t.u16(off) {
+	case 1:
+		for i := 0; i < int(t.u16(off+2)); i++ {
+			cov[t.u16(off+4+2*i)] = i
+		}
+	case 2:
+		for i := 0; i < int(t.u16(off+2)); i++ {
+			rec := off + 4 + 6*i
+			start, end, index := int(t.u16(rec)), int(t.u16(rec+2)), int(t.u16(rec+4))
+			for id := start; id <= end; id++ {
+				cov[uint16(id)] = index + id - start
+			}
+		}
+	}
+	return cov
+}
+
+func parseClassDef(t sfnt, off int) map[uint16]uint16 {
+	classes := map[uint16]uint16{}
+	switch t.u16(off) {
+	case 1:
+		start := int(t.u16(off + 2))
+		for i := 0; i < int(t.u16(off+4)); i++ {
+			classes[uint16(start+i)] = t.u16(off + 6 + 2*i)
+		}
+	case 2:
+		for i := 0; i < int(t.u16(off+2)); i++ {
+			rec := off + 4 + 6*i
+			start, end, class := int(t.u16(rec)), int(t.u16(rec+2)), t.u16(rec+4)
+			for id := start; id <= end; id++ {
+				classes[uint16(id)] = class
+			}
+		}
+	}
+	return classes
+}
+
+type singleSubst struct {
+	cov   coverage
+	delta int
+	subs  []uint16 // nil when the delta is used
+}
+
+func (st *singleSubst) apply(s *substitution, _ *lookup, i int) (int, bool) {
+	index, ok := st.cov[s.buf[i].id]
+	if !ok {
+		return 0, false
+	}
+	if st.subs == nil {
+		s.buf[i].id = uint16(int(s.buf[i].id) + st.delta)
+	} else if index < len(st.subs) {
+		s.buf[i].id = st.subs[index]
+	} else {
+		return 0, false
+	}
+	return i + 1, true
+}
+
+type multipleSubst struct {
+	cov  coverage
+	seqs [][]uint16
+}
+
+func (st *multipleSubst) apply(s *substitution, _ *lookup, i int) (int, bool) {
+	index, ok := st.cov[s.buf[i].id]
+	if !ok || index >= len(st.seqs) {
+		return 0, false
+	}
+	seq := st.seqs[index]
+	replaced := make([]glyphInfo, len(seq))
+	for j, id := range seq {
+		replaced[j] = s.buf[i]
+		replaced[j].id = id
+		if j > 0 {
+			replaced[j].text = nil
+		}
+	}
+	s.buf = append(s.buf[:i], append(replaced, s.buf[i+1:]...)...)
+	return i + len(seq), true
+}
+
+type ligature struct {
+	glyph      uint16
+	components []uint16 // after the first glyph
+}
+
+type ligatureSubst struct {
+	cov  coverage
+	sets [][]ligature
+}
+
+func (st *ligatureSubst) apply(s *substitution, l *lookup, i int) (int, bool) {
+	index, ok := st.cov[s.buf[i].id]
+	if !ok || index >= len(st.sets) {
+		return 0, false
+	}
+	for _, lig := range st.sets[index] {
+		positions := []int{i}
+		for j, component := range lig.components {
+			next := s.next(l, positions[j])
+			if next < 0 || s.buf[next].id != component {
+				positions = nil
+				break
+			}
+			positions = append(positions, next)
+		}
+		if positions == nil {
+			continue
+		}
+
+		merged := s.buf[i]
+		merged.id = lig.glyph
+		merged.text = append([]rune(nil), merged.text...)
+		for _, p := range positions[1:] {
+			merged.text = append(merged.text, s.buf[p].text...)
+			if s.buf[p].pos == posBase {
+				merged.pos = posBase
+			}
+		}
+		s.buf[i] = merged
+		for k := len(positions) - 1; k > 0; k-- {
+			s.buf = append(s.buf[:positions[k]], s.buf[positions[k]+1:]...)
+		}
+		return i + 1, true
+	}
+	return 0, false
+}
+
+type matcher func(id uint16) bool
+
+type substRecord struct {
+	index  int // position in the input sequence
+	lookup int
+}
+
+type contextRule struct {
+	backtrack []matcher // nearest glyph first
+	input     []matcher // after the first glyph
+	lookahead []matcher
+	records   []substRecord
+}
+
+// contextSubst is a contextual or chaining contextual substitution in any of its three formats
+type contextSubst struct {
+	cov     coverage
+	classes map[uint16]uint16        // input classes of format 2
+	sets    map[uint16][]contextRule // rules by first glyph (format 1) or its class (format 2)
+	rules   []contextRule            // the single rule of format 3
+}
+
+func parseContext(t sfnt, off int, format uint16, chained bool) subtable {
+	glyphIs := func(id uint16) matcher { return func(g uint16) bool { return g == id } }
+	classIs := func(classes map[uint16]uint16, class uint16) matcher {
+		return func(g uint16) bool { return classes[g] == class }
+	}
+	covers := func(cov coverage) matcher {
+		return func(g uint16) bool { _, ok := cov[g]; return ok }
+	}
+	records := func(at, count int) []substRecord {
+		recs := make([]substRecord, count)
+		for i := range recs {
+			recs[i] = substRecord{index: int(t.u16(at + 4*i)), lookup: int(t.u16(at + 4*i + 2))}
+		}
+		return recs
+	}
+
+	switch format {
+	case 1, 2:
+		st := &contextSubst{cov: parseCoverage(t, off+int(t.u16(off+2))), sets: map[uint16][]contextRule{}}
+		var backtrackClasses, lookaheadClasses map[uint16]uint16
+		classDef := func(at int) map[uint16]uint16 {
+			if rel := int(t.u16(at)); rel != 0 {
+				return parseClassDef(t, off+rel)
+			}
+			return map[uint16]uint16{}
+		}
+		setsAt := off + 4
+		if format == 2 {
+			if chained {
+				backtrackClasses, st.classes, lookaheadClasses = classDef(off+4), classDef(off+6), classDef(off+8)
+				setsAt = off + 10
+			} else {
+				st.classes = classDef(off + 4)
+				setsAt = off + 6
+			}
+		}
+		match := func(value uint16, classes map[uint16]uint16) matcher {
+			if format == 1 {
+				return glyphIs(value)
+			}
+			return classIs(classes, value)
+		}
+		sequence := func(at, count int, classes map[uint16]uint16) []matcher {
+			ms := make([]matcher, count)
+			for k := range ms {
+				ms[k] = match(t.u16(at+2*k), classes)
+			}
+			return ms
+		}
+
+		for key := 0; key < int(t.u16(setsAt)); key++ {
+			setOff := int(t.u16(setsAt + 2 + 2*key))
+			if setOff == 0 {
+				continue
+			}
+			set := off + setOff
+			for j := 0; j < int(t.u16(set)); j++ {
+				var rule contextRule
+				at := set + int(t.u16(set+2+2*j))
+				if chained {
+					// backtrack count and glyphs, input count and glyphs, lookahead count and glyphs, records
+					count := int(t.u16(at))
+					rule.backtrack = sequence(at+2, count, backtrackClasses)
+					at += 2 + 2*count
+					count = max(int(t.u16(at))-1, 0)
+					rule.input = sequence(at+2, count, st.classes)
+					at += 2 + 2*count
+					count = int(t.u16(at))
+					rule.lookahead = sequence(at+2, count, lookaheadClasses)
+					at += 2 + 2*count
+					rule.records = records(at+2, int(t.u16(at)))
+				} else {
+					// input count, record count, input glyphs, records
+					count, recordCount := max(int(t.u16(at))-1, 0), int(t.u16(at+2))
+					rule.input = sequence(at+4, count, st.classes)
+					rule.records = records(at+4+2*count, recordCount)
+				}
+				st.sets[uint16(key)] = append(st.sets[uint16(key)], rule)
+			}
+		}
+		if format == 1 {
+			// format 1 rule sets follow the coverage order, key them by glyph instead
+			byGlyph := map[uint16][]contextRule{}
+			for id, index := range st.cov {
+				byGlyph[id] = st.sets[uint16(index)]
+			}
+			st.sets = byGlyph
+		}
+		return st
+
+	case 3:
+		rule, at := contextRule{}, off+2
+		coverages := func(count int) []matcher {
+			ms := make([]matcher, count)
+			for k := range ms {
+				ms[k] = covers(parseCoverage(t, off+int(t.u16(at+2*k))))
+			}
+			at += 2 * count
+			return ms
+		}
+		var input []matcher
+		var firstCov coverage
+		if chained {
+			count := int(t.u16(at))
+			at += 2
+			rule.backtrack = coverages(count)
+			count = int(t.u16(at))
+			at += 2
+			if count == 0 {
+				return nil
+			}
+			firstCov = parseCoverage(t, off+int(t.u16(at)))
+			input = coverages(count)
+			count = int(t.u16(at))
+			at += 2
+			rule.lookahead = coverages(count)
+			count = int(t.u16(at))
+			rule.records = records(at+2, count)
+		} else {
+			count, recordCount := int(t.u16(at)), int(t.u16(at+2))
+			at += 4
+			if count == 0 {
+				return nil
+			}
+			firstCov = parseCoverage(t, off+int(t.u16(at)))
+			input = coverages(count)
+			rule.records = records(at, recordCount)
+		}
+		rule.input = input[1:]
+		return &contextSubst{cov: firstCov, rules: []contextRule{rule}}
+	}
+	return nil
+}
+
+func (st *contextSubst) candidates(id uint16) []contextRule {
+	if _, ok := st.cov[id]; !ok {
+		return nil
+	}
+	switch {
+	case st.rules != nil:
+		return st.rules
+	case st.classes != nil:
+		return st.sets[st.classes[id]]
+	}
+	return st.sets[id]
+}
+
+func (st *contextSubst) apply(s *substitution, l *lookup, i int) (int, bool) {
+	for _, rule := range st.candidates(s.buf[i].id) {
+		positions := []int{i}
+		for _, m := range rule.input {
+			next := s.next(l, positions[len(positions)-1])
+			if next < 0 || !m(s.buf[next].id) {
+				positions = nil
+				break
+			}
+			positions = append(positions, next)
+		}
+		if positions == nil || !s.matches(l, rule.backtrack, i, -1) || !s.matches(l, rule.lookahead, positions[len(positions)-1], 1) {
+			continue
+		}
+
+		if s.depth < maxNesting {
+			s.depth++
+			for _, rec := range rule.records {
+				if rec.index >= len(positions) || rec.lookup >= len(s.g.lookups) {
+					continue
+				}
+				at, before := positions[rec.index], len(s.buf)
+				s.applyAt(s.g.lookups[rec.lookup], at)
+				if delta := len(s.buf) - before; delta != 0 {
+					for k := range positions {
+						if positions[k] > at {
+							positions[k] = max(positions[k]+delta, at)
+						}
+					}
+				}
+			}
+			s.depth--
+		}
+		return positions[len(positions)-1] + 1, true
+	}
+	return 0, false
+}
+
+// substitution applies lookups to a glyph buffer
+type substitution struct {
+	g     *gsub
+	buf   []glyphInfo
+	depth int
+}
+
+func (s *substitution) skip(l *lookup, id uint16) bool {
+	switch s.g.classes[id] {
+	case 1:
+		return l.flag&ignoreBaseGlyphs != 0
+	case 2:
+		return l.flag&ignoreLigatures != 0
+	case 3:
+		return l.flag&ignoreMarks != 0
+	}
+	return false
+}
+
+// next returns the index of the next glyph the lookup does not skip, or -1
+func (s *substitution) next(l *lookup, i int) int {
+	for i++; i < len(s.buf); i++ {
+		if !s.skip(l, s.buf[i].id) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *substitution) prev(l *lookup, i int) int {
+	for i--; i >= 0; i-- {
+		if !s.skip(l, s.buf[i].id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matches checks a backtrack (dir -1) or lookahead (dir 1) sequence next to glyph i
+func (s *substitution) matches(l *lookup, ms []matcher, i, dir int) bool {
+	for _, m := range ms {
+		if dir < 0 {
+			i = s.prev(l, i)
+		} else {
+			i = s.next(l, i)
+		}
+		if i < 0 || !m(s.buf[i].id) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *substitution) applyAt(l *lookup, i int) (int, bool) {
+	for _, st := range l.subtables {
+		if next, ok := st.apply(s, l, i); ok {
+			return next, true
+		}
+	}
+	return 0, false
+}
+
+func (s *substitution) applyLookup(l *lookup, mask uint32) {
+	for i := 0; i < len(s.buf); {
+		if s.buf[i].mask&mask == 0 || s.skip(l, s.buf[i].id) {
+			i++
+			continue
+		}
+		before := len(s.buf)
+		next, ok := s.applyAt(l, i)
+		if !ok || (next <= i && len(s.buf) >= before) {
+			next = i + 1
+		}
+		i = next
+	}
+}
+
+// apply runs the lookups of features together, in lookup order, on the glyphs whose masks allow them
+func (g *gsub) apply(buf []glyphInfo, tags ...string) []glyphInfo {
+	if g == nil {
+		return buf
+	}
+	masks := map[int]uint32{}
+	for _, tag := range tags {
+		for _, index := range g.features[tag] {
+			masks[index] |= featureMask(tag)
+		}
+	}
+	indices := make([]int, 0, len(masks))
+	for index := range masks {
+		if index < len(g.lookups) {
+			indices = append(indices, index)
+		}
+	}
+	sort.Ints(indices)
+
+	s := &substitution{g: g, buf: buf}
+	for _, index := range indices {
+		s.applyLookup(g.lookups[index], masks[index])
+	}
+	return s.buf
+}
+
+// wouldSubstitute reports whether a feature changes a glyph sequence starting at its first glyph
+func (g *gsub) wouldSubstitute(tag string, ids ...uint16) bool {
+	if g == nil {
+		return false
+	}
+	for _, index := range g.features[tag] {
+		if index >= len(g.lookups) {
+			continue
+		}
+		buf := make([]glyphInfo, len(ids))
+		for i, id := range ids {
+			buf[i] = glyphInfo{id: id, mask: ^uint32(0)}
+		}
+		s := &substitution{g: g, buf: buf}
+		if _, ok := s.applyAt(g.lookups[index], 0); ok {
+			return true
+		}
+	}
+	return false
+}
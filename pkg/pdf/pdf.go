@@ -0,0 +1,322 @@
+// Package pdf writes simple PDF documents using only the standard library: text in the standard Helvetica
+// fonts or embedded TrueType fonts, lines, filled rectangles and links. Bangla text in TrueType fonts is
+// shaped with the font's OpenType substitutions so conjuncts and vowel signs render correctly.
+//
+// Coordinates are in points with the origin at the top left corner of the page; text is placed on its baseline.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// A4 page size in points
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Color is an RGB color
+type Color struct {
+	R, G, B uint8
+}
+
+func (c Color) operands() string {
+	return fmt.Sprintf("%s %s %s", number(float64(c.R)/255), number(float64(c.G)/255), number(float64(c.B)/255))
+}
+
+// Document is a PDF document being built. It is not safe for concurrent use.
+type Document struct {
+	width, height float64
+	pages         []*Page
+	fonts         []*Font
+	title, author string
+	created       time.Time
+}
+
+// New starts a document whose pages have the given size in points
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height, created: time.Now()}
+}
+
+// SetInfo sets the title and author shown by PDF viewers
+func (d *Document) SetInfo(title, author string) {
+	d.title, d.author = title, author
+}
+
+// Width returns the page width in points
+func (d *Document) Width() float64 {
+	return d.width
+}
+
+// Height returns the page height in points
+func (d *Document) Height() float64 {
+	return d.height
+}
+
+// Pages returns the pages added so far
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+// Page is a page of a document. Content can be drawn on any page until the document is written.
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+	fonts   map[*Font]bool
+	links   []link
+}
+
+type link struct {
+	x, y, w, h float64
+	uri        string
+}
+
+// AddPage appends a blank page
+func (d *Document) AddPage() *Page {
+	page := &Page{doc: d, fonts: map[*Font]bool{}}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// y converts a top-down coordinate to the PDF coordinate system
+func (p *Page) y(y float64) float64 {
+	return p.doc.height - y
+}
+
+// Rect fills a rectangle whose top left corner is at x, y
+func (p *Page) Rect(x, y, w, h float64, fill Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n", fill.operands(), number(x), number(p.y(y+h)), number(w), number(h))
+}
+
+// Line strokes a straight line
+func (p *Page) Line(x1, y1, x2, y2, width float64, stroke Color) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s m %s %s l S\n", stroke.operands(), number(width),
+		number(x1), number(p.y(y1)), number(x2), number(p.y(y2)))
+}
+
+// Text draws a single line of text with its baseline at y and returns its width
+func (p *Page) Text(x, y float64, face Face, size float64, color Color, text string) float64 {
+	start := x
+	for _, run := range face.runs(text) {
+		glyphs := run.font.layout(run.text)
+		if len(glyphs) == 0 {
+			continue
+		}
+		p.fonts[run.font] = true
+		fmt.Fprintf(&p.content, "BT /%s %s Tf %s rg %s %s Td <%s> Tj ET\n", run.font.name, number(size), color.operands(),
+			number(x), number(p.y(y)), run.font.encode(glyphs))
+		x += glyphWidth(glyphs, size)
+	}
+	return x - start
+}
+
+// Link makes a rectangle whose top left corner is at x, y open a URI when clicked
+func (p *Page) Link(x, y, w, h float64, uri string) {
+	p.links = append(p.links, link{x: x, y: y, w: w, h: h, uri: uri})
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTo renders the document to w
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	// Only fonts used on some page are written, so a fallback font that was never needed is not embedded
+	var fonts []*Font
+	for _, font := range d.fonts {
+		for _, page := range d.pages {
+			if page.fonts[font] {
+				fonts = append(fonts, font)
+				break
+			}
+		}
+	}
+
+	out := &writer{}
+	catalog, pages, info := out.alloc(), out.alloc(), out.alloc()
+	for _, font := range fonts {
+		font.obj = out.alloc()
+	}
+	pageObjs := make([]int, len(d.pages))
+	for i := range d.pages {
+		pageObjs[i] = out.alloc()
+	}
+
+	out.buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+
+	out.begin(catalog)
+	fmt.Fprintf(&out.buf, "<< /Type /Catalog /Pages %d 0 R >>", pages)
+	out.end()
+
+	kids := make([]string, len(pageObjs))
+	for i, obj := range pageObjs {
+		kids[i] = fmt.Sprintf("%d 0 R", obj)
+	}
+	out.begin(pages)
+	fmt.Fprintf(&out.buf, "<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>",
+		strings.Join(kids, " "), len(kids), number(d.width), number(d.height))
+	out.end()
+
+	out.begin(info)
+	fmt.Fprintf(&out.buf, "<< /Producer %s /CreationDate %s", textString("bdSeeker"), textString(d.created.UTC().Format("D:20060102150405Z")))
+	if d.title != "" {
+		fmt.Fprintf(&out.buf, " /Title %s", textString(d.title))
+	}
+	if d.author != "" {
+		fmt.Fprintf(&out.buf, " /Author %s", textString(d.author))
+	}
+	out.buf.WriteString(" >>")
+	out.end()
+
+	for _, font := range fonts {
+		if err := font.write(out); err != nil {
+			return 0, err
+		}
+	}
+
+	for i, page := range d.pages {
+		content := out.alloc()
+		var resources []string
+		for _, font := range fonts {
+			if page.fonts[font] {
+				resources = append(resources, fmt.Sprintf("/%s %d 0 R", font.name, font.obj))
+			}
+		}
+		var annots []string
+		for _, l := range page.links {
+			annot := out.alloc()
+			out.begin(annot)
+			fmt.Fprintf(&out.buf, "<< /Type /Annot /Subtype /Link /Rect [%s %s %s %s] /Border [0 0 0] /A << /S /URI /URI %s >> >>",
+				number(l.x), number(page.y(l.y+l.h)), number(l.x+l.w), number(page.y(l.y)), textString(l.uri))
+			out.end()
+			annots = append(annots, fmt.Sprintf("%d 0 R", annot))
+		}
+
+		out.begin(pageObjs[i])
+		fmt.Fprintf(&out.buf, "<< /Type /Page /Parent %d 0 R /Resources << /Font << %s >> >> /Contents %d 0 R",
+			pages, strings.Join(resources, " "), content)
+		if len(annots) > 0 {
+			fmt.Fprintf(&out.buf, " /Annots [%s]", strings.Join(annots, " "))
+		}
+		out.buf.WriteString(" >>")
+		out.end()
+
+		if err := out.stream(content, "", page.content.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+
+	xref := out.buf.Len()
+	fmt.Fprintf(&out.buf, "xref\n0 %d\n0000000000 65535 f \n", len(out.offsets)+1)
+	for _, offset := range out.offsets {
+		fmt.Fprintf(&out.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out.buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(out.offsets)+1, catalog, info, xref)
+
+	n, err := w.Write(out.buf.Bytes())
+	return int64(n), err
+}
+
+// writer numbers objects and records their offsets for the cross-reference table
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) alloc() int {
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets)
+}
+
+func (w *writer) begin(obj int) {
+	w.offsets[obj-1] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n", obj)
+}
+
+func (w *writer) end() {
+	w.buf.WriteString("\nendobj\n")
+}
+
+// stream writes a Flate compressed stream object with extra dictionary entries
+func (w *writer) stream(obj int, dict string, data []byte) error {
+	compressed, err := deflate(data)
+	if err != nil {
+		return err
+	}
+	w.compressed(obj, dict, compressed)
+	return nil
+}
+
+// compressed writes a stream object whose data is already Flate compressed
+func (w *writer) compressed(obj int, dict string, data []byte) {
+	w.begin(obj)
+	fmt.Fprintf(&w.buf, "<< /Length %d /Filter /FlateDecode%s >>\nstream\n", len(data), dict)
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream")
+	w.end()
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// number formats a coordinate without trailing zeros
+func number(f float64) string {
+	s := fmt.Sprintf("%.3f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// textString encodes a string for the document information and annotations, as UTF-16 when it is not ASCII
+func textString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r >= 0x80 || r < 0x20 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return "(" + strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s) + ")"
+	}
+	return "<FEFF" + utf16Hex([]rune(s)) + ">"
+}
+
+func utf16Hex(runes []rune) string {
+	var sb strings.Builder
+	for _, r := range runes {
+		if r >= 0x10000 {
+			r -= 0x10000
+			fmt.Fprintf(&sb, "%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+			continue
+		}
+		fmt.Fprintf(&sb, "%04X", r)
+	}
+	return sb.String()
+}
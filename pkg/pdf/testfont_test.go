@@ -0,0 +1,209 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"sort"
+	"testing"
+	"unicode/utf16"
+)
+
+// Glyphs of the test font. The font has no outlines, only what the shaper reads: metrics, a character
+// map and Bangla substitutions for a reph, a conjunct, a half form, ya-phala and an initial vowel sign.
+const (
+	gSpace uint16 = iota + 1
+	gA
+	gKa
+	gSsa
+	gRa
+	gHasanta
+	gI
+	gAa
+	gE
+	gAuLength
+	gYa
+	gReph       // rphf: ra hasanta
+	gKssa       // akhn: ka hasanta ssa
+	gYaPhala    // pstf: hasanta ya
+	gIInit      // init: i sign at the start of a word
+	gKaHalf     // half: ka hasanta
+	gKaBeforeAa // pres: ka followed by the aa sign
+	numTestGlyphs
+)
+
+const testAdvance = 500 // in font units of a 1000 unit em
+
+var testCmap = map[rune]uint16{
+	' ': gSpace, 'A': gA, 'ক': gKa, 'ষ': gSsa, 'র': gRa, '্': gHasanta,
+	'ি': gI, 'া': gAa, 'ে': gE, 'ৗ': gAuLength, 'য': gYa,
+}
+
+// be builds big-endian binary data
+type be []byte
+
+func (b be) u16(vs ...int) be {
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint16(b, uint16(v))
+	}
+	return b
+}
+
+func (b be) u32(vs ...int) be {
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint32(b, uint32(v))
+	}
+	return b
+}
+
+func (b be) tag(tag string) be {
+	return append(b, tag...)
+}
+
+// testTables returns the tables of the test font; tests change them to build broken fonts
+func testTables() map[string][]byte {
+	head := make(be, 54)
+	binary.BigEndian.PutUint32(head, 0x00010000)
+	binary.BigEndian.PutUint16(head[18:], 1000)
+	for i, v := range []int16{0, -250, 1000, 900} {
+		binary.BigEndian.PutUint16(head[36+2*i:], uint16(v))
+	}
+
+	hhea := make(be, 36)
+	binary.BigEndian.PutUint32(hhea, 0x00010000)
+	binary.BigEndian.PutUint16(hhea[4:], 900)
+	binary.BigEndian.PutUint16(hhea[6:], uint16(0x10000-250))
+	binary.BigEndian.PutUint16(hhea[34:], uint16(numTestGlyphs))
+
+	var hmtx be
+	for i := 0; i < int(numTestGlyphs); i++ {
+		hmtx = hmtx.u16(testAdvance, 0)
+	}
+
+	runes := make([]rune, 0, len(testCmap))
+	for r := range testCmap {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	cmap := be{}.u16(0, 1).u16(3, 10).u32(12)
+	cmap = cmap.u16(12, 0).u32(16+12*len(runes), 0, len(runes))
+	for _, r := range runes {
+		cmap = cmap.u32(int(r), int(r), int(testCmap[r]))
+	}
+
+	name := utf16.Encode([]rune("Test Bangla-Regular"))
+	nameTable := be{}.u16(0, 1, 18).u16(3, 1, 0x409, 6, 2*len(name), 0)
+	for _, unit := range name {
+		nameTable = nameTable.u16(int(unit))
+	}
+
+	return map[string][]byte{
+		"head": head,
+		"hhea": hhea,
+		"hmtx": hmtx,
+		"maxp": be{}.u32(0x00005000).u16(int(numTestGlyphs)),
+		"cmap": cmap,
+		"glyf": make([]byte, 4),
+		"name": nameTable,
+		"OS/2": be{}.u16(0, 500, 400, 0, 0),
+		"GSUB": testGSUB(),
+	}
+}
+
+// testGSUB has one lookup per feature for the bng2 script, and a single substitution only reached
+// from the contextual pres lookup
+func testGSUB() []byte {
+	features := []struct {
+		tag    string
+		lookup be
+	}{
+		{"akhn", lookupTable(4, ligatureSubtable(gKssa, gKa, gHasanta, gSsa))},
+		{"rphf", lookupTable(4, ligatureSubtable(gReph, gRa, gHasanta))},
+		{"half", lookupTable(4, ligatureSubtable(gKaHalf, gKa, gHasanta))},
+		{"pstf", lookupTable(4, ligatureSubtable(gYaPhala, gHasanta, gYa))},
+		{"init", lookupTable(1, be{}.u16(2, 8, 1, int(gIInit)).u16(1, 1, int(gI)))},
+		// ka followed by the aa sign takes its alternate form from lookup 6, which no feature lists
+		{"pres", lookupTable(6, be{}.u16(3, 0, 1, 18, 1, 24, 1, 0, 6).u16(1, 1, int(gKa)).u16(1, 1, int(gAa)))},
+	}
+	lookups := make([]be, 0, len(features)+1)
+	for _, f := range features {
+		lookups = append(lookups, f.lookup)
+	}
+	lookups = append(lookups, lookupTable(1, be{}.u16(1, 6, int(gKaBeforeAa)-int(gKa)).u16(1, 1, int(gKa))))
+
+	scriptList := be{}.u16(1).tag("bng2").u16(8).u16(4, 0).u16(0, 0xFFFF, len(features))
+	for i := range features {
+		scriptList = scriptList.u16(i)
+	}
+
+	featureList := be{}.u16(len(features))
+	for i, f := range features {
+		featureList = featureList.tag(f.tag).u16(2 + 6*len(features) + 6*i)
+	}
+	for i := range features {
+		featureList = featureList.u16(0, 1, i)
+	}
+
+	lookupList := be{}.u16(len(lookups))
+	at := 2 + 2*len(lookups)
+	for _, l := range lookups {
+		lookupList = lookupList.u16(at)
+		at += len(l)
+	}
+	for _, l := range lookups {
+		lookupList = append(lookupList, l...)
+	}
+
+	header := be{}.u32(0x00010000).u16(10, 10+len(scriptList), 10+len(scriptList)+len(featureList))
+	return append(append(append(header, scriptList...), featureList...), lookupList...)
+}
+
+func lookupTable(kind int, subtable be) be {
+	return append(be{}.u16(kind, 0, 1, 8), subtable...)
+}
+
+// ligatureSubtable substitutes one ligature for a sequence of glyphs
+func ligatureSubtable(lig uint16, first uint16, rest ...uint16) be {
+	sub := be{}.u16(1, 8, 1, 14).u16(1, 1, int(first)).u16(1, 4).u16(int(lig), len(rest)+1)
+	for _, id := range rest {
+		sub = sub.u16(int(id))
+	}
+	return sub
+}
+
+// buildFont assembles tables into a TrueType file
+func buildFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	font := be{}.u32(0x00010000).u16(len(tags), 0, 0, 0)
+	at := 12 + 16*len(tags)
+	var data be
+	for _, tag := range tags {
+		table := tables[tag]
+		font = font.tag(tag).u32(0, at+len(data), len(table))
+		data = append(data, table...)
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+	}
+	return append(font, data...)
+}
+
+func testFont(t *testing.T) *TrueType {
+	t.Helper()
+	tt, err := ParseTrueType(buildFont(testTables()))
+	if err != nil {
+		t.Fatalf("ParseTrueType() = %v", err)
+	}
+	return tt
+}
+
+func glyphIDs(glyphs []glyph) []uint16 {
+	ids := make([]uint16, len(glyphs))
+	for i, g := range glyphs {
+		ids[i] = g.id
+	}
+	return ids
+}
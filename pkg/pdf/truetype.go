@@ -0,0 +1,305 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"unicode/utf16"
+)
+
+var (
+	ErrMalformedFont       = errors.New("malformed TrueType font")
+	ErrUnsupportedFont     = errors.New("unsupported font, use a TrueType (.ttf) font with glyf outlines")
+	ErrEmbeddingRestricted = errors.New("the font license does not allow embedding")
+)
+
+// TrueType is a parsed TrueType font. It is safe for concurrent use by several documents.
+type TrueType struct {
+	data        []byte
+	name        string // PostScript name
+	unitsPerEm  int
+	bbox        [4]int16
+	ascent      int16
+	descent     int16
+	capHeight   int16
+	italicAngle float64
+	flags       int
+	stemV       int
+	advances    []uint16
+	cmap        map[rune]uint16
+	gsub        *gsub
+
+	once sync.Once
+	file []byte
+	err  error
+}
+
+// sfnt reads big-endian values from font tables, panicking with ErrMalformedFont when out of range
+type sfnt []byte
+
+func (b sfnt) check(off, n int) {
+	if off < 0 || off+n > len(b) {
+		panic(ErrMalformedFont)
+	}
+}
+
+func (b sfnt) u16(off int) uint16 {
+	b.check(off, 2)
+	return binary.BigEndian.Uint16(b[off:])
+}
+
+func (b sfnt) i16(off int) int16 {
+	return int16(b.u16(off))
+}
+
+func (b sfnt) u32(off int) uint32 {
+	b.check(off, 4)
+	return binary.BigEndian.Uint32(b[off:])
+}
+
+func (b sfnt) tag(off int) string {
+	b.check(off, 4)
+	return string(b[off : off+4])
+}
+
+// ParseTrueType reads the tables needed to embed a font and shape text with it
+func ParseTrueType(data []byte) (tt *TrueType, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok && errors.Is(e, ErrMalformedFont) {
+				tt, err = nil, ErrMalformedFont
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	b := sfnt(data)
+	switch b.tag(0) {
+	case "\x00\x01\x00\x00", "true":
+	default:
+		return nil, ErrUnsupportedFont
+	}
+
+	tables := map[string]sfnt{}
+	for i := 0; i < int(b.u16(4)); i++ {
+		rec := 12 + 16*i
+		off, length := int(b.u32(rec+8)), int(b.u32(rec+12))
+		b.check(off, length)
+		tables[b.tag(rec)] = b[off : off+length]
+	}
+	for _, name := range []string{"head", "hhea", "hmtx", "maxp", "cmap", "glyf"} {
+		if tables[name] == nil {
+			return nil, ErrUnsupportedFont
+		}
+	}
+
+	tt = &TrueType{data: data, name: "EmbeddedFont", flags: 4}
+
+	head := tables["head"]
+	tt.unitsPerEm = int(head.u16(18))
+	if tt.unitsPerEm == 0 {
+		return nil, ErrMalformedFont
+	}
+	tt.bbox = [4]int16{head.i16(36), head.i16(38), head.i16(40), head.i16(42)}
+
+	hhea := tables["hhea"]
+	tt.ascent, tt.descent = hhea.i16(4), hhea.i16(6)
+	tt.capHeight = tt.ascent
+
+	numGlyphs := int(tables["maxp"].u16(4))
+	numMetrics := int(hhea.u16(34))
+	if numMetrics == 0 || numMetrics > numGlyphs {
+		return nil, ErrMalformedFont
+	}
+	hmtx := tables["hmtx"]
+	tt.advances = make([]uint16, numGlyphs)
+	for i := range tt.advances {
+		if i < numMetrics {
+			tt.advances[i] = hmtx.u16(4 * i)
+		} else {
+			tt.advances[i] = tt.advances[numMetrics-1]
+		}
+	}
+
+	weight := 400
+	if os2 := tables["OS/2"]; len(os2) >= 10 {
+		weight = int(os2.u16(4))
+		fsType := os2.u16(8)
+		if fsType&0x000F == 0x0002 {
+			return nil, ErrEmbeddingRestricted
+		}
+		if os2.u16(0) >= 2 && len(os2) >= 90 {
+			tt.capHeight = os2.i16(88)
+		}
+	}
+	tt.stemV = 50 + weight*weight/4225
+	if post := tables["post"]; len(post) >= 16 {
+		tt.italicAngle = float64(int32(post.u32(4))) / 65536
+		if post.u32(12) != 0 {
+			tt.flags |= 1
+		}
+		if tt.italicAngle != 0 {
+			tt.flags |= 64
+		}
+	}
+	if name := postScriptName(tables["name"]); name != "" {
+		tt.name = name
+	}
+
+	tt.cmap = parseCmap(tables["cmap"])
+	if len(tt.cmap) == 0 {
+		return nil, ErrUnsupportedFont
+	}
+	if tables["GSUB"] != nil {
+		tt.gsub = parseGSUB(tables["GSUB"], tables["GDEF"])
+	}
+	return tt, nil
+}
+
+// postScriptName reads name ID 6, keeping only the characters allowed in PDF font names
+func postScriptName(table sfnt) string {
+	if len(table) < 6 {
+		return ""
+	}
+	count, storage := int(table.u16(2)), int(table.u16(4))
+	for i := 0; i < count; i++ {
+		rec := 6 + 12*i
+		platform, nameID := table.u16(rec), table.u16(rec+6)
+		if nameID != 6 || (platform != 1 && platform != 3) {
+			continue
+		}
+		length, off := int(table.u16(rec+8)), storage+int(table.u16(rec+10))
+		table.check(off, length)
+		raw := table[off : off+length]
+
+		var name string
+		if platform == 3 {
+			units := make([]uint16, len(raw)/2)
+			for j := range units {
+				units[j] = binary.BigEndian.Uint16(raw[2*j:])
+			}
+			name = string(utf16.Decode(units))
+		} else {
+			name = string(raw)
+		}
+		name = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+				return r
+			}
+			return -1
+		}, name)
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// parseCmap reads the Unicode character map, preferring the full repertoire table (format 12)
+func parseCmap(table sfnt) map[rune]uint16 {
+	var format4, format12 int
+	for i := 0; i < int(table.u16(2)); i++ {
+		rec := 4 + 8*i
+		platform, encoding, off := table.u16(rec), table.u16(rec+2), int(table.u32(rec+4))
+		if platform != 0 && !(platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+		switch table.u16(off) {
+		case 4:
+			format4 = off
+		case 12:
+			format12 = off
+		}
+	}
+
+	cmap := map[rune]uint16{}
+	switch {
+	case format12 != 0:
+		groups := int(table.u32(format12 + 12))
+		for i := 0; i < groups; i++ {
+			rec := format12 + 16 + 12*i
+			start, end, gid := table.u32(rec), table.u32(rec+4), table.u32(rec+8)
+			if end < start || end > 0x10FFFF || end-start > 0xFFFF {
+				panic(ErrMalformedFont)
+			}
+			for r := start; r <= end; r++ {
+				cmap[rune(r)] = uint16(gid + r - start)
+			}
+		}
+	case format4 != 0:
+		segments := int(table.u16(format4+6)) / 2
+		ends, starts := format4+14, format4+16+2*segments
+		deltas, ranges := starts+2*segments, starts+4*segments
+		for i := 0; i < segments; i++ {
+			start, end := int(table.u16(starts+2*i)), int(table.u16(ends+2*i))
+			delta, rangeOffset := int(table.u16(deltas+2*i)), int(table.u16(ranges+2*i))
+			for r := start; r <= end && r != 0xFFFF; r++ {
+				gid := 0
+				if rangeOffset == 0 {
+					gid = (r + delta) & 0xFFFF
+				} else if g := int(table.u16(ranges + 2*i + rangeOffset + 2*(r-start))); g != 0 {
+					gid = (g + delta) & 0xFFFF
+				}
+				if gid != 0 {
+					cmap[rune(r)] = uint16(gid)
+				}
+			}
+		}
+	}
+	return cmap
+}
+
+// Has reports whether the font has a glyph for a character
+func (tt *TrueType) Has(r rune) bool {
+	_, ok := tt.cmap[r]
+	return ok || isJoiner(r)
+}
+
+func (tt *TrueType) glyphID(r rune) uint16 {
+	return tt.cmap[r]
+}
+
+// advance returns a glyph's advance width in thousandths of the font size
+func (tt *TrueType) advance(id uint16) int {
+	if int(id) >= len(tt.advances) {
+		return 0
+	}
+	return tt.scale(int(tt.advances[id]))
+}
+
+func (tt *TrueType) scale(v int) int {
+	return v * 1000 / tt.unitsPerEm
+}
+
+// compressed returns the Flate compressed font file, compressing it once
+func (tt *TrueType) compressed() ([]byte, error) {
+	tt.once.Do(func() {
+		tt.file, tt.err = deflate(tt.data)
+	})
+	return tt.file, tt.err
+}
+
+// layout maps text to glyphs, shaping Bangla syllables
+func (tt *TrueType) layout(text string) []glyph {
+	runes := []rune(text)
+	glyphs := make([]glyph, 0, len(runes))
+	for i := 0; i < len(runes); {
+		if isBengali(runes[i]) {
+			end := bnSyllableEnd(runes, i)
+			wordStart := i == 0 || !isBengali(runes[i-1])
+			for _, info := range tt.shapeBengali(runes[i:end], wordStart) {
+				glyphs = append(glyphs, glyph{id: info.id, advance: tt.advance(info.id), text: info.text})
+			}
+			i = end
+			continue
+		}
+		if !isJoiner(runes[i]) || tt.glyphID(runes[i]) != 0 {
+			id := tt.glyphID(runes[i])
+			glyphs = append(glyphs, glyph{id: id, advance: tt.advance(id), text: []rune{runes[i]}})
+		}
+		i++
+	}
+	return glyphs
+}
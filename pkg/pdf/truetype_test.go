@@ -0,0 +1,110 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestParseTrueType(t *testing.T) {
+	tt := testFont(t)
+	if tt.name != "TestBangla-Regular" {
+		t.Errorf("name = %q, want the PostScript name without spaces", tt.name)
+	}
+	if tt.unitsPerEm != 1000 || tt.ascent != 900 || tt.descent != -250 {
+		t.Errorf("unitsPerEm, ascent, descent = %d, %d, %d", tt.unitsPerEm, tt.ascent, tt.descent)
+	}
+	if tt.gsub == nil || len(tt.gsub.features) != 6 || len(tt.gsub.lookups) != 7 {
+		t.Fatalf("gsub = %+v, want 6 features and 7 lookups", tt.gsub)
+	}
+	for r, id := range testCmap {
+		if got := tt.glyphID(r); got != id {
+			t.Errorf("glyphID(%q) = %d, want %d", r, got, id)
+		}
+		if !tt.Has(r) {
+			t.Errorf("Has(%q) = false", r)
+		}
+	}
+	if tt.Has('খ') || tt.Has('B') {
+		t.Error("Has() = true for a character without a glyph")
+	}
+	if !tt.Has(zwj) || !tt.Has(zwnj) {
+		t.Error("Has() = false for a joiner, which only steers shaping")
+	}
+	if got := tt.advance(gKa); got != testAdvance {
+		t.Errorf("advance() = %d, want %d", got, testAdvance)
+	}
+	if got := tt.advance(numTestGlyphs + 10); got != 0 {
+		t.Errorf("advance() of a missing glyph = %d, want 0", got)
+	}
+}
+
+func TestParseTrueTypeRejects(t *testing.T) {
+	otf := buildFont(testTables())
+	copy(otf, "OTTO")
+
+	withoutCmap := testTables()
+	delete(withoutCmap, "cmap")
+
+	restricted := testTables()
+	restricted["OS/2"] = be{}.u16(0, 500, 400, 0, 2)
+
+	noMetrics := testTables()
+	binary.BigEndian.PutUint16(noMetrics["hhea"][34:], 0)
+
+	tooManyMetrics := testTables()
+	binary.BigEndian.PutUint16(tooManyMetrics["hhea"][34:], uint16(numTestGlyphs)+1)
+
+	emptyCmap := testTables()
+	emptyCmap["cmap"] = be{}.u16(0, 1).u16(3, 10).u32(12).u16(12, 0).u32(16, 0, 0)
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty file", nil, ErrMalformedFont},
+		{"CFF outlines", otf, ErrUnsupportedFont},
+		{"missing table", buildFont(withoutCmap), ErrUnsupportedFont},
+		{"embedding restricted", buildFont(restricted), ErrEmbeddingRestricted},
+		{"no horizontal metrics", buildFont(noMetrics), ErrMalformedFont},
+		{"more metrics than glyphs", buildFont(tooManyMetrics), ErrMalformedFont},
+		{"no characters", buildFont(emptyCmap), ErrUnsupportedFont},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := ParseTrueType(tt.data)
+			if !errors.Is(err, tt.want) || font != nil {
+				t.Errorf("ParseTrueType() = %v, %v, want %v", font, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrueTypeTruncated(t *testing.T) {
+	data := buildFont(testTables())
+	// the last table may be followed by up to 3 bytes of padding
+	for n := 0; n < len(data)-3; n++ {
+		if _, err := ParseTrueType(data[:n]); err == nil {
+			t.Fatalf("ParseTrueType() of the first %d bytes succeeded", n)
+		}
+	}
+}
+
+// A corrupt GSUB table disables shaping but keeps the font usable
+func TestParseTrueTypeCorruptGSUB(t *testing.T) {
+	gsubLength := len(testGSUB())
+	for i := 0; i < gsubLength; i++ {
+		for _, value := range []byte{0x00, 0x7F, 0xFF} {
+			tables := testTables()
+			tables["GSUB"][i] = value
+			tt, err := ParseTrueType(buildFont(tables))
+			if err != nil {
+				t.Fatalf("ParseTrueType() with GSUB byte %d set to %#x = %v", i, value, err)
+			}
+			for _, text := range []string{"র্কি", "ক্ষো", "ক্যা", "ক্কৌ"} {
+				tt.layout(text)
+			}
+		}
+	}
+}
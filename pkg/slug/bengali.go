@@ -0,0 +1,125 @@
+package slug
+
+import "strings"
+
+// Bangla script transliteration following common romanization of names,
+// e.g. "রহিম" -> "rahim", "ঢাকা" -> "dhaka", "বাংলা" -> "bangla".
+// Consonants carry an inherent "a" that is dropped before a vowel sign, a hasanta (virama) and at the end of a word.
+
+const (
+	bnHasanta      = '্'
+	bnNukta        = '়'
+	bnChandrabindu = 'ঁ'
+	bnKhandaTa     = 'ৎ'
+	bnYa           = 'য'
+	bnDigitZero    = '০'
+	bnDigitNine    = '৯'
+)
+
+var bnConsonants = map[rune]string{
+	'ক': "k", 'খ': "kh", 'গ': "g", 'ঘ': "gh", 'ঙ': "ng",
+	'চ': "ch", 'ছ': "chh", 'জ': "j", 'ঝ': "jh", 'ঞ': "n",
+	'ট': "t", 'ঠ': "th", 'ড': "d", 'ঢ': "dh", 'ণ': "n",
+	'ত': "t", 'থ': "th", 'দ': "d", 'ধ': "dh", 'ন': "n",
+	'প': "p", 'ফ': "f", 'ব': "b", 'ভ': "bh", 'ম': "m",
+	'য': "j", 'র': "r", 'ল': "l", 'শ': "sh", 'ষ': "sh",
+	'স': "s", 'হ': "h", 'ড়': "r", 'ঢ়': "rh", 'য়': "y",
+}
+
+var bnVowels = map[rune]string{
+	'অ': "a", 'আ': "a", 'ই': "i", 'ঈ': "i", 'উ': "u", 'ঊ': "u",
+	'ঋ': "ri", 'এ': "e", 'ঐ': "oi", 'ও': "o", 'ঔ': "ou",
+}
+
+var bnVowelSigns = map[rune]string{
+	'া': "a", 'ি': "i", 'ী': "i", 'ু': "u", 'ূ': "u",
+	'ৃ': "ri", 'ে': "e", 'ৈ': "oi", 'ো': "o", 'ৌ': "ou",
+}
+
+var bnSigns = map[rune]string{
+	'ং': "ng", 'ঃ': "h",
+}
+
+// Decomposed nukta forms are folded into their precomposed letters before transliteration
+var bnNuktaForms = strings.NewReplacer(
+	"ড়", "ড়",
+	"ঢ়", "ঢ়",
+	"য়", "য়",
+)
+
+func transliterateBengali(text string) string {
+	if !containsBengali(text) {
+		return text
+	}
+	text = bnNuktaForms.Replace(text)
+
+	var b strings.Builder
+	inherent := false // the previous consonant still carries its inherent vowel
+	afterHasanta := false
+	for _, r := range text {
+		if latin, ok := bnConsonants[r]; ok {
+			if inherent {
+				b.WriteByte('a')
+			}
+			// A ya after a hasanta is the ya-phala, pronounced as a glide
+			if r == bnYa && afterHasanta {
+				latin = "y"
+			}
+			b.WriteString(latin)
+			inherent, afterHasanta = true, false
+			continue
+		}
+		afterHasanta = false
+
+		switch {
+		case r == bnHasanta:
+			inherent, afterHasanta = false, true
+		case r == bnChandrabindu || r == bnNukta:
+			// nasalisation and stray nuktas have no Latin equivalent
+		case r == bnKhandaTa:
+			if inherent {
+				b.WriteByte('a')
+			}
+			b.WriteByte('t')
+			inherent = false
+		case r >= bnDigitZero && r <= bnDigitNine:
+			b.WriteByte(byte('0' + r - bnDigitZero))
+			inherent = false
+		default:
+			if latin, ok := bnVowelSigns[r]; ok {
+				b.WriteString(latin)
+				inherent = false
+				continue
+			}
+			if latin, ok := bnVowels[r]; ok {
+				if inherent {
+					b.WriteByte('a')
+				}
+				b.WriteString(latin)
+				inherent = false
+				continue
+			}
+			if latin, ok := bnSigns[r]; ok {
+				if inherent {
+					b.WriteByte('a')
+				}
+				b.WriteString(latin)
+				inherent = false
+				continue
+			}
+			// Anything else ends the word, which drops the inherent vowel
+			b.WriteRune(r)
+			inherent = false
+		}
+	}
+	return b.String()
+}
+
+func containsBengali(text string) bool {
+	for _, r := range text {
+		if r >= 'ঀ' && r <= '৿' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package slug
+
+import "testing"
+
+func TestTransliterateBengali(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"রহিম", "rahim"},
+		{"ঢাকা", "dhaka"},
+		{"বাংলা", "bangla"},
+		{"কমল", "kamal"},                                   // inherent vowels, dropped at the end of the word
+		{"কমল হক", "kamal hak"},                            // and before a space
+		{"আকাশ", "akash"},                                  // initial vowel
+		{"শান্ত", "shant"},                                 // hasanta drops the inherent vowel
+		{"বিদ্যুৎ", "bidyut"},                              // ya-phala and khanda ta
+		{"হঠাৎ", "hathat"},                                 // khanda ta after a consonant with its inherent vowel
+		{"\u09AC\u09DC", "bar"},                            // precomposed nukta form
+		{"\u09AC\u09A1\u09BC", "bar"},                      // decomposed nukta form
+		{"\u0986\u09AF\u09BC\u09C7\u09B6\u09BE", "ayesha"}, // decomposed ya with a vowel sign
+		{"চাঁদ", "chad"},                                   // chandrabindu
+		{"দুঃখ", "duhkh"},                                  // visarga
+		{"ঢাকা ১২৩০", "dhaka 1230"},                        // Bangla digits
+		{"Pathao লিমিটেড", "Pathao limited"},
+		{"Café", "Café"}, // no Bangla, left alone
+	}
+	for _, tt := range tests {
+		if got := transliterateBengali(tt.text); got != tt.want {
+			t.Errorf("transliterateBengali(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
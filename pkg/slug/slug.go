@@ -0,0 +1,84 @@
+// Package slug builds URL-friendly identifiers from names, transliterating Bangla to Latin script.
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxLength is the longest slug Make returns, leaving room for a uniqueness suffix
+const MaxLength = 80
+
+// Make lowercases text, transliterates Bangla and joins the remaining letters and digits with hyphens.
+// It returns "" when nothing usable is left.
+func Make(text string) string {
+	text = latinFold.Replace(strings.ToLower(transliterateBengali(text)))
+
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range text {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		// Apostrophes are dropped so "Rahim's" becomes "rahims" rather than "rahim-s"
+		if r == '\'' || r == '’' {
+			continue
+		}
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || r < unicode.MaxASCII {
+			pendingHyphen = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > MaxLength {
+		slug = slug[:MaxLength]
+		if i := strings.LastIndexByte(slug, '-'); i > MaxLength/2 {
+			slug = slug[:i]
+		}
+		slug = strings.TrimRight(slug, "-")
+	}
+	return slug
+}
+
+// latinFold strips accents from common Latin letters so "Café" becomes "cafe"
+var latinFold = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y",
+)
+
+// Unique returns base, or base followed by -2, -3, ... for the first candidate that is not taken
+func Unique(base string, taken func(candidate string) (bool, error)) (string, error) {
+	candidate := base
+	for n := 2; ; n++ {
+		exists, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = base + "-" + strconv.Itoa(n)
+	}
+}
+
+// IsNumeric reports whether s is a plain number, which is treated as an ID rather than a slug
+func IsNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package slug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Pathao Ltd.", "pathao-ltd"},
+		{"  --Hello,   World!! ", "hello-world"},
+		{"Rahim's Kitchen", "rahims-kitchen"},
+		{"Rahim’s Kitchen", "rahims-kitchen"},
+		{"C++ & Go", "c-go"},
+		{"Café Déjà Vu", "cafe-deja-vu"},
+		{"ÉCOLE Naïve", "ecole-naive"},
+		{"রহিম স্টোর", "rahim-stor"},
+		{"বাংলা IT ২০২৪", "bangla-it-2024"},
+		{"2024", "2024"},
+		{"日本", ""},
+		{"!!!", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Make(tt.text); got != tt.want {
+			t.Errorf("Make(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMakeTruncates(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"single word", strings.Repeat("a", 100), strings.Repeat("a", MaxLength)},
+		{"at a word boundary", strings.Repeat("abcdefghi ", 10), strings.TrimSuffix(strings.Repeat("abcdefghi-", 8), "-")},
+		{"mid word when the last hyphen is early", "a " + strings.Repeat("b", 100), "a-" + strings.Repeat("b", MaxLength-2)},
+		{"exactly the limit", strings.Repeat("a", MaxLength), strings.Repeat("a", MaxLength)},
+	}
+	for _, tt := range tests {
+		got := Make(tt.text)
+		if got != tt.want {
+			t.Errorf("%s: Make() = %q, want %q", tt.name, got, tt.want)
+		}
+		if len(got) > MaxLength || strings.HasSuffix(got, "-") {
+			t.Errorf("%s: Make() = %q is longer than %d or ends in a hyphen", tt.name, got, MaxLength)
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		name  string
+		taken []string
+		want  string
+	}{
+		{"free", nil, "acme"},
+		{"taken", []string{"acme"}, "acme-2"},
+		{"suffixes taken", []string{"acme", "acme-2", "acme-3"}, "acme-4"},
+		{"only a suffix taken", []string{"acme-2"}, "acme"},
+	}
+	for _, tt := range tests {
+		var asked []string
+		got, err := Unique("acme", func(candidate string) (bool, error) {
+			asked = append(asked, candidate)
+			for _, s := range tt.taken {
+				if s == candidate {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		if err != nil || got != tt.want {
+			t.Errorf("%s: Unique() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+		if asked[len(asked)-1] != got {
+			t.Errorf("%s: Unique() returned %q without checking it last, checked %q", tt.name, got, asked)
+		}
+	}
+
+	failure := errors.New("database down")
+	if got, err := Unique("acme", func(string) (bool, error) { return false, failure }); !errors.Is(err, failure) || got != "" {
+		t.Errorf("Unique() with a failing check = %q, %v, want the error", got, err)
+	}
+}
+
+func TestIsNumeric(t *testing.T) {
+	tests := map[string]bool{
+		"123": true,
+		"0":   true,
+		"":    false,
+		"12a": false,
+		"-12": false,
+		"1.5": false,
+		"১২৩": false, // Bangla digits never reach a slug untransliterated
+	}
+	for s, want := range tests {
+		if got := IsNumeric(s); got != want {
+			t.Errorf("IsNumeric(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
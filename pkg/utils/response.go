@@ -28,6 +28,15 @@ type PaginationResult struct {
 	TotalPages int         `json:"total_pages"`
 }
 
+// CursorResult represents a page of cursor-paginated data.
+// Pass NextCursor back as ?cursor= to fetch the following page.
+type CursorResult struct {
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more"`
+	Limit      int         `json:"limit"`
+}
+
 // RespondJSON sends a JSON response
 func RespondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -47,6 +47,10 @@ func formatValidationError(err validator.FieldError) string {
 		return err.Field() + " must be at most " + err.Param() + " characters"
 	case "oneof":
 		return err.Field() + " must be one of: " + err.Param()
+	case "gtfield":
+		return err.Field() + " must be after " + err.Param()
+	case "url", "http_url":
+		return err.Field() + " must be a valid URL"
 	default:
 		return err.Field() + " is invalid"
 	}
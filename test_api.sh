@@ -282,7 +282,7 @@ run_test "Review Company"
 REVIEW=$(curl -s -X POST "$BASE_URL/companies/$COMPANY_PROFILE_ID/reviews" \
   -H "Authorization: Bearer $DEV_TOKEN" \
   -H "Content-Type: application/json" \
-  -d '{"content":"Excellent company culture and great benefits. Highly recommend!"}')
+  -d '{"title":"Great culture","pros":"Excellent company culture and great benefits.","cons":"Long release cycles on some teams.","employment_status":"current","job_title":"Software Engineer"}')
 
 if echo $REVIEW | grep -q "success.*true"; then
     echo "Review created (pending approval)"